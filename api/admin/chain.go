@@ -6,6 +6,7 @@ package admin
 import (
 	"net/http"
 
+	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
 )
 
@@ -25,3 +26,70 @@ func (service *Admin) GetChainAliases(r *http.Request, args *GetChainAliasesArgs
 	reply.Aliases = service.chainManager.Aliases(ID)
 	return nil
 }
+
+// ChainArgs are the arguments for the chain lifecycle API calls
+type ChainArgs struct {
+	// Alias of the chain
+	// Can also be the string representation of the chain's ID
+	Chain string `json:"chain"`
+}
+
+// StopChain shuts down the handler, engine and VM of the chain and removes its
+// API endpoints. The chain can be started again with RestartChain.
+func (service *Admin) StopChain(_ *http.Request, args *ChainArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: StopChain called with Chain: %s", args.Chain)
+
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	// Removing the chain's API endpoints requires the http write lock
+	if err := service.httpServer.CallWithReadLock(func() error {
+		return service.chainManager.StopChain(chainID)
+	}); err != nil {
+		return err
+	}
+
+	reply.Success = true
+	return nil
+}
+
+// RestartChain stops the chain, if it's running, and starts it again from its
+// persisted state
+func (service *Admin) RestartChain(_ *http.Request, args *ChainArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: RestartChain called with Chain: %s", args.Chain)
+
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	// Replacing the chain's API endpoints requires the http write lock
+	if err := service.httpServer.CallWithReadLock(func() error {
+		return service.chainManager.RestartChain(chainID)
+	}); err != nil {
+		return err
+	}
+
+	reply.Success = true
+	return nil
+}
+
+// RebootstrapChain stops the chain, if it's running, and starts it again with
+// its pending bootstrapping jobs discarded, so that it resyncs with its
+// beacons. Progress can be followed with info.isBootstrapped.
+func (service *Admin) RebootstrapChain(_ *http.Request, args *ChainArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: RebootstrapChain called with Chain: %s", args.Chain)
+
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	if err := service.httpServer.CallWithReadLock(func() error {
+		return service.chainManager.RebootstrapChain(chainID)
+	}); err != nil {
+		return err
+	}
+
+	reply.Success = true
+	return nil
+}
@@ -0,0 +1,241 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/snow/triggers"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/timestampvm"
+
+	avcon "github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+)
+
+const requestTimeout = 5 * time.Second
+
+// freePort returns a port that is currently free on the loopback interface
+func freePort(t *testing.T) uint16 {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	if err := listener.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return uint16(port)
+}
+
+// newTestChainManager returns a chain manager that registers the APIs of its
+// chains with [server]. Chains are validated by the primary network, which has
+// no validators, so they finish bootstrapping as soon as they're created.
+func newTestChainManager(t *testing.T, server *api.Server) chains.Manager {
+	log := logging.NoLog{}
+	db := memdb.New()
+
+	timeoutManager := &timeout.Manager{}
+	if err := timeoutManager.Initialize(&timer.AdaptiveTimeoutConfig{
+		InitialTimeout: time.Millisecond,
+		MinimumTimeout: time.Millisecond,
+		MaximumTimeout: 10 * time.Second,
+		TimeoutInc:     2 * time.Millisecond,
+		TimeoutDec:     time.Millisecond,
+		Namespace:      "",
+		Registerer:     prometheus.NewRegistry(),
+	}, benchlist.NewNoBenchlist()); err != nil {
+		t.Fatal(err)
+	}
+	go timeoutManager.Dispatch()
+
+	chainRouter := &router.ChainRouter{}
+	chainRouter.Initialize(ids.ShortEmpty, log, timeoutManager, time.Hour, time.Second, ids.Set{}, nil)
+
+	vdrs := validators.NewManager()
+	if err := vdrs.Set(constants.PrimaryNetworkID, validators.NewSet()); err != nil {
+		t.Fatal(err)
+	}
+
+	keystoreService := &keystore.Keystore{}
+	if err := keystoreService.Initialize(log, memdb.New()); err != nil {
+		t.Fatal(err)
+	}
+	sharedMemory := &atomic.Memory{}
+	if err := sharedMemory.Initialize(log, memdb.New()); err != nil {
+		t.Fatal(err)
+	}
+
+	decisionEvents := &triggers.EventDispatcher{}
+	decisionEvents.Initialize(log)
+	consensusEvents := &triggers.EventDispatcher{}
+	consensusEvents.Initialize(log)
+
+	vmManager := vms.NewManager(server, log)
+	if err := vmManager.RegisterVMFactory(timestampvm.ID, &timestampvm.Factory{}); err != nil {
+		t.Fatal(err)
+	}
+
+	whitelistedSubnets := ids.Set{}
+	whitelistedSubnets.Add(constants.PrimaryNetworkID)
+
+	manager := chains.New(&chains.ManagerConfig{
+		MaxPendingMsgs:          1024,
+		MaxNonStakerPendingMsgs: router.DefaultMaxNonStakerPendingMsgs,
+		StakerMSGPortion:        router.DefaultStakerPortion,
+		StakerCPUPortion:        router.DefaultStakerPortion,
+		Log:                     log,
+		LogFactory:              logging.NoFactory{},
+		VMManager:               vmManager,
+		DecisionEvents:          decisionEvents,
+		ConsensusEvents:         consensusEvents,
+		DB:                      db,
+		Router:                  chainRouter,
+		ConsensusParams: avcon.Parameters{
+			Parameters: snowball.Parameters{
+				Metrics:           prometheus.NewRegistry(),
+				K:                 1,
+				Alpha:             1,
+				BetaVirtuous:      1,
+				BetaRogue:         1,
+				ConcurrentRepolls: 1,
+			},
+			Parents:   2,
+			BatchSize: 1,
+		},
+		Validators:         vdrs,
+		NodeID:             ids.GenerateTestShortID(),
+		Server:             server,
+		Keystore:           keystoreService,
+		AtomicMemory:       sharedMemory,
+		WhitelistedSubnets: whitelistedSubnets,
+		TimeoutManager:     timeoutManager,
+		HealthService:      health.NewService(log),
+	})
+	manager.AddRegistrant(server)
+	return manager
+}
+
+// Ensure chains can be stopped and restarted through the admin API while the
+// API server is serving requests, and that their endpoints are removed and
+// added back
+func TestStopAndRestartChain(t *testing.T) {
+	port := freePort(t)
+	uri := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	server := &api.Server{}
+	if err := server.Initialize(logging.NoLog{}, logging.NoFactory{}, "127.0.0.1", port, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	manager := newTestChainManager(t, server)
+	defer manager.Shutdown()
+
+	service, err := NewService(logging.NoLog{}, manager, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.AddRoute(service, &sync.RWMutex{}, "admin", "", logging.NoLog{}); err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = server.Dispatch() }()
+	defer func() {
+		if err := server.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	chainID := ids.GenerateTestID()
+	manager.ForceCreateChain(chains.ChainParameters{
+		ID:          chainID,
+		SubnetID:    constants.PrimaryNetworkID,
+		GenesisData: []byte{1},
+		VMAlias:     timestampvm.ID.String(),
+	})
+
+	client := NewClient(uri, requestTimeout)
+	chainRequester := rpc.NewEndpointRequester(uri, "/ext/bc/"+chainID.String(), "timestamp", requestTimeout)
+	getBlock := func() error {
+		return chainRequester.SendRequest("getBlock", &timestampvm.GetBlockArgs{}, &timestampvm.GetBlockReply{})
+	}
+
+	// Wait for the API server to start listening
+	for start := time.Now(); getBlock() != nil; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > requestTimeout {
+			t.Fatal("the chain's API should have been served")
+		}
+	}
+
+	// Each call is made while the API server holds its read lock. If the admin
+	// API didn't release it, removing the chain's routes would deadlock.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		if success, err := client.StopChain(chainID.String()); err != nil {
+			t.Error(err)
+			return
+		} else if !success {
+			t.Error("should have stopped the chain")
+			return
+		}
+		if manager.IsBootstrapped(chainID) {
+			t.Error("the chain shouldn't be running")
+		}
+		if err := getBlock(); err == nil {
+			t.Error("the chain's API should have been removed")
+		}
+		if _, err := client.StopChain(chainID.String()); err == nil {
+			t.Error("shouldn't be able to stop a chain that isn't running")
+		}
+
+		if success, err := client.RestartChain(chainID.String()); err != nil {
+			t.Error(err)
+			return
+		} else if !success {
+			t.Error("should have restarted the chain")
+			return
+		}
+		if !manager.IsBootstrapped(chainID) {
+			t.Error("the chain should be running again")
+		}
+		if err := getBlock(); err != nil {
+			t.Errorf("the chain's API should be served again: %s", err)
+		}
+
+		if success, err := client.RebootstrapChain(chainID.String()); err != nil {
+			t.Error(err)
+		} else if !success {
+			t.Error("should have re-bootstrapped the chain")
+		} else if err := getBlock(); err != nil {
+			t.Errorf("the chain's API should be served again: %s", err)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(4 * requestTimeout):
+		t.Fatal("the API server deadlocked")
+	}
+}
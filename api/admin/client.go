@@ -76,3 +76,30 @@ func (c *Client) Stacktrace() (bool, error) {
 	err := c.requester.SendRequest("stacktrace", struct{}{}, res)
 	return res.Success, err
 }
+
+// StopChain ...
+func (c *Client) StopChain(chain string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("stopChain", &ChainArgs{
+		Chain: chain,
+	}, res)
+	return res.Success, err
+}
+
+// RestartChain ...
+func (c *Client) RestartChain(chain string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("restartChain", &ChainArgs{
+		Chain: chain,
+	}, res)
+	return res.Success, err
+}
+
+// RebootstrapChain ...
+func (c *Client) RebootstrapChain(chain string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("rebootstrapChain", &ChainArgs{
+		Chain: chain,
+	}, res)
+	return res.Success, err
+}
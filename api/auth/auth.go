@@ -20,8 +20,14 @@ const (
 	// Endpoint is the base of the auth URL
 	Endpoint = "auth"
 
+	// TokenProtocolPrefix prefixes an auth token passed as a websocket
+	// subprotocol, since browsers can't set headers on websocket handshakes
+	TokenProtocolPrefix = "bearer."
+
 	headerKey      = "Authorization"
 	headerValStart = "Bearer "
+	queryKey       = "token"
+	protocolKey    = "Sec-WebSocket-Protocol"
 )
 
 var (
@@ -34,6 +40,10 @@ var (
 	errWrongPassword      = errors.New("incorrect password")
 	errInvalidTokenFormat = errors.New("token is invalid format")
 	errSamePassword       = errors.New("new password can't be same as old password")
+	errTokenExpired       = errors.New("invalid auth token. Is it expired?")
+	errWrongClaims        = errors.New("expected auth token's claims to be type endpointClaims but is different type")
+	errNoAccess           = errors.New("the provided auth token does not allow access to this endpoint")
+	errTokenRevoked       = errors.New("the provided auth token was revoked")
 )
 
 // Auth handles HTTP API authorization for this node
@@ -61,13 +71,16 @@ func (auth *Auth) getTokenKey(*jwt.Token) (interface{}, error) {
 	return auth.Password.Password[:], nil
 }
 
-// getToken gets the JWT token from the request header
+// GetToken gets the JWT token from the request
 // Assumes the header is this form:
 // "Authorization": "Bearer TOKEN.GOES.HERE"
-func getToken(r *http.Request) (string, error) {
+// If the header isn't set, the token can be given in the [token] query
+// parameter or, for websocket handshakes, as the subprotocol
+// "bearer.TOKEN.GOES.HERE"
+func GetToken(r *http.Request) (string, error) {
 	rawHeader := r.Header.Get(headerKey) // Should be "Bearer AUTH.TOKEN.HERE"
 	if rawHeader == "" {
-		return "", ErrNoToken
+		return getTokenFromURLOrProtocol(r)
 	}
 	if !strings.HasPrefix(rawHeader, headerValStart) {
 		return "", errInvalidTokenFormat
@@ -75,6 +88,21 @@ func getToken(r *http.Request) (string, error) {
 	return rawHeader[len(headerValStart):], nil // Returns actual auth token. Slice guaranteed to not go OOB
 }
 
+func getTokenFromURLOrProtocol(r *http.Request) (string, error) {
+	if tokenStr := r.URL.Query().Get(queryKey); tokenStr != "" {
+		return tokenStr, nil
+	}
+	for _, protocols := range r.Header[protocolKey] {
+		for _, protocol := range strings.Split(protocols, ",") {
+			protocol = strings.TrimSpace(protocol)
+			if strings.HasPrefix(protocol, TokenProtocolPrefix) {
+				return protocol[len(TokenProtocolPrefix):], nil
+			}
+		}
+	}
+	return "", ErrNoToken
+}
+
 // Create and return a new token that allows access to each API endpoint such
 // that the API's path ends with an element of [endpoints]
 // If one of the elements of [endpoints] is "*", allows access to all APIs
@@ -106,6 +134,44 @@ func (auth *Auth) newToken(password string, endpoints []string) (string, error)
 
 }
 
+// CheckToken returns nil iff [tokenStr] is a valid, unexpired and unrevoked
+// token that allows access to the API at [path]
+func (auth *Auth) CheckToken(tokenStr, path string) error {
+	auth.lock.RLock()
+	defer auth.lock.RUnlock()
+
+	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, auth.getTokenKey)
+	if err != nil { // Probably because signature wrong
+		return fmt.Errorf("invalid auth token: %w", err)
+	}
+	if !token.Valid { // Check that token isn't expired
+		return errTokenExpired
+	}
+
+	// Make sure this token gives access to the requested endpoint
+	claims, ok := token.Claims.(*endpointClaims)
+	if !ok {
+		return errWrongClaims
+	}
+	canAccess := false // true iff the token authorizes access to the API
+	for _, endpoint := range claims.Endpoints {
+		if endpoint == "*" || strings.HasSuffix(path, endpoint) {
+			canAccess = true
+			break
+		}
+	}
+	if !canAccess {
+		return errNoAccess
+	}
+
+	for _, revokedToken := range auth.revoked { // Make sure this token wasn't revoked
+		if revokedToken == tokenStr {
+			return errTokenRevoked
+		}
+	}
+	return nil
+}
+
 // Revokes the token whose string repr. is [tokenStr]; it will not be accepted as authorization for future API calls.
 // If the token is invalid, this is a no-op.
 // Only currently valid tokens can be revoked
@@ -173,7 +239,7 @@ func (auth *Auth) WrapHandler(h http.Handler) http.Handler {
 			return
 		}
 
-		tokenStr, err := getToken(r) // Get the token from the header
+		tokenStr, err := GetToken(r) // Get the token from the request
 		if err == ErrNoToken {
 			w.WriteHeader(http.StatusUnauthorized)
 			// Error is intentionally dropped here as there is nothing left to
@@ -195,62 +261,14 @@ func (auth *Auth) WrapHandler(h http.Handler) http.Handler {
 			return
 		}
 
-		auth.lock.RLock()
-		token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, auth.getTokenKey)
-		auth.lock.RUnlock()
-
-		if err != nil { // Probably because signature wrong
-			w.WriteHeader(http.StatusUnauthorized)
-			// Error is intentionally dropped here as there is nothing left to
-			// do with it.
-			_, _ = io.WriteString(w, fmt.Sprintf("invalid auth token: %s", err))
-			return
-		}
-		if !token.Valid { // Check that token isn't expired
-			w.WriteHeader(http.StatusUnauthorized)
-			// Error is intentionally dropped here as there is nothing left to
-			// do with it.
-			_, _ = io.WriteString(w, "invalid auth token. Is it expired?")
-			return
-		}
-
-		// Make sure this token gives access to the requested endpoint
-		claims, ok := token.Claims.(*endpointClaims)
-		if !ok {
+		if err := auth.CheckToken(tokenStr, r.URL.Path); err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
 			// Error is intentionally dropped here as there is nothing left to
 			// do with it.
-			_, _ = io.WriteString(w, "expected auth token's claims to be type endpointClaims but is different type")
-			return
-		}
-		canAccess := false // true iff the token authorizes access to the API
-		for _, endpoint := range claims.Endpoints {
-			if endpoint == "*" || strings.HasSuffix(r.URL.Path, endpoint) {
-				canAccess = true
-				break
-			}
-		}
-		if !canAccess {
-			w.WriteHeader(http.StatusUnauthorized)
-			// Error is intentionally dropped here as there is nothing left to
-			// do with it.
-			_, _ = io.WriteString(w, "the provided auth token does not allow access to this endpoint")
+			_, _ = io.WriteString(w, err.Error())
 			return
 		}
 
-		auth.lock.RLock()
-		for _, revokedToken := range auth.revoked { // Make sure this token wasn't revoked
-			if revokedToken == tokenStr {
-				w.WriteHeader(http.StatusUnauthorized)
-				// Error is intentionally dropped here as there is nothing left
-				// to do with it.
-				_, _ = io.WriteString(w, "the provided auth token was revoked")
-				auth.lock.RUnlock()
-				return
-			}
-		}
-		auth.lock.RUnlock()
-
 		h.ServeHTTP(w, r) // Authorization successful
 	})
 }
@@ -75,6 +75,14 @@ type FormattedTx struct {
 	Encoding formatting.Encoding `json:"encoding"`
 }
 
+// GetTxReply defines the response of GetTx. If [Encoding] is formatting.JSON,
+// [Tx] is the decoded transaction. Otherwise, it's the string representation of
+// the transaction's bytes.
+type GetTxReply struct {
+	Tx       interface{}         `json:"tx"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
 // Index is an address and an associated UTXO.
 // Marks a starting or stopping point when fetching UTXOs. Used for pagination.
 type Index struct {
@@ -87,6 +87,11 @@ func (h *Health) RegisterCheck(c checks.Check) error {
 	})
 }
 
+// DeregisterCheck removes the check with the given name, if it exists
+func (h *Health) DeregisterCheck(name string) {
+	h.health.Deregister(name)
+}
+
 // GetLivenessArgs are the arguments for GetLiveness
 type GetLivenessArgs struct{}
 
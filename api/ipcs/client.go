@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
 
@@ -39,3 +40,16 @@ func (c *Client) UnpublishBlockchain(blockchainID string) (bool, error) {
 	}, res)
 	return res.Success, err
 }
+
+// GetEvents returns up to [limit] events recorded in the event log of
+// [stream] on the blockchain, starting with the event at [fromSequence]
+func (c *Client) GetEvents(blockchainID, stream string, fromSequence uint64, limit uint32) (*GetEventsReply, error) {
+	res := &GetEventsReply{}
+	err := c.requester.SendRequest("getEvents", &GetEventsArgs{
+		BlockchainID: blockchainID,
+		Stream:       stream,
+		FromSequence: json.Uint64(fromSequence),
+		Limit:        json.Uint32(limit),
+	}, res)
+	return res, err
+}
@@ -11,12 +11,19 @@ import (
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ipcs"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
+const (
+	// Maximum number of events returned by GetEvents
+	maxGetEventsLimit = 1024
+)
+
 // IPCServer maintains the IPCs
 type IPCServer struct {
 	httpServer   *api.Server
@@ -52,6 +59,9 @@ type PublishBlockchainArgs struct {
 type PublishBlockchainReply struct {
 	ConsensusURL string `json:"consensusURL"`
 	DecisionsURL string `json:"decisionsURL"`
+	// Sockets that publish issue, accept and reject events in envelopes
+	ConsensusEventsURL string `json:"consensusEventsURL"`
+	DecisionsEventsURL string `json:"decisionsEventsURL"`
 }
 
 // PublishBlockchain publishes the finalized accepted transactions from the blockchainID over the IPC
@@ -71,6 +81,8 @@ func (ipc *IPCServer) PublishBlockchain(r *http.Request, args *PublishBlockchain
 
 	reply.ConsensusURL = ipcs.ConsensusURL()
 	reply.DecisionsURL = ipcs.DecisionsURL()
+	reply.ConsensusEventsURL = ipcs.ConsensusEventsURL()
+	reply.DecisionsEventsURL = ipcs.DecisionsEventsURL()
 
 	return nil
 }
@@ -97,3 +109,94 @@ func (ipc *IPCServer) UnpublishBlockchain(r *http.Request, args *UnpublishBlockc
 	reply.Success = true
 	return err
 }
+
+// GetEventsArgs are the arguments for calling GetEvents
+type GetEventsArgs struct {
+	BlockchainID string `json:"blockchainID"`
+	// Either "consensus" or "decisions"
+	Stream string `json:"stream"`
+	// Sequence number of the first event to return. Ignored if
+	// [FromContainerID] is provided.
+	FromSequence json.Uint64 `json:"fromSequence"`
+	// If provided, the events after the event with this container are returned
+	FromContainerID string      `json:"fromContainerID"`
+	Limit           json.Uint32 `json:"limit"`
+}
+
+// GetEventsReply are the results from calling GetEvents
+type GetEventsReply struct {
+	// The containers are hex encoded
+	Events []ipcs.StreamMessage `json:"events"`
+	// Sequence number of the oldest event still in the log
+	FirstSequence json.Uint64 `json:"firstSequence"`
+	// Sequence number to pass as [FromSequence] to get the following events
+	NextSequence json.Uint64 `json:"nextSequence"`
+}
+
+// GetEvents returns the events recorded in the event log of a blockchain,
+// allowing consumers to resume from the last event they processed
+func (ipc *IPCServer) GetEvents(r *http.Request, args *GetEventsArgs, reply *GetEventsReply) error {
+	ipc.log.Info("IPCs: GetEvents called with BlockchainID: %s, Stream: %s", args.BlockchainID, args.Stream)
+	chainID, err := ipc.chainManager.Lookup(args.BlockchainID)
+	if err != nil {
+		ipc.log.Error("unknown blockchainID %s: %s", args.BlockchainID, err)
+		return err
+	}
+
+	l, err := ipc.ipcs.GetLog(chainID, args.Stream)
+	if err != nil {
+		return err
+	}
+
+	start := uint64(args.FromSequence)
+	if args.FromContainerID != "" {
+		containerID, err := ids.FromString(args.FromContainerID)
+		if err != nil {
+			return fmt.Errorf("problem parsing containerID %q: %w", args.FromContainerID, err)
+		}
+		seq, err := l.SequenceOf(containerID)
+		if err != nil {
+			return fmt.Errorf("couldn't find container %s: %w", containerID, err)
+		}
+		start = seq + 1
+	}
+	first, next := l.Bounds()
+	if start == 0 {
+		start = first
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || limit > maxGetEventsLimit {
+		limit = maxGetEventsLimit
+	}
+
+	events, err := l.Range(start, limit)
+	if err != nil {
+		return err
+	}
+
+	reply.Events = make([]ipcs.StreamMessage, len(events))
+	for i, event := range events {
+		bytes, err := formatting.Encode(formatting.Hex, event.Container)
+		if err != nil {
+			return fmt.Errorf("couldn't encode container %s: %w", event.ContainerID, err)
+		}
+		reply.Events[i] = ipcs.StreamMessage{
+			Kind:        ipcs.AcceptEvent.String(),
+			ChainID:     chainID.String(),
+			ContainerID: event.ContainerID.String(),
+			Timestamp:   json.Uint64(event.Timestamp),
+			Bytes:       bytes,
+			Sequence:    json.Uint64(event.Sequence),
+		}
+	}
+
+	reply.FirstSequence = json.Uint64(first)
+	reply.NextSequence = json.Uint64(start)
+	if len(events) > 0 {
+		reply.NextSequence = json.Uint64(events[len(events)-1].Sequence + 1)
+	} else if start > next {
+		reply.NextSequence = json.Uint64(next)
+	}
+	return nil
+}
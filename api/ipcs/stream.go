@@ -0,0 +1,119 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/ipcs"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// StreamHandler serves the events of a blockchain over a websocket. The
+// request specifies the blockchain with the [blockchainID] query parameter,
+// the stream ("consensus" or "decisions") with [stream], and optionally the
+// [encoding] and backpressure [policy] to use, and the comma separated list
+// of [events] kinds ("issue", "accept" and "reject") to subscribe to. The stream can be replayed
+// from the blockchain's event log by providing either [fromSequence] or
+// [fromContainerID].
+//
+// Every request must carry an auth token that allows access to the stream,
+// even if API authorization is disabled. As browsers can't set headers on
+// websocket handshakes, the token can also be given in the [token] query
+// parameter or as the subprotocol "bearer.TOKEN.GOES.HERE".
+type StreamHandler struct {
+	log          logging.Logger
+	chainManager chains.Manager
+	ipcs         *ipcs.ChainIPCs
+	auth         *auth.Auth
+}
+
+// NewStreamHandler returns a new handler for websocket IPC streams
+func NewStreamHandler(log logging.Logger, chainManager chains.Manager, ipcs *ipcs.ChainIPCs, apiAuth *auth.Auth) *common.HTTPHandler {
+	return &common.HTTPHandler{
+		LockOptions: common.NoLock,
+		Handler: &StreamHandler{
+			log:          log,
+			chainManager: chainManager,
+			ipcs:         ipcs,
+			auth:         apiAuth,
+		},
+	}
+}
+
+// ServeHTTP implements the http.Handler interface
+func (h *StreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tokenStr, err := auth.GetToken(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := h.auth.CheckToken(tokenStr, r.URL.Path); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	blockchainID := query.Get("blockchainID")
+	stream := query.Get("stream")
+	h.log.Info("IPCs: websocket stream requested for BlockchainID: %s, stream: %s", blockchainID, stream)
+
+	chainID, err := h.chainManager.Lookup(blockchainID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Only chains this node runs have events to stream
+	if _, err := h.chainManager.SubnetID(chainID); err != nil {
+		http.Error(w, fmt.Sprintf("unknown blockchain %s: %s", chainID, err), http.StatusNotFound)
+		return
+	}
+
+	events, err := ipcs.ParseEventFilter(query.Get("events"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts := ipcs.StreamOptions{
+		Encoding: query.Get("encoding"),
+		Policy:   query.Get("policy"),
+		Events:   events,
+	}
+	// Browsers reject handshakes that don't accept the requested subprotocol
+	for _, protocol := range websocket.Subprotocols(r) {
+		if protocol == auth.TokenProtocolPrefix+tokenStr {
+			opts.Subprotocol = protocol
+			break
+		}
+	}
+	if fromSequence := query.Get("fromSequence"); fromSequence != "" {
+		opts.FromSequence, err = strconv.ParseUint(fromSequence, 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("problem parsing fromSequence: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+	if fromContainerID := query.Get("fromContainerID"); fromContainerID != "" {
+		opts.FromContainerID, err = ids.FromString(fromContainerID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("problem parsing fromContainerID: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	err = h.ipcs.ServeStream(w, r, chainID, stream, opts)
+	if err != nil {
+		h.log.Debug("couldn't serve websocket stream of %s: %s", chainID, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,203 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/ipcs"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/triggers"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const testPassword = "ipcs-stream-password"
+
+var errUnknownChain = errors.New("unknown chain")
+
+// testChainManager only runs [chainID]
+type testChainManager struct {
+	chains.MockManager
+	chainID ids.ID
+}
+
+func (m *testChainManager) SubnetID(chainID ids.ID) (ids.ID, error) {
+	if chainID != m.chainID {
+		return ids.ID{}, errUnknownChain
+	}
+	return ids.Empty, nil
+}
+
+// newTestStreamServer returns a server that streams the events of
+// [ctx.ChainID]. API authorization is disabled, so only the stream handler
+// checks auth tokens.
+func newTestStreamServer(t *testing.T) (*triggers.EventDispatcher, *snow.Context, *httptest.Server) {
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+
+	consensusEvents := &triggers.EventDispatcher{}
+	consensusEvents.Initialize(logging.NoLog{})
+	decisionEvents := &triggers.EventDispatcher{}
+	decisionEvents.Initialize(logging.NoLog{})
+
+	cipcs, err := ipcs.NewChainIPCs(logging.NoLog{}, ipcs.DefaultBaseURL, 12345, consensusEvents, decisionEvents, nil, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &auth.Auth{}
+	if err := a.Password.Set(testPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/ext/auth", auth.NewService(logging.NoLog{}, a).Handler)
+	mux.Handle("/ext/ipcs/ws", NewStreamHandler(logging.NoLog{}, &testChainManager{chainID: ctx.ChainID}, cipcs, a).Handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(func() {
+		server.Close()
+		if err := cipcs.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	return decisionEvents, ctx, server
+}
+
+func streamURL(server *httptest.Server, chainID ids.ID) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/ext/ipcs/ws?stream=decisions&blockchainID=" + chainID.String()
+}
+
+func newToken(t *testing.T, server *httptest.Server, endpoints ...string) string {
+	token := &auth.Token{}
+	requester := rpc.NewEndpointRequester(server.URL, "/ext/auth", "auth", time.Second)
+	err := requester.SendRequest("newToken", &auth.NewTokenArgs{
+		Password:  auth.Password{Password: testPassword},
+		Endpoints: endpoints,
+	}, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token.Token
+}
+
+func TestStreamRequiresAuthToken(t *testing.T) {
+	_, ctx, server := newTestStreamServer(t)
+
+	_, resp, err := websocket.DefaultDialer.Dial(streamURL(server, ctx.ChainID), nil)
+	if err == nil {
+		t.Fatal("should have failed to stream without an auth token")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status %d but got %v", http.StatusUnauthorized, resp)
+	}
+
+	header := http.Header{"Authorization": []string{"Bearer not.a.token"}}
+	_, resp, err = websocket.DefaultDialer.Dial(streamURL(server, ctx.ChainID), header)
+	if err == nil {
+		t.Fatal("should have failed to stream with an invalid auth token")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status %d but got %v", http.StatusUnauthorized, resp)
+	}
+
+	token := newToken(t, server, "/ext/info")
+	_, resp, err = websocket.DefaultDialer.Dial(streamURL(server, ctx.ChainID)+"&token="+token, nil)
+	if err == nil {
+		t.Fatal("should have failed to stream with an auth token for another endpoint")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status %d but got %v", http.StatusUnauthorized, resp)
+	}
+}
+
+func TestStreamRejectsCrossOrigin(t *testing.T) {
+	_, ctx, server := newTestStreamServer(t)
+
+	token := newToken(t, server, "/ext/ipcs/ws")
+	header := http.Header{"Origin": []string{"http://attacker.example"}}
+	_, resp, err := websocket.DefaultDialer.Dial(streamURL(server, ctx.ChainID)+"&token="+token, header)
+	if err == nil {
+		t.Fatal("should have failed to stream to another origin")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected status %d but got %v", http.StatusForbidden, resp)
+	}
+}
+
+func TestStreamRejectsUnknownChain(t *testing.T) {
+	_, _, server := newTestStreamServer(t)
+
+	token := newToken(t, server, "/ext/ipcs/ws")
+	_, resp, err := websocket.DefaultDialer.Dial(streamURL(server, ids.GenerateTestID())+"&token="+token, nil)
+	if err == nil {
+		t.Fatal("should have failed to stream a chain the node doesn't run")
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status %d but got %v", http.StatusNotFound, resp)
+	}
+}
+
+func TestStreamSubscribeAndDeliver(t *testing.T) {
+	events, ctx, server := newTestStreamServer(t)
+
+	// Browsers can only pass the auth token as a subprotocol
+	protocol := auth.TokenProtocolPrefix + newToken(t, server, "/ext/ipcs/ws")
+	dialer := websocket.Dialer{Subprotocols: []string{protocol}}
+	conn, _, err := dialer.Dial(streamURL(server, ctx.ChainID), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if conn.Subprotocol() != protocol {
+		t.Fatalf("expected the handshake to accept subprotocol %q but got %q", protocol, conn.Subprotocol())
+	}
+
+	// The consumer may be subscribed after the handshake completes, so the
+	// container is accepted until it is delivered
+	container := []byte{1, 2, 3}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				events.Accept(ctx, ids.GenerateTestID(), container)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	messageType, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if messageType != websocket.BinaryMessage {
+		t.Fatalf("expected a binary message but got type %d", messageType)
+	}
+	p := wrappers.Packer{MaxSize: wrappers.LongLen + len(container)}
+	p.PackLong(uint64(len(container)))
+	p.PackFixedBytes(container)
+	if !bytes.Equal(p.Bytes, data) {
+		t.Fatalf("expected the length prefixed container %v but got %v", p.Bytes, data)
+	}
+}
@@ -0,0 +1,153 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// DefaultPushJob is the job name metrics are pushed under
+	DefaultPushJob = "avalanchego"
+
+	// DefaultPushFileName is the name of the file, in the logs directory,
+	// that metrics are written to
+	DefaultPushFileName = "metrics.prom"
+
+	nodeIDLabel = "node_id"
+)
+
+var (
+	errNoPushDestination = errors.New("either a push gateway URL or a file path must be provided")
+	errNonPositivePeriod = errors.New("push frequency must be positive")
+)
+
+// PushConfig describes where, and how often, metrics are pushed
+type PushConfig struct {
+	// URL of a Pushgateway compatible endpoint. Ignored if empty.
+	GatewayURL string
+
+	// Path of the file the metrics are written to. Ignored if empty.
+	FilePath string
+
+	// Job name the metrics are grouped under on the gateway
+	Job string
+
+	// ID of this node, used to group the metrics on the gateway
+	NodeID string
+
+	// How often the metrics are pushed
+	Frequency time.Duration
+}
+
+// Pusher periodically pushes the metrics of a gatherer. This allows nodes that
+// can't be scraped, for example because they are behind a NAT, to export
+// their metrics.
+type Pusher struct {
+	log      logging.Logger
+	config   PushConfig
+	gatherer prometheus.Gatherer
+	gateway  *push.Pusher
+	repeater *timer.Repeater
+}
+
+// NewPusher returns a new pusher that pushes the metrics of [gatherer] as
+// described by [config]. The pusher doesn't push until Dispatch is called.
+func NewPusher(log logging.Logger, gatherer prometheus.Gatherer, config PushConfig) (*Pusher, error) {
+	if config.GatewayURL == "" && config.FilePath == "" {
+		return nil, errNoPushDestination
+	}
+	if config.Frequency <= 0 {
+		return nil, errNonPositivePeriod
+	}
+	if config.Job == "" {
+		config.Job = DefaultPushJob
+	}
+
+	p := &Pusher{
+		log:      log,
+		config:   config,
+		gatherer: gatherer,
+	}
+	if config.GatewayURL != "" {
+		p.gateway = push.New(config.GatewayURL, config.Job).Gatherer(gatherer)
+		if config.NodeID != "" {
+			p.gateway = p.gateway.Grouping(nodeIDLabel, config.NodeID)
+		}
+	}
+	p.repeater = timer.NewRepeater(p.push, config.Frequency)
+	return p, nil
+}
+
+// Dispatch pushes the metrics every [Frequency] until Stop is called
+func (p *Pusher) Dispatch() { p.repeater.Dispatch() }
+
+// Stop pushing metrics
+func (p *Pusher) Stop() { p.repeater.Stop() }
+
+// Push the metrics once
+func (p *Pusher) Push() error {
+	errs := wrappers.Errs{}
+	if p.gateway != nil {
+		if err := p.gateway.Push(); err != nil {
+			errs.Add(fmt.Errorf("couldn't push metrics to %s: %w", p.config.GatewayURL, err))
+		}
+	}
+	if p.config.FilePath != "" {
+		if err := p.writeFile(); err != nil {
+			errs.Add(fmt.Errorf("couldn't write metrics to %s: %w", p.config.FilePath, err))
+		}
+	}
+	return errs.Err
+}
+
+func (p *Pusher) push() {
+	if err := p.Push(); err != nil {
+		p.log.Warn("%s", err)
+	}
+}
+
+// writeFile writes the metrics to a temporary file in the OpenMetrics text
+// format and then moves it into place, so that readers never observe a
+// partially written file.
+func (p *Pusher) writeFile() error {
+	metricFamilies, err := p.gatherer.Gather()
+	if err != nil {
+		return err
+	}
+
+	tmpPath := p.config.FilePath + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	encoder := expfmt.NewEncoder(file, expfmt.FmtOpenMetrics)
+	errs := wrappers.Errs{}
+	for _, metricFamily := range metricFamilies {
+		if err := encoder.Encode(metricFamily); err != nil {
+			errs.Add(err)
+			break
+		}
+	}
+	if closer, ok := encoder.(expfmt.Closer); ok && !errs.Errored() {
+		errs.Add(closer.Close())
+	}
+	errs.Add(file.Close())
+	if errs.Errored() {
+		return errs.Err
+	}
+	return os.Rename(tmpPath, p.config.FilePath)
+}
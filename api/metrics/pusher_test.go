@@ -0,0 +1,65 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestNewPusherRequiresDestination(t *testing.T) {
+	if _, err := NewPusher(logging.NoLog{}, prometheus.NewRegistry(), PushConfig{Frequency: time.Second}); err == nil {
+		t.Fatal("should have errored due to no push destination")
+	}
+}
+
+func TestPusherWritesFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "test_counter",
+		Help: "counter used for testing",
+	})
+	if err := registry.Register(counter); err != nil {
+		t.Fatal(err)
+	}
+	counter.Inc()
+
+	filePath := filepath.Join(dir, DefaultPushFileName)
+	pusher, err := NewPusher(logging.NoLog{}, registry, PushConfig{
+		FilePath:  filePath,
+		Frequency: time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pusher.Push(); err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics := string(contents)
+	if !strings.Contains(metrics, "test_counter 1") {
+		t.Fatalf("missing counter in pushed metrics:\n%s", metrics)
+	}
+	if !strings.HasSuffix(metrics, "# EOF\n") {
+		t.Fatalf("pushed metrics should be in the OpenMetrics format:\n%s", metrics)
+	}
+}
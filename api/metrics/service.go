@@ -10,14 +10,18 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 )
 
-// NewService returns a new prometheus service
+// NewService returns a new prometheus service. Scrapers that accept the
+// OpenMetrics text format are served OpenMetrics, which includes exemplars.
+// Other scrapers are served the Prometheus text format.
 func NewService() (*prometheus.Registry, *common.HTTPHandler) {
 	registerer := prometheus.NewRegistry()
 	handler := promhttp.InstrumentMetricHandler(
 		registerer,
 		promhttp.HandlerFor(
 			registerer,
-			promhttp.HandlerOpts{},
+			promhttp.HandlerOpts{
+				EnableOpenMetrics: true,
+			},
 		),
 	)
 	return registerer, &common.HTTPHandler{LockOptions: common.NoLock, Handler: handler}
@@ -135,3 +135,37 @@ func (r *router) AddAlias(base string, aliases ...string) error {
 	}
 	return err
 }
+
+// RemoveRouter removes every endpoint registered under [base], and under any of
+// [base]'s aliases. The aliases themselves remain reserved, so that endpoints
+// added to [base] in the future are again reachable through them.
+func (r *router) RemoveRouter(base string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.routeLock.Lock()
+	defer r.routeLock.Unlock()
+
+	if _, exists := r.routes[base]; !exists {
+		return errUnknownBaseURL
+	}
+	delete(r.routes, base)
+	for _, alias := range r.aliases[base] {
+		delete(r.routes, alias)
+	}
+
+	// mux doesn't support removing routes, so the remaining routes are
+	// registered with a fresh router.
+	newRouter := mux.NewRouter()
+	for base, endpoints := range r.routes {
+		for endpoint, handler := range endpoints {
+			url := base + endpoint
+			if route := newRouter.Handle(url, handler); route != nil {
+				route.Name(url)
+			} else {
+				return fmt.Errorf("failed to create new route for %s", url)
+			}
+		}
+	}
+	r.router = newRouter
+	return nil
+}
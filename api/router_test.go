@@ -75,3 +75,39 @@ func TestBlock(t *testing.T) {
 		t.Fatalf("Permanently locked %s", "1")
 	}
 }
+
+func TestRemoveRouter(t *testing.T) {
+	r := newRouter()
+
+	if err := r.AddAlias("1", "2"); err != nil {
+		t.Fatal(err)
+	}
+
+	handler1 := &testHandler{}
+	if err := r.AddRouter("1", "", handler1); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RemoveRouter("1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.GetHandler("1", ""); err == nil {
+		t.Fatalf("Should have removed %s", "1")
+	}
+	if _, err := r.GetHandler("2", ""); err == nil {
+		t.Fatalf("Should have removed alias %s", "2")
+	}
+	if err := r.RemoveRouter("1"); err == nil {
+		t.Fatalf("Should have errored removing unknown route %s", "1")
+	}
+
+	// Re-adding the route should make it reachable through its alias again
+	handler2 := &testHandler{}
+	if err := r.AddRouter("1", "", handler2); err != nil {
+		t.Fatal(err)
+	}
+	if handler, err := r.GetHandler("2", ""); err != nil {
+		t.Fatalf("Should have re-added alias %s", "2")
+	} else if handler != handler2 {
+		t.Fatalf("Registered unknown handler")
+	}
+}
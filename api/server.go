@@ -104,6 +104,11 @@ func (s *Server) DispatchTLS(certFile, keyFile string) error {
 	return http.ServeTLS(listener, handler, certFile, keyFile)
 }
 
+// Auth returns the token authorization of the API server
+func (s *Server) Auth() *auth.Auth {
+	return s.auth
+}
+
 // RegisterChain registers the API endpoints associated with this chain That is,
 // add <route, handler> pairs to server so that http calls can be made to the vm
 func (s *Server) RegisterChain(chainName string, ctx *snow.Context, vmIntf interface{}) {
@@ -137,6 +142,16 @@ func (s *Server) RegisterChain(chainName string, ctx *snow.Context, vmIntf inter
 	}
 }
 
+// UnregisterChain removes the API endpoints associated with this chain, along
+// with the endpoints of the chain's aliases.
+func (s *Server) UnregisterChain(ctx *snow.Context) {
+	s.log.Verbo("About to remove API endpoints for chain with ID %s", ctx.ChainID)
+	url := fmt.Sprintf("%s/bc/%s", baseURL, ctx.ChainID)
+	if err := s.router.RemoveRouter(url); err != nil {
+		s.log.Debug("couldn't remove routes of chain %s: %s", ctx.ChainID, err)
+	}
+}
+
 // AddChainRoute registers a route to a chain's handler
 func (s *Server) AddChainRoute(handler *common.HTTPHandler, ctx *snow.Context, base, endpoint string, loggingWriter io.Writer) error {
 	url := fmt.Sprintf("%s/%s", baseURL, base)
@@ -225,6 +240,17 @@ func (s *Server) AddAliasesWithReadLock(endpoint string, aliases ...string) erro
 	return s.AddAliases(endpoint, aliases...)
 }
 
+// CallWithReadLock calls [f] assuming the http read lock is currently held.
+// The lock is released while [f] runs, so that [f] can add and remove routes,
+// as starting and stopping a chain does.
+func (s *Server) CallWithReadLock(f func() error) error {
+	// This is safe for the same reason as in AddAliasesWithReadLock.
+	s.router.lock.RUnlock()
+	defer s.router.lock.RLock()
+
+	return f()
+}
+
 // Call ...
 func (s *Server) Call(
 	writer http.ResponseWriter,
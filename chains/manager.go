@@ -45,6 +45,23 @@ const (
 	defaultChannelSize = 1024
 )
 
+var (
+	vertexBootstrappingPrefix = []byte("vertex_bs")
+	txBootstrappingPrefix     = []byte("tx_bs")
+	blockBootstrappingPrefix  = []byte("bs")
+
+	// The prefixes of a chain's database that hold bootstrapping jobs
+	bootstrappingPrefixes = [][]byte{
+		vertexBootstrappingPrefix,
+		txBootstrappingPrefix,
+		blockBootstrappingPrefix,
+	}
+
+	errUnknownChain    = errors.New("unknown chain ID")
+	errChainNotRunning = errors.New("chain is not running")
+	errCriticalChain   = errors.New("critical chains can't be stopped")
+)
+
 // Manager manages the chains running on this node.
 // It can:
 //   * Create a chain
@@ -52,6 +69,7 @@ const (
 //     RegisterChain with the new chain as the argument.
 //   * Get the aliases associated with a given chain.
 //   * Get the ID of the chain associated with a given alias.
+//   * Stop, restart and re-bootstrap a chain that was previously created.
 type Manager interface {
 	// Return the router this Manager is using to route consensus messages to chains
 	Router() router.Router
@@ -84,6 +102,19 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Shut down the handler, engine and VM of a running chain and unregister
+	// it from the registrants. The chain can later be started again with
+	// RestartChain.
+	StopChain(ids.ID) error
+
+	// Stop the chain, if it is running, and start it again from its
+	// persisted state
+	RestartChain(ids.ID) error
+
+	// Stop the chain, if it is running, discard its pending bootstrapping
+	// jobs and start it again so that it resyncs with its beacons
+	RebootstrapChain(ids.ID) error
+
 	Shutdown()
 }
 
@@ -133,8 +164,11 @@ type ManagerConfig struct {
 	XChainID                ids.ID
 	CriticalChains          ids.Set          // Chains that can't exit gracefully
 	WhitelistedSubnets      ids.Set          // Subnets to validate
+	PrivateSubnets          ids.Set          // Subnets whose chains only accept messages from the subnet's validators
 	TimeoutManager          *timeout.Manager // Manages request timeouts when sending messages to other validators
 	HealthService           *health.Health
+	// Operator supplied configuration of chains, keyed by chain ID or alias
+	ChainConfigs map[string][]byte
 }
 
 type manager struct {
@@ -148,17 +182,29 @@ type manager struct {
 	unblocked     bool
 	blockedChains []ChainParameters
 
+	// Serializes stopping and restarting of chains
+	lifecycleLock sync.Mutex
+
 	chainsLock sync.Mutex
 	// Key: Chain's ID
 	// Value: The chain
-	chains map[ids.ID]*router.Handler
+	chains map[ids.ID]*chain
+	// Key: Chain's ID
+	// Value: The parameters the chain was created with. Entries remain after
+	// the chain is stopped so that it can be started again.
+	chainParams map[ids.ID]ChainParameters
+
+	// Looks up the validator sets at past heights of the P-Chain. Set once the
+	// P-Chain is created.
+	validatorState validators.State
 }
 
 // New returns a new Manager
 func New(config *ManagerConfig) Manager {
 	m := &manager{
 		ManagerConfig: *config,
-		chains:        make(map[ids.ID]*router.Handler),
+		chains:        make(map[ids.ID]*chain),
+		chainParams:   make(map[ids.ID]ChainParameters),
 	}
 	m.Initialize()
 	return m
@@ -202,16 +248,16 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 		chainParams.VMAlias,
 	)
 
-	chain, err := m.buildChain(chainParams)
+	m.chainsLock.Lock()
+	m.chainParams[chainParams.ID] = chainParams
+	m.chainsLock.Unlock()
+
+	chain, err := m.startChain(chainParams)
 	if err != nil {
 		m.Log.Error("Error while creating new chain: %s", err)
 		return
 	}
 
-	m.chainsLock.Lock()
-	m.chains[chainParams.ID] = chain.Handler
-	m.chainsLock.Unlock()
-
 	// Associate the newly created chain with its default alias
 	m.Log.AssertNoError(m.Alias(chainParams.ID, chainParams.ID.String()))
 
@@ -219,6 +265,144 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 	m.notifyRegistrants(chain.Name, chain.Ctx, chain.VM)
 }
 
+// startChain builds the chain described by [chainParams] and starts routing
+// messages to it
+func (m *manager) startChain(chainParams ChainParameters) (*chain, error) {
+	chain, err := m.buildChain(chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	m.chainsLock.Lock()
+	m.chains[chainParams.ID] = chain
+	m.chainsLock.Unlock()
+	return chain, nil
+}
+
+// restartChain starts a chain that was previously stopped and notifies the
+// registrants. Assumes [m.lifecycleLock] is held.
+func (m *manager) restartChain(chainParams ChainParameters) error {
+	chain, err := m.startChain(chainParams)
+	if err != nil {
+		return err
+	}
+
+	m.notifyRegistrants(chain.Name, chain.Ctx, chain.VM)
+	return nil
+}
+
+// StopChain implements the Manager interface
+func (m *manager) StopChain(chainID ids.ID) error {
+	m.lifecycleLock.Lock()
+	defer m.lifecycleLock.Unlock()
+
+	return m.stopChain(chainID)
+}
+
+// RestartChain implements the Manager interface
+func (m *manager) RestartChain(chainID ids.ID) error {
+	m.lifecycleLock.Lock()
+	defer m.lifecycleLock.Unlock()
+
+	chainParams, err := m.stopChainIfRunning(chainID)
+	if err != nil {
+		return err
+	}
+
+	m.Log.Info("restarting chain %s", chainID)
+	return m.restartChain(chainParams)
+}
+
+// RebootstrapChain implements the Manager interface
+func (m *manager) RebootstrapChain(chainID ids.ID) error {
+	m.lifecycleLock.Lock()
+	defer m.lifecycleLock.Unlock()
+
+	chainParams, err := m.stopChainIfRunning(chainID)
+	if err != nil {
+		return err
+	}
+
+	// The bootstrapping job queues are stored under the chain's prefix, next
+	// to the VM's database. Only the job queues are cleared.
+	db := prefixdb.New(chainID[:], m.DB)
+	for _, prefix := range bootstrappingPrefixes {
+		if err := clearDB(prefixdb.New(prefix, db)); err != nil {
+			return fmt.Errorf("couldn't clear bootstrapping jobs of chain %s: %w", chainID, err)
+		}
+	}
+
+	m.Log.Info("re-bootstrapping chain %s", chainID)
+	return m.restartChain(chainParams)
+}
+
+// stopChainIfRunning stops the chain if it's running and returns the
+// parameters it was created with.
+func (m *manager) stopChainIfRunning(chainID ids.ID) (ChainParameters, error) {
+	m.chainsLock.Lock()
+	chainParams, known := m.chainParams[chainID]
+	_, running := m.chains[chainID]
+	m.chainsLock.Unlock()
+
+	if !known {
+		return ChainParameters{}, errUnknownChain
+	}
+	if !running {
+		return chainParams, nil
+	}
+	return chainParams, m.stopChain(chainID)
+}
+
+// stopChain assumes [m.lifecycleLock] is held
+func (m *manager) stopChain(chainID ids.ID) error {
+	if m.CriticalChains.Contains(chainID) {
+		return errCriticalChain
+	}
+
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	if !exists {
+		_, known := m.chainParams[chainID]
+		m.chainsLock.Unlock()
+		if !known {
+			return errUnknownChain
+		}
+		return errChainNotRunning
+	}
+	delete(m.chains, chainID)
+	m.chainsLock.Unlock()
+
+	m.Log.Info("stopping chain %s", chainID)
+
+	// Stop serving the chain's APIs before its VM is shut down
+	m.notifyUnregistrants(chain.Ctx)
+	m.HealthService.DeregisterCheck(chain.Name)
+
+	// Shuts down the handler, the engine and the VM. Blocks until the chain
+	// has finished shutting down, or the router's shutdown timeout elapses.
+	m.ManagerConfig.Router.RemoveChain(chainID)
+
+	// The benchlist of the chain holds the stopped instance's context. It's
+	// created again when the chain is restarted.
+	m.TimeoutManager.RemoveChain(chainID)
+	return nil
+}
+
+// chainConfig returns the operator supplied configuration of [chainID]. The
+// configuration keyed by the chain's ID takes precedence over the
+// configurations keyed by its aliases.
+func (m *manager) chainConfig(chainID ids.ID) []byte {
+	if config, ok := m.ChainConfigs[chainID.String()]; ok {
+		return config
+	}
+	for _, alias := range m.Aliases(chainID) {
+		if config, ok := m.ChainConfigs[alias]; ok {
+			return config
+		}
+	}
+	return nil
+}
+
 // Create a chain
 func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 	vmID, err := m.VMManager.Lookup(chainParams.VMAlias)
@@ -237,6 +421,10 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		return nil, fmt.Errorf("error while creating chain's log %w", err)
 	}
 
+	// Collectors left behind by a previous instance of this chain are
+	// replaced, so the chain can be restarted.
+	registerer := newChainRegisterer(m.ConsensusParams.Metrics)
+
 	ctx := &snow.Context{
 		NetworkID:           m.NetworkID,
 		SubnetID:            chainParams.SubnetID,
@@ -251,8 +439,10 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		SharedMemory:        m.AtomicMemory.NewSharedMemory(chainParams.ID),
 		BCLookup:            m,
 		SNLookup:            m,
+		ValidatorState:      m.validatorState,
 		Namespace:           fmt.Sprintf("%s_%s_vm", constants.PlatformName, primaryAlias),
-		Metrics:             m.ConsensusParams.Metrics,
+		Metrics:             registerer,
+		ChainConfig:         m.chainConfig(chainParams.ID),
 	}
 
 	// Get a factory for the vm we want to use on our chain
@@ -268,6 +458,15 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 	}
 	// TODO: Shutdown VM if an error occurs
 
+	// The P-Chain serves the validator sets to the chains created after it. It
+	// calls itself while it already holds its lock, so it isn't wrapped.
+	if chainParams.ID == constants.PlatformChainID {
+		if vdrState, ok := vm.(validators.State); ok {
+			ctx.ValidatorState = vdrState
+			m.validatorState = validators.NewLockedState(&ctx.Lock, vdrState)
+		}
+	}
+
 	fxs := make([]*common.Fx, len(chainParams.FxAliases))
 	for i, fxAlias := range chainParams.FxAliases {
 		fxID, err := m.VMManager.Lookup(fxAlias)
@@ -295,6 +494,7 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 
 	consensusParams := m.ConsensusParams
 	consensusParams.Namespace = fmt.Sprintf("%s_%s", constants.PlatformName, primaryAlias)
+	consensusParams.Metrics = registerer
 
 	// The validators of this blockchain
 	var vdrs validators.Set // Validators validating this blockchain
@@ -397,8 +597,8 @@ func (m *manager) createAvalancheChain(
 	db := prefixdb.New(ctx.ChainID[:], m.DB)
 	vmDB := prefixdb.New([]byte("vm"), db)
 	vertexDB := prefixdb.New([]byte("vertex"), db)
-	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bs"), db)
-	txBootstrappingDB := prefixdb.New([]byte("tx_bs"), db)
+	vertexBootstrappingDB := prefixdb.New(vertexBootstrappingPrefix, db)
+	txBootstrappingDB := prefixdb.New(txBootstrappingPrefix, db)
 
 	vtxBlocker, err := queue.New(vertexBootstrappingDB)
 	if err != nil {
@@ -426,6 +626,17 @@ func (m *manager) createAvalancheChain(
 	sender := sender.Sender{}
 	sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager)
 
+	// VM-defined messages are sent through the same sender as the engine's
+	if appVM, ok := vm.(common.AppVM); ok {
+		appVM.SetAppSender(&sender)
+	}
+
+	// The VM may report the conflicts that consensus found between its txs
+	consensus := &avcon.Topological{}
+	if cgVM, ok := vm.(vertex.ConflictAwareVM); ok {
+		cgVM.SetConflictGraph(consensus)
+	}
+
 	sampleK := consensusParams.K
 	if uint64(sampleK) > bootstrapWeight {
 		sampleK = int(bootstrapWeight)
@@ -450,7 +661,7 @@ func (m *manager) createAvalancheChain(
 			VM:         vm,
 		},
 		Params:    consensusParams,
-		Consensus: &avcon.Topological{},
+		Consensus: consensus,
 	}); err != nil {
 		return nil, fmt.Errorf("error initializing avalanche engine: %w", err)
 	}
@@ -470,7 +681,9 @@ func (m *manager) createAvalancheChain(
 	}
 
 	// Asynchronously passes messages from the network to the consensus engine
-	handler := &router.Handler{}
+	handler := &router.Handler{
+		ValidatorOnly: m.PrivateSubnets.Contains(ctx.SubnetID),
+	}
 	handler.Initialize(
 		engine,
 		validators,
@@ -508,7 +721,7 @@ func (m *manager) createSnowmanChain(
 
 	db := prefixdb.New(ctx.ChainID[:], m.DB)
 	vmDB := prefixdb.New([]byte("vm"), db)
-	bootstrappingQueueDB := prefixdb.New([]byte("bs"), db)
+	bootstrappingQueueDB := prefixdb.New(blockBootstrappingPrefix, db)
 
 	blocked, err := queue.New(bootstrappingQueueDB)
 	if err != nil {
@@ -528,6 +741,11 @@ func (m *manager) createSnowmanChain(
 	sender := sender.Sender{}
 	sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager)
 
+	// VM-defined messages are sent through the same sender as the engine's
+	if appVM, ok := vm.(common.AppVM); ok {
+		appVM.SetAppSender(&sender)
+	}
+
 	sampleK := consensusParams.K
 	if uint64(sampleK) > bootstrapWeight {
 		sampleK = int(bootstrapWeight)
@@ -557,7 +775,9 @@ func (m *manager) createSnowmanChain(
 	}
 
 	// Asynchronously passes messages from the network to the consensus engine
-	handler := &router.Handler{}
+	handler := &router.Handler{
+		ValidatorOnly: m.PrivateSubnets.Contains(ctx.SubnetID),
+	}
 	handler.Initialize(
 		engine,
 		validators,
@@ -600,9 +820,9 @@ func (m *manager) SubnetID(chainID ids.ID) (ids.ID, error) {
 
 	chain, exists := m.chains[chainID]
 	if !exists {
-		return ids.ID{}, errors.New("unknown chain ID")
+		return ids.ID{}, errUnknownChain
 	}
-	return chain.Context().SubnetID, nil
+	return chain.Ctx.SubnetID, nil
 }
 
 func (m *manager) IsBootstrapped(id ids.ID) bool {
@@ -613,7 +833,7 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 		return false
 	}
 
-	return chain.Engine().IsBootstrapped()
+	return chain.Engine.IsBootstrapped()
 }
 
 // Shutdown stops all the chains
@@ -633,6 +853,13 @@ func (m *manager) notifyRegistrants(name string, ctx *snow.Context, vm interface
 	}
 }
 
+// Notify registrants that the chain described by [ctx] has been stopped
+func (m *manager) notifyUnregistrants(ctx *snow.Context) {
+	for _, registrant := range m.registrants {
+		registrant.UnregisterChain(ctx)
+	}
+}
+
 // Returns:
 // 1) the alias that already exists, or the empty string if there is none
 // 2) true iff there exists a chain such that the chain has an alias in [aliases]
@@ -645,6 +872,23 @@ func (m *manager) isChainWithAlias(aliases ...string) (string, bool) {
 	return "", false
 }
 
+// clearDB deletes every key in [db]
+func clearDB(db database.Database) error {
+	it := db.NewIterator()
+	defer it.Release()
+
+	batch := db.NewBatch()
+	for it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
 // Wraps a health check.
 // Grabs [Lock] before executing the health check
 type healthCheckWrapper struct {
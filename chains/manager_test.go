@@ -0,0 +1,45 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestChainConfig(t *testing.T) {
+	bothID := ids.GenerateTestID()
+	aliasID := ids.GenerateTestID()
+	noneID := ids.GenerateTestID()
+
+	m := New(&ManagerConfig{
+		ChainConfigs: map[string][]byte{
+			bothID.String(): []byte("by ID"),
+			"both":          []byte("by alias"),
+			"alias":         []byte("by alias"),
+		},
+	}).(*manager)
+	for id, alias := range map[ids.ID]string{
+		bothID:  "both",
+		aliasID: "alias",
+		noneID:  "none",
+	} {
+		if err := m.Alias(id, alias); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The config keyed by the chain ID takes precedence over its aliases
+	if config := m.chainConfig(bothID); !bytes.Equal(config, []byte("by ID")) {
+		t.Fatalf("expected the config keyed by the chain ID but got %q", config)
+	}
+	if config := m.chainConfig(aliasID); !bytes.Equal(config, []byte("by alias")) {
+		t.Fatalf("expected the config keyed by the alias but got %q", config)
+	}
+	if config := m.chainConfig(noneID); config != nil {
+		t.Fatalf("expected no config but got %q", config)
+	}
+}
@@ -53,3 +53,12 @@ func (mm MockManager) SubnetID(ids.ID) (ids.ID, error) { return ids.ID{}, nil }
 
 // IsBootstrapped ...
 func (mm MockManager) IsBootstrapped(ids.ID) bool { return false }
+
+// StopChain ...
+func (mm MockManager) StopChain(ids.ID) error { return nil }
+
+// RestartChain ...
+func (mm MockManager) RestartChain(ids.ID) error { return nil }
+
+// RebootstrapChain ...
+func (mm MockManager) RebootstrapChain(ids.ID) error { return nil }
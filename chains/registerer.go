@@ -0,0 +1,43 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// chainRegisterer wraps the node's metrics registerer for a single instance of
+// a chain. When a chain is restarted, the new instance registers collectors
+// with the same names as the previous instance. Rather than failing, the
+// collectors left behind by the previous instance are replaced.
+type chainRegisterer struct {
+	prometheus.Registerer
+}
+
+func newChainRegisterer(registerer prometheus.Registerer) prometheus.Registerer {
+	if registerer == nil {
+		return nil
+	}
+	return &chainRegisterer{Registerer: registerer}
+}
+
+// Register implements the prometheus.Registerer interface
+func (r *chainRegisterer) Register(c prometheus.Collector) error {
+	err := r.Registerer.Register(c)
+	alreadyRegistered, ok := err.(prometheus.AlreadyRegisteredError)
+	if !ok {
+		return err
+	}
+	r.Registerer.Unregister(alreadyRegistered.ExistingCollector)
+	return r.Registerer.Register(c)
+}
+
+// MustRegister implements the prometheus.Registerer interface
+func (r *chainRegisterer) MustRegister(cs ...prometheus.Collector) {
+	for _, c := range cs {
+		if err := r.Register(c); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -10,4 +10,7 @@ import (
 // Registrant can register the existence of a chain
 type Registrant interface {
 	RegisterChain(name string, ctx *snow.Context, vm interface{})
+
+	// UnregisterChain is called when a previously registered chain is stopped
+	UnregisterChain(ctx *snow.Context)
 }
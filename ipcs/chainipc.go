@@ -4,9 +4,13 @@
 package ipcs
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"sync"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/triggers"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -20,8 +24,12 @@ const (
 	ipcIdentifierPrefix    = "ipc"
 	ipcConsensusIdentifier = "consensus"
 	ipcDecisionsIdentifier = "decisions"
+
+	ipcEventsIdentifierSuffix = "events"
 )
 
+var errNotLogged = errors.New("blockchain's events aren't being logged")
+
 type context struct {
 	log       logging.Logger
 	networkID uint32
@@ -34,11 +42,26 @@ type ChainIPCs struct {
 	chains          map[ids.ID]*EventSockets
 	consensusEvents *triggers.EventDispatcher
 	decisionEvents  *triggers.EventDispatcher
+
+	streamsLock sync.Mutex
+	// Key: Chain ID
+	// Value: The websocket streams of the chain, keyed by event type
+	streams map[ids.ID]map[string]*wsStream
+
+	// If non-nil, the events of every chain are recorded here
+	db           database.Database
+	logRetention uint64
+	logsLock     sync.Mutex
+	// Key: Chain ID
+	// Value: The event logs of the chain, keyed by event type
+	logs map[ids.ID]map[string]*EventLog
 }
 
 // NewChainIPCs creates a new *ChainIPCs that writes consensus and decision
-// events to IPC sockets
-func NewChainIPCs(log logging.Logger, path string, networkID uint32, consensusEvents *triggers.EventDispatcher, decisionEvents *triggers.EventDispatcher, defaultChainIDs []ids.ID) (*ChainIPCs, error) {
+// events to IPC sockets. If [db] is non-nil, the events of every chain, whether
+// published or not, are also recorded in a replayable log, keeping the latest
+// [logRetention] events of each stream, or every event if [logRetention] is 0.
+func NewChainIPCs(log logging.Logger, path string, networkID uint32, consensusEvents *triggers.EventDispatcher, decisionEvents *triggers.EventDispatcher, defaultChainIDs []ids.ID, db database.Database, logRetention uint64) (*ChainIPCs, error) {
 	cipcs := &ChainIPCs{
 		context: context{
 			log:       log,
@@ -48,6 +71,13 @@ func NewChainIPCs(log logging.Logger, path string, networkID uint32, consensusEv
 		chains:          make(map[ids.ID]*EventSockets),
 		consensusEvents: consensusEvents,
 		decisionEvents:  decisionEvents,
+		streams:         make(map[ids.ID]map[string]*wsStream),
+		db:              db,
+		logRetention:    logRetention,
+		logs:            make(map[ids.ID]map[string]*EventLog),
+	}
+	if err := cipcs.startLogs(); err != nil {
+		return nil, err
 	}
 	for _, chainID := range defaultChainIDs {
 		if _, err := cipcs.Publish(chainID); err != nil {
@@ -71,10 +101,93 @@ func (cipcs *ChainIPCs) Publish(chainID ids.ID) (*EventSockets, error) {
 	}
 
 	cipcs.chains[chainID] = es
-	cipcs.log.Info("created IPC sockets for blockchain %s at %s and %s", chainID.String(), es.ConsensusURL(), es.DecisionsURL())
+	cipcs.log.Info("created IPC sockets for blockchain %s at %s, %s, %s and %s", chainID.String(), es.ConsensusURL(), es.DecisionsURL(), es.ConsensusEventsURL(), es.DecisionsEventsURL())
 	return es, nil
 }
 
+// startLogs starts recording the consensus and decision events of every chain,
+// if logging is enabled
+func (cipcs *ChainIPCs) startLogs() error {
+	if cipcs.db == nil {
+		return nil
+	}
+
+	err := cipcs.consensusEvents.Register(
+		ipcLogIdentifierPrefix+"-"+ipcConsensusIdentifier,
+		&logRecorder{cipcs: cipcs, stream: ipcConsensusIdentifier},
+	)
+	if err != nil {
+		return err
+	}
+	return cipcs.decisionEvents.Register(
+		ipcLogIdentifierPrefix+"-"+ipcDecisionsIdentifier,
+		&logRecorder{cipcs: cipcs, stream: ipcDecisionsIdentifier},
+	)
+}
+
+// stopLogs stops recording events and closes the opened logs. The recorded
+// events are kept.
+func (cipcs *ChainIPCs) stopLogs() error {
+	if cipcs.db == nil {
+		return nil
+	}
+
+	// The recorders are deregistered before the logs lock is grabbed, as the
+	// dispatchers hold their own lock while calling Accept.
+	errs := wrappers.Errs{}
+	errs.Add(
+		cipcs.consensusEvents.Deregister(ipcLogIdentifierPrefix+"-"+ipcConsensusIdentifier),
+		cipcs.decisionEvents.Deregister(ipcLogIdentifierPrefix+"-"+ipcDecisionsIdentifier),
+	)
+
+	cipcs.logsLock.Lock()
+	defer cipcs.logsLock.Unlock()
+
+	for _, chainLogs := range cipcs.logs {
+		for _, l := range chainLogs {
+			l.stop()
+		}
+	}
+	cipcs.logs = make(map[ids.ID]map[string]*EventLog)
+	return errs.Err
+}
+
+// GetLog returns the log of [stream] on [chainID]. [stream] is either
+// "consensus" or "decisions".
+func (cipcs *ChainIPCs) GetLog(chainID ids.ID, stream string) (*EventLog, error) {
+	if stream != ipcConsensusIdentifier && stream != ipcDecisionsIdentifier {
+		return nil, errUnknownStream
+	}
+	if cipcs.db == nil {
+		return nil, errNotLogged
+	}
+	return cipcs.getLog(chainID, stream)
+}
+
+// getLog returns the log of [stream] on [chainID], opening it if it isn't
+// already open
+func (cipcs *ChainIPCs) getLog(chainID ids.ID, stream string) (*EventLog, error) {
+	cipcs.logsLock.Lock()
+	defer cipcs.logsLock.Unlock()
+
+	chainLogs, exists := cipcs.logs[chainID]
+	if !exists {
+		chainLogs = make(map[string]*EventLog)
+		cipcs.logs[chainID] = chainLogs
+	}
+	if l, exists := chainLogs[stream]; exists {
+		return l, nil
+	}
+
+	chainDB := prefixdb.New(chainID[:], cipcs.db)
+	l, err := newEventLog(cipcs.log, prefixdb.New([]byte(stream), chainDB), cipcs.logRetention)
+	if err != nil {
+		return nil, err
+	}
+	chainLogs[stream] = l
+	return l, nil
+}
+
 // Unpublish stops the eventSocket for the given chain if it exists. It returns
 // whether or not the socket existed and errors when trying to close it
 func (cipcs *ChainIPCs) Unpublish(chainID ids.ID) (bool, error) {
@@ -82,6 +195,7 @@ func (cipcs *ChainIPCs) Unpublish(chainID ids.ID) (bool, error) {
 	if !ok {
 		return false, nil
 	}
+	delete(cipcs.chains, chainID)
 	return true, chainIPCs.stop()
 }
 
@@ -92,6 +206,10 @@ func (cipcs *ChainIPCs) Shutdown() error {
 	for _, ch := range cipcs.chains {
 		errs.Add(ch.stop())
 	}
+	errs.Add(
+		cipcs.stopLogs(),
+		cipcs.stopStreams(),
+	)
 	return errs.Err
 }
 
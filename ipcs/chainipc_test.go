@@ -0,0 +1,82 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/triggers"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestChainIPCsLogUnpublishedChains(t *testing.T) {
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+
+	consensusEvents := &triggers.EventDispatcher{}
+	consensusEvents.Initialize(logging.NoLog{})
+	decisionEvents := &triggers.EventDispatcher{}
+	decisionEvents.Initialize(logging.NoLog{})
+
+	db := memdb.New()
+	cipcs, err := NewChainIPCs(logging.NoLog{}, DefaultBaseURL, 12345, consensusEvents, decisionEvents, nil, db, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	containerIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+
+	// Accepted before the chain is published
+	decisionEvents.Accept(ctx, containerIDs[0], []byte{0})
+
+	if _, err := cipcs.Publish(ctx.ChainID); err != nil {
+		t.Fatal(err)
+	}
+	decisionEvents.Accept(ctx, containerIDs[1], []byte{1})
+
+	// Accepted after the chain is unpublished
+	if _, err := cipcs.Unpublish(ctx.ChainID); err != nil {
+		t.Fatal(err)
+	}
+	decisionEvents.Accept(ctx, containerIDs[2], []byte{2})
+
+	if _, err := cipcs.Publish(ctx.ChainID); err != nil {
+		t.Fatal(err)
+	}
+	if err := cipcs.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Accepted after a restart, without publishing the chain
+	cipcs, err = NewChainIPCs(logging.NoLog{}, DefaultBaseURL, 12345, consensusEvents, decisionEvents, nil, db, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := cipcs.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	decisionEvents.Accept(ctx, containerIDs[3], []byte{3})
+
+	l, err := cipcs.GetLog(ctx.ChainID, ipcDecisionsIdentifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := l.Range(firstSequence, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(containerIDs) {
+		t.Fatalf("expected %d events but got %d", len(containerIDs), len(got))
+	}
+	for i, event := range got {
+		if event.Sequence != uint64(i+1) || event.ContainerID != containerIDs[i] {
+			t.Fatalf("expected container %s at sequence %d but got %s at %d", containerIDs[i], i+1, event.ContainerID, event.Sequence)
+		}
+	}
+}
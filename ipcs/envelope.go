@@ -0,0 +1,171 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// EventKind is the kind of consensus event that occurred to a container
+type EventKind byte
+
+// Kinds of events that are sent to IPC consumers
+const (
+	IssueEvent EventKind = iota
+	AcceptEvent
+	RejectEvent
+)
+
+const (
+	// Length of the fixed size header of a serialized envelope:
+	// kind, chain ID, container ID and timestamp
+	envelopeHeaderLen = wrappers.ByteLen + 2*hashing.HashLen + wrappers.LongLen
+)
+
+var (
+	errUnknownEventKind = errors.New("unknown event kind")
+	errEnvelopeTooShort = errors.New("envelope is too short")
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case IssueEvent:
+		return "issue"
+	case AcceptEvent:
+		return "accept"
+	case RejectEvent:
+		return "reject"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseEventKind returns the kind with the given name
+func ParseEventKind(name string) (EventKind, error) {
+	switch strings.ToLower(name) {
+	case "issue":
+		return IssueEvent, nil
+	case "accept":
+		return AcceptEvent, nil
+	case "reject":
+		return RejectEvent, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", errUnknownEventKind, name)
+	}
+}
+
+// EventFilter is the set of event kinds a consumer subscribed to. The zero
+// value only matches accept events.
+type EventFilter struct {
+	kinds   [RejectEvent + 1]bool
+	enabled bool
+}
+
+// NewEventFilter returns a filter that matches the given kinds. If no kinds
+// are given, the filter only matches accept events.
+func NewEventFilter(kinds ...EventKind) (EventFilter, error) {
+	f := EventFilter{}
+	for _, kind := range kinds {
+		if kind > RejectEvent {
+			return EventFilter{}, errUnknownEventKind
+		}
+		f.kinds[kind] = true
+		f.enabled = true
+	}
+	return f, nil
+}
+
+// ParseEventFilter returns a filter from a comma separated list of event kind
+// names, such as "issue,accept,reject"
+func ParseEventFilter(names string) (EventFilter, error) {
+	if names == "" {
+		return EventFilter{}, nil
+	}
+	kinds := []EventKind(nil)
+	for _, name := range strings.Split(names, ",") {
+		kind, err := ParseEventKind(strings.TrimSpace(name))
+		if err != nil {
+			return EventFilter{}, err
+		}
+		kinds = append(kinds, kind)
+	}
+	return NewEventFilter(kinds...)
+}
+
+// Matches returns true if events of [kind] pass the filter
+func (f EventFilter) Matches(kind EventKind) bool {
+	if !f.enabled {
+		return kind == AcceptEvent
+	}
+	return kind <= RejectEvent && f.kinds[kind]
+}
+
+// Enveloped returns true if the consumer explicitly subscribed to a set of
+// event kinds. Such consumers are sent enveloped events, so that they can
+// tell the kinds apart.
+func (f EventFilter) Enveloped() bool { return f.enabled }
+
+// Envelope is a typed consensus event
+type Envelope struct {
+	Kind        EventKind
+	ChainID     ids.ID
+	ContainerID ids.ID
+	Timestamp   uint64
+	Container   []byte
+}
+
+// Bytes returns the binary representation of the envelope:
+//   - 1 byte event kind
+//   - 32 byte chain ID
+//   - 32 byte container ID
+//   - 8 byte timestamp, in unix seconds
+//   - the container bytes, filling the rest of the envelope
+func (e *Envelope) Bytes() []byte {
+	size := envelopeHeaderLen + len(e.Container)
+	p := wrappers.Packer{
+		MaxSize: size,
+		Bytes:   make([]byte, 0, size),
+	}
+	p.PackByte(byte(e.Kind))
+	p.PackFixedBytes(e.ChainID[:])
+	p.PackFixedBytes(e.ContainerID[:])
+	p.PackLong(e.Timestamp)
+	p.PackFixedBytes(e.Container)
+	return p.Bytes
+}
+
+// ParseEnvelope parses the binary representation of an envelope
+func ParseEnvelope(b []byte) (*Envelope, error) {
+	if len(b) < envelopeHeaderLen {
+		return nil, errEnvelopeTooShort
+	}
+
+	p := wrappers.Packer{Bytes: b}
+	kind := EventKind(p.UnpackByte())
+	if kind > RejectEvent {
+		return nil, errUnknownEventKind
+	}
+	chainID, err := ids.ToID(p.UnpackFixedBytes(hashing.HashLen))
+	if err != nil {
+		return nil, err
+	}
+	containerID, err := ids.ToID(p.UnpackFixedBytes(hashing.HashLen))
+	if err != nil {
+		return nil, err
+	}
+	e := &Envelope{
+		Kind:        kind,
+		ChainID:     chainID,
+		ContainerID: containerID,
+		Timestamp:   p.UnpackLong(),
+		Container:   p.UnpackFixedBytes(len(b) - envelopeHeaderLen),
+	}
+	return e, p.Err
+}
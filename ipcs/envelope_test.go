@@ -0,0 +1,59 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	envelope := Envelope{
+		Kind:        RejectEvent,
+		ChainID:     ids.GenerateTestID(),
+		ContainerID: ids.GenerateTestID(),
+		Timestamp:   1234,
+		Container:   []byte("container"),
+	}
+
+	parsed, err := ParseEnvelope(envelope.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Kind != envelope.Kind ||
+		parsed.ChainID != envelope.ChainID ||
+		parsed.ContainerID != envelope.ContainerID ||
+		parsed.Timestamp != envelope.Timestamp ||
+		!bytes.Equal(parsed.Container, envelope.Container) {
+		t.Fatalf("expected %+v but got %+v", envelope, parsed)
+	}
+
+	if _, err := ParseEnvelope([]byte{byte(AcceptEvent)}); err != errEnvelopeTooShort {
+		t.Fatalf("expected %s but got %v", errEnvelopeTooShort, err)
+	}
+}
+
+func TestEventFilter(t *testing.T) {
+	f, err := ParseEventFilter("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Enveloped() || !f.Matches(AcceptEvent) || f.Matches(IssueEvent) || f.Matches(RejectEvent) {
+		t.Fatal("default filter should only match accept events")
+	}
+
+	f, err = ParseEventFilter("issue, Reject")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.Enveloped() || f.Matches(AcceptEvent) || !f.Matches(IssueEvent) || !f.Matches(RejectEvent) {
+		t.Fatal("filter should only match issue and reject events")
+	}
+
+	if _, err := ParseEventFilter("accept,vote"); err == nil {
+		t.Fatal("should have failed to parse an unknown event kind")
+	}
+}
@@ -0,0 +1,326 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	ipcLogIdentifierPrefix = "ipc-log"
+
+	// Sequence numbers start at 1, so that 0 can be used to mean "no event"
+	firstSequence uint64 = 1
+
+	entryHeaderLen = hashing.HashLen + wrappers.LongLen
+)
+
+const (
+	entryPrefix byte = iota
+	indexPrefix
+	metadataPrefix
+)
+
+var (
+	errEventPruned   = errors.New("event was pruned from the log")
+	errUnknownEvent  = errors.New("event isn't in the log")
+	errLogClosed     = errors.New("event log is closed")
+	errCorruptedLog  = errors.New("event log is corrupted")
+	firstSequenceKey = []byte{metadataPrefix, 0}
+	nextSequenceKey  = []byte{metadataPrefix, 1}
+)
+
+// Event is an accepted container that was recorded in an EventLog
+type Event struct {
+	Sequence    uint64
+	ContainerID ids.ID
+	Timestamp   uint64
+	Container   []byte
+}
+
+// EventLog is a persisted, append-only log of the containers accepted on a
+// single stream of a chain. Every container is assigned a monotonically
+// increasing sequence number, which allows consumers to resume from the last
+// event they processed.
+//
+// If [retention] is non-zero, only the latest [retention] events are kept.
+type EventLog struct {
+	log       logging.Logger
+	db        database.Database
+	retention uint64
+
+	lock sync.RWMutex
+	// Sequence number of the oldest event still in the log
+	first uint64
+	// Sequence number that will be assigned to the next event
+	next uint64
+	// Closed, and replaced, every time an event is appended
+	updated chan struct{}
+	// Closed when the log is stopped
+	closed chan struct{}
+}
+
+// newEventLog returns the log stored in [db]
+func newEventLog(log logging.Logger, db database.Database, retention uint64) (*EventLog, error) {
+	l := &EventLog{
+		log:       log,
+		db:        db,
+		retention: retention,
+		updated:   make(chan struct{}),
+		closed:    make(chan struct{}),
+	}
+	if err := l.init(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *EventLog) init() error {
+	first, err := l.getSequence(firstSequenceKey)
+	if err != nil {
+		return err
+	}
+	next, err := l.getSequence(nextSequenceKey)
+	if err != nil {
+		return err
+	}
+	if first > next {
+		return errCorruptedLog
+	}
+	l.first = first
+	l.next = next
+	return nil
+}
+
+func (l *EventLog) getSequence(key []byte) (uint64, error) {
+	b, err := l.db.Get(key)
+	switch {
+	case err == database.ErrNotFound:
+		return firstSequence, nil
+	case err != nil:
+		return 0, err
+	case len(b) != wrappers.LongLen:
+		return 0, errCorruptedLog
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+// Accept appends the container to the log
+func (l *EventLog) Accept(_ *snow.Context, containerID ids.ID, container []byte) error {
+	if err := l.append(containerID, container, uint64(time.Now().Unix())); err != nil {
+		l.log.Error("couldn't append container %s to the event log: %s", containerID, err)
+		return err
+	}
+	return nil
+}
+
+func (l *EventLog) append(containerID ids.ID, container []byte, timestamp uint64) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	select {
+	case <-l.closed:
+		return errLogClosed
+	default:
+	}
+
+	seq := l.next
+	batch := l.db.NewBatch()
+
+	entry := make([]byte, entryHeaderLen+len(container))
+	copy(entry, containerID[:])
+	binary.BigEndian.PutUint64(entry[hashing.HashLen:], timestamp)
+	copy(entry[entryHeaderLen:], container)
+	if err := batch.Put(entryKey(seq), entry); err != nil {
+		return err
+	}
+	if err := batch.Put(indexKey(containerID), sequenceBytes(seq)); err != nil {
+		return err
+	}
+
+	first := l.first
+	for l.retention != 0 && seq+1-first > l.retention {
+		if err := l.prune(batch, first); err != nil {
+			return err
+		}
+		first++
+	}
+
+	if err := batch.Put(firstSequenceKey, sequenceBytes(first)); err != nil {
+		return err
+	}
+	if err := batch.Put(nextSequenceKey, sequenceBytes(seq+1)); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	l.first = first
+	l.next = seq + 1
+	close(l.updated)
+	l.updated = make(chan struct{})
+	return nil
+}
+
+// prune adds the removal of the event at [seq] to [batch]
+func (l *EventLog) prune(batch database.Batch, seq uint64) error {
+	entry, err := l.db.Get(entryKey(seq))
+	if err != nil {
+		return err
+	}
+	if len(entry) < entryHeaderLen {
+		return errCorruptedLog
+	}
+	containerID, err := ids.ToID(entry[:hashing.HashLen])
+	if err != nil {
+		return err
+	}
+
+	// The container may have been logged again after this event
+	indexed, err := l.db.Get(indexKey(containerID))
+	if err != nil {
+		return err
+	}
+	if len(indexed) == wrappers.LongLen && binary.BigEndian.Uint64(indexed) == seq {
+		if err := batch.Delete(indexKey(containerID)); err != nil {
+			return err
+		}
+	}
+	return batch.Delete(entryKey(seq))
+}
+
+// Bounds returns the sequence number of the oldest event in the log and the
+// sequence number that will be assigned to the next event. The log is empty
+// if they are equal.
+func (l *EventLog) Bounds() (uint64, uint64) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.first, l.next
+}
+
+// SequenceOf returns the sequence number of the latest event with the given
+// container
+func (l *EventLog) SequenceOf(containerID ids.ID) (uint64, error) {
+	b, err := l.db.Get(indexKey(containerID))
+	switch {
+	case err == database.ErrNotFound:
+		return 0, errUnknownEvent
+	case err != nil:
+		return 0, err
+	case len(b) != wrappers.LongLen:
+		return 0, errCorruptedLog
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+// Range returns up to [limit] events, starting with the event at [start]. If
+// [start] has already been pruned, an error is returned, so that consumers
+// never silently skip events.
+func (l *EventLog) Range(start uint64, limit int) ([]Event, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if start < l.first {
+		return nil, fmt.Errorf("%w: oldest available sequence is %d", errEventPruned, l.first)
+	}
+
+	events := []Event(nil)
+	for seq := start; seq < l.next && len(events) < limit; seq++ {
+		entry, err := l.db.Get(entryKey(seq))
+		if err != nil {
+			return nil, err
+		}
+		if len(entry) < entryHeaderLen {
+			return nil, errCorruptedLog
+		}
+		containerID, err := ids.ToID(entry[:hashing.HashLen])
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, Event{
+			Sequence:    seq,
+			ContainerID: containerID,
+			Timestamp:   binary.BigEndian.Uint64(entry[hashing.HashLen:]),
+			Container:   entry[entryHeaderLen:],
+		})
+	}
+	return events, nil
+}
+
+// Updated returns a channel that is closed when the next event is appended
+func (l *EventLog) Updated() <-chan struct{} {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.updated
+}
+
+// Closed returns a channel that is closed when the log is stopped
+func (l *EventLog) Closed() <-chan struct{} {
+	return l.closed
+}
+
+// stop closes the log. The recorded events are kept in the database.
+func (l *EventLog) stop() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	select {
+	case <-l.closed:
+	default:
+		close(l.closed)
+	}
+}
+
+// logRecorder records the containers accepted on [stream] of every chain in
+// the chain's event log, whether or not the chain is published
+type logRecorder struct {
+	cipcs  *ChainIPCs
+	stream string
+}
+
+// Accept appends the container to the event log of the chain
+func (r *logRecorder) Accept(ctx *snow.Context, containerID ids.ID, container []byte) error {
+	l, err := r.cipcs.getLog(ctx.ChainID, r.stream)
+	if err != nil {
+		r.cipcs.log.Error("couldn't open the %s event log of %s: %s", r.stream, ctx.ChainID, err)
+		return err
+	}
+	return l.Accept(ctx, containerID, container)
+}
+
+func entryKey(seq uint64) []byte {
+	key := make([]byte, 1+wrappers.LongLen)
+	key[0] = entryPrefix
+	binary.BigEndian.PutUint64(key[1:], seq)
+	return key
+}
+
+func indexKey(containerID ids.ID) []byte {
+	key := make([]byte, 1+hashing.HashLen)
+	key[0] = indexPrefix
+	copy(key[1:], containerID[:])
+	return key
+}
+
+func sequenceBytes(seq uint64) []byte {
+	b := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(b, seq)
+	return b
+}
@@ -0,0 +1,141 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestEventLog(t *testing.T, retention uint64) (*EventLog, *snow.Context, *memdb.Database) {
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+
+	db := memdb.New()
+	l, err := newEventLog(logging.NoLog{}, db, retention)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l, ctx, db
+}
+
+func TestEventLogAppendAndResume(t *testing.T) {
+	l, ctx, db := newTestEventLog(t, 0)
+
+	containerIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	for i, containerID := range containerIDs {
+		if err := l.Accept(ctx, containerID, []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if first, next := l.Bounds(); first != 1 || next != 4 {
+		t.Fatalf("expected bounds [1, 4) but got [%d, %d)", first, next)
+	}
+
+	seq, err := l.SequenceOf(containerIDs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq != 2 {
+		t.Fatalf("expected sequence 2 but got %d", seq)
+	}
+
+	got, err := l.Range(seq+1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event but got %d", len(got))
+	}
+	if got[0].Sequence != 3 || got[0].ContainerID != containerIDs[2] || !bytes.Equal(got[0].Container, []byte{2}) {
+		t.Fatalf("unexpected event %+v", got[0])
+	}
+
+	// The log should pick up where it left off after a restart
+	l.stop()
+	l, err = newEventLog(logging.NoLog{}, db, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Accept(ctx, ids.GenerateTestID(), []byte{3}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = l.Range(1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 4 {
+		t.Fatalf("expected 4 events but got %d", len(got))
+	}
+	for i, event := range got {
+		if event.Sequence != uint64(i+1) {
+			t.Fatalf("expected sequence %d but got %d", i+1, event.Sequence)
+		}
+	}
+}
+
+func TestEventLogRetention(t *testing.T) {
+	l, ctx, _ := newTestEventLog(t, 2)
+
+	containerIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	for i, containerID := range containerIDs {
+		if err := l.Accept(ctx, containerID, []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if first, next := l.Bounds(); first != 2 || next != 4 {
+		t.Fatalf("expected bounds [2, 4) but got [%d, %d)", first, next)
+	}
+	if _, err := l.Range(1, 10); !errors.Is(err, errEventPruned) {
+		t.Fatalf("expected %s but got %v", errEventPruned, err)
+	}
+	if _, err := l.SequenceOf(containerIDs[0]); err != errUnknownEvent {
+		t.Fatalf("expected %s but got %v", errUnknownEvent, err)
+	}
+
+	got, err := l.Range(2, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events but got %d", len(got))
+	}
+}
+
+func TestEventLogUpdated(t *testing.T) {
+	l, ctx, _ := newTestEventLog(t, 0)
+
+	updated := l.Updated()
+	select {
+	case <-updated:
+		t.Fatal("log shouldn't have been updated")
+	default:
+	}
+
+	if err := l.Accept(ctx, ids.GenerateTestID(), []byte{0}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-updated:
+	default:
+		t.Fatal("log should have been updated")
+	}
+
+	l.stop()
+	select {
+	case <-l.Closed():
+	default:
+		t.Fatal("log should have been closed")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"os"
 	"syscall"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ipcs/socket"
@@ -21,24 +22,37 @@ import (
 type EventSockets struct {
 	consensusSocket *eventSocket
 	decisionsSocket *eventSocket
+
+	// Sockets that write issue, accept and reject events in envelopes
+	consensusEventsSocket *eventSocket
+	decisionsEventsSocket *eventSocket
 }
 
 // newEventSockets creates a *ChainIPCs with both consensus and decisions IPCs
 func newEventSockets(ctx context, chainID ids.ID, consensusEvents *triggers.EventDispatcher, decisionEvents *triggers.EventDispatcher) (*EventSockets, error) {
-	consensusIPC, err := newEventIPCSocket(ctx, chainID, ipcConsensusIdentifier, consensusEvents)
-	if err != nil {
-		return nil, err
+	es := &EventSockets{}
+	sockets := []struct {
+		socket    **eventSocket
+		name      string
+		events    *triggers.EventDispatcher
+		enveloped bool
+	}{
+		{&es.consensusSocket, ipcConsensusIdentifier, consensusEvents, false},
+		{&es.decisionsSocket, ipcDecisionsIdentifier, decisionEvents, false},
+		{&es.consensusEventsSocket, ipcConsensusIdentifier, consensusEvents, true},
+		{&es.decisionsEventsSocket, ipcDecisionsIdentifier, decisionEvents, true},
 	}
-
-	decisionsIPC, err := newEventIPCSocket(ctx, chainID, ipcDecisionsIdentifier, decisionEvents)
-	if err != nil {
-		return nil, err
+	for _, s := range sockets {
+		socket, err := newEventIPCSocket(ctx, chainID, s.name, s.events, s.enveloped)
+		if err != nil {
+			if err := es.stop(); err != nil {
+				return nil, err
+			}
+			return nil, err
+		}
+		*s.socket = socket
 	}
-
-	return &EventSockets{
-		consensusSocket: consensusIPC,
-		decisionsSocket: decisionsIPC,
-	}, nil
+	return es, nil
 }
 
 // Accept delivers a message to the underlying eventSockets
@@ -70,6 +84,14 @@ func (ipcs *EventSockets) stop() error {
 		errs.Add(ipcs.decisionsSocket.stop())
 	}
 
+	if ipcs.consensusEventsSocket != nil {
+		errs.Add(ipcs.consensusEventsSocket.stop())
+	}
+
+	if ipcs.decisionsEventsSocket != nil {
+		errs.Add(ipcs.decisionsEventsSocket.stop())
+	}
+
 	return errs.Err
 }
 
@@ -83,17 +105,37 @@ func (ipcs *EventSockets) DecisionsURL() string {
 	return ipcs.decisionsSocket.URL()
 }
 
+// ConsensusEventsURL returns the URL of socket receiving enveloped consensus
+// events
+func (ipcs *EventSockets) ConsensusEventsURL() string {
+	return ipcs.consensusEventsSocket.URL()
+}
+
+// DecisionsEventsURL returns the URL of socket receiving enveloped decisions
+// events
+func (ipcs *EventSockets) DecisionsEventsURL() string {
+	return ipcs.decisionsEventsSocket.URL()
+}
+
 // eventSocket is a single IPC socket for a single chain
 type eventSocket struct {
 	url          string
 	log          logging.Logger
 	socket       *socket.Socket
 	unregisterFn func() error
+
+	// If true, issue, accept and reject events are written as envelopes.
+	// Otherwise, only the bytes of accepted containers are written.
+	enveloped bool
+	chainID   ids.ID
 }
 
 // newEventIPCSocket creates a *eventSocket for the given chain and
 // EventDispatcher that writes to a local IPC socket
-func newEventIPCSocket(ctx context, chainID ids.ID, name string, events *triggers.EventDispatcher) (*eventSocket, error) {
+func newEventIPCSocket(ctx context, chainID ids.ID, name string, events *triggers.EventDispatcher, enveloped bool) (*eventSocket, error) {
+	if enveloped {
+		name += "-" + ipcEventsIdentifierSuffix
+	}
 	var (
 		url     = ipcURL(ctx, chainID, name)
 		ipcName = ipcIdentifierPrefix + "-" + name
@@ -111,6 +153,8 @@ func newEventIPCSocket(ctx context, chainID ids.ID, name string, events *trigger
 		unregisterFn: func() error {
 			return events.DeregisterChain(chainID, ipcName)
 		},
+		enveloped: enveloped,
+		chainID:   chainID,
 	}
 
 	if err := eis.socket.Listen(); err != nil {
@@ -130,11 +174,45 @@ func newEventIPCSocket(ctx context, chainID ids.ID, name string, events *trigger
 	return eis, nil
 }
 
+// Issue delivers an issue event to the eventSocket, if it is enveloped
+func (eis *eventSocket) Issue(_ *snow.Context, containerID ids.ID, container []byte) error {
+	if !eis.enveloped {
+		return nil
+	}
+	return eis.sendEnvelope(IssueEvent, containerID, container)
+}
+
 // Accept delivers a message to the eventSocket
-func (eis *eventSocket) Accept(_ *snow.Context, _ ids.ID, container []byte) error {
-	err := eis.socket.Send(container)
+func (eis *eventSocket) Accept(_ *snow.Context, containerID ids.ID, container []byte) error {
+	if eis.enveloped {
+		return eis.sendEnvelope(AcceptEvent, containerID, container)
+	}
+	return eis.send(container)
+}
+
+// Reject delivers a reject event to the eventSocket, if it is enveloped
+func (eis *eventSocket) Reject(_ *snow.Context, containerID ids.ID, container []byte) error {
+	if !eis.enveloped {
+		return nil
+	}
+	return eis.sendEnvelope(RejectEvent, containerID, container)
+}
+
+func (eis *eventSocket) sendEnvelope(kind EventKind, containerID ids.ID, container []byte) error {
+	envelope := Envelope{
+		Kind:        kind,
+		ChainID:     eis.chainID,
+		ContainerID: containerID,
+		Timestamp:   uint64(time.Now().Unix()),
+		Container:   container,
+	}
+	return eis.send(envelope.Bytes())
+}
+
+func (eis *eventSocket) send(msg []byte) error {
+	err := eis.socket.Send(msg)
 	if err != nil {
-		eis.log.Error("%s while trying to send:\n%s", err, formatting.DumpBytes{Bytes: container})
+		eis.log.Error("%s while trying to send:\n%s", err, formatting.DumpBytes{Bytes: msg})
 	}
 	return err
 }
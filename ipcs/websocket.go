@@ -0,0 +1,643 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/triggers"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+const (
+	// BinaryEncoding sends each container in a binary message, prefixed with
+	// its 8 byte length, exactly as it is written to the IPC sockets
+	BinaryEncoding = "binary"
+
+	// JSONEncoding sends each container in a JSON envelope
+	JSONEncoding = "json"
+
+	// DisconnectPolicy closes the connection of a consumer that falls too far
+	// behind, so that the consumer never silently misses events
+	DisconnectPolicy = "disconnect"
+
+	// DropPolicy drops events for a consumer that falls too far behind. When
+	// using the JSON encoding, the number of dropped events is reported in the
+	// next envelope that is delivered.
+	DropPolicy = "drop"
+
+	ipcWebsocketIdentifierPrefix = "ipc-ws"
+
+	// Maximum number of events buffered for a consumer
+	maxPendingStreamMessages = 1024
+
+	// Time allowed to write a message to the consumer
+	streamWriteWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the consumer
+	streamPongWait = 60 * time.Second
+
+	// Send pings to the consumer with this period. Must be less than
+	// [streamPongWait].
+	streamPingPeriod = (streamPongWait * 9) / 10
+
+	// Consumers only send control messages
+	streamMaxReadSize = 512
+
+	// Maximum number of logged events read at once when replaying a stream
+	replayBatchSize = 256
+)
+
+var (
+	errUnknownStream   = errors.New("unknown stream")
+	errUnknownEncoding = errors.New("unknown encoding")
+	errUnknownPolicy   = errors.New("unknown backpressure policy")
+
+	errReplayAcceptsOnly = errors.New("only accept events can be replayed")
+
+	// The upgrader's default CheckOrigin only accepts handshakes without an
+	// Origin header or from the origin of the node's API
+	streamUpgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+	}
+)
+
+// StreamOptions describes how events are delivered to a websocket consumer
+type StreamOptions struct {
+	// One of BinaryEncoding or JSONEncoding. Defaults to BinaryEncoding.
+	Encoding string
+
+	// The kinds of events to deliver. If no kinds are specified, only accept
+	// events are delivered and binary messages aren't enveloped.
+	Events EventFilter
+
+	// One of DisconnectPolicy or DropPolicy. Defaults to DisconnectPolicy.
+	// Ignored when replaying the stream's log, as the consumer is then fed at
+	// its own pace.
+	Policy string
+
+	// If non-zero, the stream is replayed from the chain's event log, starting
+	// with the event that has this sequence number
+	FromSequence uint64
+
+	// If non-empty, the stream is replayed from the chain's event log, starting
+	// with the event after the one that has this container
+	FromContainerID ids.ID
+
+	// If non-empty, the subprotocol to accept in the handshake, which must be
+	// one of the subprotocols requested by the consumer
+	Subprotocol string
+}
+
+// responseHeader returns the header to reply to the handshake with
+func (o *StreamOptions) responseHeader() http.Header {
+	if o.Subprotocol == "" {
+		return nil
+	}
+	return http.Header{"Sec-Websocket-Protocol": []string{o.Subprotocol}}
+}
+
+// replay returns whether the stream should be read from the event log
+func (o *StreamOptions) replay() bool {
+	return o.FromSequence != 0 || o.FromContainerID != ids.Empty
+}
+
+func (o *StreamOptions) verify() error {
+	switch o.Encoding {
+	case "":
+		o.Encoding = BinaryEncoding
+	case BinaryEncoding, JSONEncoding:
+	default:
+		return errUnknownEncoding
+	}
+	switch o.Policy {
+	case "":
+		o.Policy = DisconnectPolicy
+	case DisconnectPolicy, DropPolicy:
+	default:
+		return errUnknownPolicy
+	}
+	return nil
+}
+
+// StreamMessage is the JSON envelope of an event sent to websocket consumers
+type StreamMessage struct {
+	// One of "issue", "accept" or "reject"
+	Kind        string       `json:"kind"`
+	ChainID     string       `json:"chainID"`
+	ContainerID string       `json:"containerID"`
+	Timestamp   cjson.Uint64 `json:"timestamp"`
+	Bytes       string       `json:"bytes"`
+
+	// Sequence number of the event in the chain's event log. Only set when the
+	// stream is replayed from the log.
+	Sequence cjson.Uint64 `json:"sequence,omitempty"`
+
+	// Number of events dropped for this consumer since the last delivered one
+	Dropped cjson.Uint64 `json:"dropped,omitempty"`
+}
+
+// ServeStream upgrades the request to a websocket connection and streams the
+// events of [stream] on [chainID] to it, until the connection is closed.
+// [stream] is either "consensus" or "decisions".
+//
+// If the consumer subscribed to a set of event kinds, binary messages contain
+// the serialized Envelope of the event rather than the raw container. When the
+// stream is replayed from the event log, binary messages are prefixed with the
+// 8 byte sequence number of the event.
+func (cipcs *ChainIPCs) ServeStream(w http.ResponseWriter, r *http.Request, chainID ids.ID, stream string, opts StreamOptions) error {
+	if err := opts.verify(); err != nil {
+		return err
+	}
+	if opts.replay() {
+		return cipcs.serveReplay(w, r, chainID, stream, opts)
+	}
+
+	events, err := cipcs.streamEvents(stream)
+	if err != nil {
+		return err
+	}
+
+	conn, err := streamUpgrader.Upgrade(w, r, opts.responseHeader())
+	if err != nil {
+		// The upgrader has already replied to the request
+		cipcs.log.Debug("couldn't upgrade websocket stream of %s: %s", chainID, err)
+		return nil
+	}
+
+	c := newWSConsumer(cipcs.log, chainID, conn, opts)
+	s, err := cipcs.subscribe(chainID, stream, events, c)
+	if err != nil {
+		cipcs.log.Debug("couldn't subscribe to websocket stream of %s: %s", chainID, err)
+		c.close()
+		go c.writePump()
+		return nil
+	}
+	c.onClose = func() { cipcs.unsubscribe(chainID, stream, s, c) }
+
+	go c.writePump()
+	go c.readPump()
+	return nil
+}
+
+// serveReplay streams the events of the chain's event log, starting from the
+// requested event, and then follows the log as new events are appended
+func (cipcs *ChainIPCs) serveReplay(w http.ResponseWriter, r *http.Request, chainID ids.ID, stream string, opts StreamOptions) error {
+	if !opts.Events.Matches(AcceptEvent) {
+		return errReplayAcceptsOnly
+	}
+
+	l, err := cipcs.GetLog(chainID, stream)
+	if err != nil {
+		return err
+	}
+
+	start := opts.FromSequence
+	if opts.FromContainerID != ids.Empty {
+		seq, err := l.SequenceOf(opts.FromContainerID)
+		if err != nil {
+			return fmt.Errorf("couldn't find container %s: %w", opts.FromContainerID, err)
+		}
+		start = seq + 1
+	}
+	// Fail before upgrading the connection if the start was already pruned
+	if _, err := l.Range(start, 0); err != nil {
+		return err
+	}
+
+	conn, err := streamUpgrader.Upgrade(w, r, opts.responseHeader())
+	if err != nil {
+		// The upgrader has already replied to the request
+		cipcs.log.Debug("couldn't upgrade websocket stream of %s: %s", chainID, err)
+		return nil
+	}
+
+	c := newWSConsumer(cipcs.log, chainID, conn, opts)
+	c.onClose = c.close
+
+	go c.replay(l, start)
+	go c.writePump()
+	go c.readPump()
+	return nil
+}
+
+// streamEvents returns the dispatcher of the events of [stream]
+func (cipcs *ChainIPCs) streamEvents(stream string) (*triggers.EventDispatcher, error) {
+	switch stream {
+	case ipcConsensusIdentifier:
+		return cipcs.consensusEvents, nil
+	case ipcDecisionsIdentifier:
+		return cipcs.decisionEvents, nil
+	default:
+		return nil, errUnknownStream
+	}
+}
+
+// subscribe adds [c] to the websocket stream of [chainID], creating the stream
+// if it doesn't exist yet. The caller must have checked that the node runs
+// [chainID], as a stream is kept until its last consumer leaves.
+func (cipcs *ChainIPCs) subscribe(chainID ids.ID, stream string, events *triggers.EventDispatcher, c *wsConsumer) (*wsStream, error) {
+	cipcs.streamsLock.Lock()
+	defer cipcs.streamsLock.Unlock()
+
+	chainStreams, exists := cipcs.streams[chainID]
+	if !exists {
+		chainStreams = make(map[string]*wsStream)
+		cipcs.streams[chainID] = chainStreams
+	}
+	s, exists := chainStreams[stream]
+	if !exists {
+		var err error
+		s, err = newWSStream(cipcs.log, chainID, stream, events)
+		if err != nil {
+			if len(chainStreams) == 0 {
+				delete(cipcs.streams, chainID)
+			}
+			return nil, err
+		}
+		chainStreams[stream] = s
+		cipcs.log.Info("created websocket %s stream for blockchain %s", stream, chainID)
+	}
+	s.add(c)
+	return s, nil
+}
+
+// unsubscribe removes [c] from [s], and stops [s] if [c] was its last
+// consumer
+func (cipcs *ChainIPCs) unsubscribe(chainID ids.ID, stream string, s *wsStream, c *wsConsumer) {
+	cipcs.streamsLock.Lock()
+	defer cipcs.streamsLock.Unlock()
+
+	if s.removeConsumer(c) > 0 {
+		return
+	}
+	chainStreams := cipcs.streams[chainID]
+	if chainStreams[stream] != s {
+		// The stream was already stopped
+		return
+	}
+	delete(chainStreams, stream)
+	if len(chainStreams) == 0 {
+		delete(cipcs.streams, chainID)
+	}
+	if err := s.stop(); err != nil {
+		cipcs.log.Debug("couldn't stop websocket %s stream of %s: %s", stream, chainID, err)
+		return
+	}
+	cipcs.log.Info("removed websocket %s stream for blockchain %s", stream, chainID)
+}
+
+// stopStreams closes every websocket stream
+func (cipcs *ChainIPCs) stopStreams() error {
+	cipcs.streamsLock.Lock()
+	defer cipcs.streamsLock.Unlock()
+
+	errs := wrappers.Errs{}
+	for _, chainStreams := range cipcs.streams {
+		for _, s := range chainStreams {
+			errs.Add(s.stop())
+		}
+	}
+	cipcs.streams = make(map[ids.ID]map[string]*wsStream)
+	return errs.Err
+}
+
+// wsStream fans out the events of a single chain to websocket consumers
+type wsStream struct {
+	log          logging.Logger
+	chainID      ids.ID
+	unregisterFn func() error
+
+	lock      sync.Mutex
+	consumers map[*wsConsumer]struct{}
+}
+
+func newWSStream(log logging.Logger, chainID ids.ID, name string, events *triggers.EventDispatcher) (*wsStream, error) {
+	identifier := ipcWebsocketIdentifierPrefix + "-" + name
+	s := &wsStream{
+		log:     log,
+		chainID: chainID,
+		unregisterFn: func() error {
+			return events.DeregisterChain(chainID, identifier)
+		},
+		consumers: make(map[*wsConsumer]struct{}),
+	}
+	if err := events.RegisterChain(chainID, identifier, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Issue delivers the container to every consumer subscribed to issue events
+func (s *wsStream) Issue(_ *snow.Context, containerID ids.ID, container []byte) error {
+	return s.deliver(IssueEvent, containerID, container)
+}
+
+// Accept delivers the container to every consumer subscribed to accept events
+func (s *wsStream) Accept(_ *snow.Context, containerID ids.ID, container []byte) error {
+	return s.deliver(AcceptEvent, containerID, container)
+}
+
+// Reject delivers the container to every consumer subscribed to reject events
+func (s *wsStream) Reject(_ *snow.Context, containerID ids.ID, container []byte) error {
+	return s.deliver(RejectEvent, containerID, container)
+}
+
+func (s *wsStream) deliver(kind EventKind, containerID ids.ID, container []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.consumers) == 0 {
+		return nil
+	}
+
+	timestamp := uint64(time.Now().Unix())
+	for c := range s.consumers {
+		if !c.events.Matches(kind) {
+			continue
+		}
+
+		msg, err := c.encode(kind, 0, containerID, container, timestamp)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case c.send <- msg:
+			c.dropped = 0
+		default:
+			if c.policy == DropPolicy {
+				c.dropped++
+				continue
+			}
+			s.log.Debug("disconnecting websocket consumer %s of chain %s as it fell behind", c.conn.RemoteAddr(), s.chainID)
+			s.remove(c)
+		}
+	}
+	return nil
+}
+
+func (s *wsStream) add(c *wsConsumer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.consumers[c] = struct{}{}
+}
+
+// removeConsumer removes [c] and returns the number of remaining consumers
+func (s *wsStream) removeConsumer(c *wsConsumer) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.remove(c)
+	return len(s.consumers)
+}
+
+// remove assumes [s.lock] is held
+func (s *wsStream) remove(c *wsConsumer) {
+	if _, exists := s.consumers[c]; !exists {
+		return
+	}
+	delete(s.consumers, c)
+	c.close()
+}
+
+// stop unregisters the stream and disconnects all of its consumers
+func (s *wsStream) stop() error {
+	// The stream is unregistered before its lock is grabbed, as the
+	// dispatcher holds its own lock while calling Accept.
+	err := s.unregisterFn()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for c := range s.consumers {
+		s.remove(c)
+	}
+	return err
+}
+
+type wsMessage struct {
+	messageType int
+	data        []byte
+}
+
+// wsConsumer is a single websocket connection consuming a stream
+type wsConsumer struct {
+	log      logging.Logger
+	chainID  ids.ID
+	conn     *websocket.Conn
+	encoding string
+	policy   string
+	events   EventFilter
+
+	// Called when the connection is closed by the consumer
+	onClose func()
+
+	// Buffered channel of outbound messages
+	send chan wsMessage
+
+	// Closed when the consumer is removed from its stream
+	quit     chan struct{}
+	quitOnce sync.Once
+
+	// Number of events dropped since the last delivered event. Only accessed
+	// while holding the stream's lock.
+	dropped uint64
+}
+
+func newWSConsumer(log logging.Logger, chainID ids.ID, conn *websocket.Conn, opts StreamOptions) *wsConsumer {
+	return &wsConsumer{
+		log:      log,
+		chainID:  chainID,
+		conn:     conn,
+		encoding: opts.Encoding,
+		policy:   opts.Policy,
+		events:   opts.Events,
+		send:     make(chan wsMessage, maxPendingStreamMessages),
+		quit:     make(chan struct{}),
+	}
+}
+
+// close signals the writePump to close the connection
+func (c *wsConsumer) close() {
+	c.quitOnce.Do(func() { close(c.quit) })
+}
+
+// encode the container into a message. If [sequence] is non-zero, it is
+// included in the message.
+func (c *wsConsumer) encode(kind EventKind, sequence uint64, containerID ids.ID, container []byte, timestamp uint64) (wsMessage, error) {
+	if c.encoding == BinaryEncoding {
+		if c.events.Enveloped() {
+			envelope := Envelope{
+				Kind:        kind,
+				ChainID:     c.chainID,
+				ContainerID: containerID,
+				Timestamp:   timestamp,
+				Container:   container,
+			}
+			container = envelope.Bytes()
+		}
+
+		p := wrappers.Packer{MaxSize: 2*wrappers.LongLen + len(container)}
+		if sequence != 0 {
+			p.PackLong(sequence)
+		}
+		p.PackLong(uint64(len(container)))
+		p.PackFixedBytes(container)
+		if p.Errored() {
+			return wsMessage{}, p.Err
+		}
+		return wsMessage{
+			messageType: websocket.BinaryMessage,
+			data:        p.Bytes,
+		}, nil
+	}
+
+	bytes, err := formatting.Encode(formatting.Hex, container)
+	if err != nil {
+		return wsMessage{}, fmt.Errorf("couldn't encode container %s: %w", containerID, err)
+	}
+	data, err := json.Marshal(StreamMessage{
+		Kind:        kind.String(),
+		ChainID:     c.chainID.String(),
+		ContainerID: containerID.String(),
+		Timestamp:   cjson.Uint64(timestamp),
+		Bytes:       bytes,
+		Sequence:    cjson.Uint64(sequence),
+		Dropped:     cjson.Uint64(c.dropped),
+	})
+	if err != nil {
+		return wsMessage{}, err
+	}
+	return wsMessage{
+		messageType: websocket.TextMessage,
+		data:        data,
+	}, nil
+}
+
+// readPump only processes control messages. It exits, and removes the
+// consumer from its stream, when the connection is closed.
+func (c *wsConsumer) readPump() {
+	defer func() {
+		c.onClose()
+		// close is called by both the writePump and the readPump so one of them
+		// will always error
+		_ = c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(streamMaxReadSize)
+	// SetReadDeadline returns an error if the connection is corrupted
+	if err := c.conn.SetReadDeadline(time.Now().Add(streamPongWait)); err != nil {
+		return
+	}
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				c.log.Debug("unexpected close of websocket stream: %s", err)
+			}
+			return
+		}
+	}
+}
+
+// writePump writes the stream's events to the connection
+func (c *wsConsumer) writePump() {
+	ticker := time.NewTicker(streamPingPeriod)
+	defer func() {
+		ticker.Stop()
+		// close is called by both the writePump and the readPump so one of them
+		// will always error
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg := <-c.send:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+			if err := c.conn.WriteMessage(msg.messageType, msg.data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-c.quit:
+			// The consumer was removed from the stream. Attempt to close the
+			// connection gracefully.
+			_ = c.conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "stream closed"),
+				time.Now().Add(streamWriteWait),
+			)
+			return
+		}
+	}
+}
+
+// replay feeds the consumer with the events of [l], starting at [start], until
+// the consumer is closed or the log is stopped
+func (c *wsConsumer) replay(l *EventLog, start uint64) {
+	defer c.close()
+
+	next := start
+	for {
+		// Grab the notification channel before reading, so that an event
+		// appended after the read isn't missed
+		updated := l.Updated()
+		events, err := l.Range(next, replayBatchSize)
+		if err != nil {
+			c.log.Debug("stopping websocket replay of chain %s: %s", c.chainID, err)
+			return
+		}
+
+		for _, event := range events {
+			msg, err := c.encode(AcceptEvent, event.Sequence, event.ContainerID, event.Container, event.Timestamp)
+			if err != nil {
+				c.log.Debug("stopping websocket replay of chain %s: %s", c.chainID, err)
+				return
+			}
+			select {
+			case c.send <- msg:
+			case <-c.quit:
+				return
+			}
+			next = event.Sequence + 1
+		}
+		if len(events) == replayBatchSize {
+			continue
+		}
+
+		select {
+		case <-updated:
+		case <-l.Closed():
+			return
+		case <-c.quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/triggers"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+func newTestStreamServer(t *testing.T, opts StreamOptions) (*ChainIPCs, *triggers.EventDispatcher, *snow.Context, string) {
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+
+	consensusEvents := &triggers.EventDispatcher{}
+	consensusEvents.Initialize(logging.NoLog{})
+	decisionEvents := &triggers.EventDispatcher{}
+	decisionEvents.Initialize(logging.NoLog{})
+
+	cipcs, err := NewChainIPCs(logging.NoLog{}, DefaultBaseURL, 12345, consensusEvents, decisionEvents, nil, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := cipcs.ServeStream(w, r, ctx.ChainID, ipcDecisionsIdentifier, opts); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return cipcs, decisionEvents, ctx, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// numConsumers returns the number of consumers of the stream, or -1 if the
+// stream doesn't exist
+func numConsumers(cipcs *ChainIPCs, chainID ids.ID, stream string) int {
+	cipcs.streamsLock.Lock()
+	defer cipcs.streamsLock.Unlock()
+
+	s, exists := cipcs.streams[chainID][stream]
+	if !exists {
+		return -1
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.consumers)
+}
+
+func awaitConsumers(t *testing.T, cipcs *ChainIPCs, chainID ids.ID, expected int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for numConsumers(cipcs, chainID, ipcDecisionsIdentifier) != expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d consumers but got %d", expected, numConsumers(cipcs, chainID, ipcDecisionsIdentifier))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWebsocketStreamDeliversAccepts(t *testing.T) {
+	cipcs, events, ctx, url := newTestStreamServer(t, StreamOptions{})
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	awaitConsumers(t, cipcs, ctx.ChainID, 1)
+
+	// Without a subscription, only accepts are delivered
+	events.Issue(ctx, ids.GenerateTestID(), []byte{0})
+	events.Accept(ctx, ids.GenerateTestID(), []byte{1, 2, 3})
+
+	messageType, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if messageType != websocket.BinaryMessage {
+		t.Fatalf("expected a binary message but got type %d", messageType)
+	}
+	p := wrappers.Packer{MaxSize: wrappers.LongLen + 3}
+	p.PackLong(3)
+	p.PackFixedBytes([]byte{1, 2, 3})
+	if !bytes.Equal(p.Bytes, data) {
+		t.Fatalf("expected the length prefixed container %v but got %v", p.Bytes, data)
+	}
+}
+
+func TestWebsocketStreamDeliversSubscribedEvents(t *testing.T) {
+	filter, err := ParseEventFilter("issue,reject")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cipcs, events, ctx, url := newTestStreamServer(t, StreamOptions{
+		Encoding: JSONEncoding,
+		Events:   filter,
+	})
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	awaitConsumers(t, cipcs, ctx.ChainID, 1)
+
+	acceptedID := ids.GenerateTestID()
+	rejectedID := ids.GenerateTestID()
+	events.Accept(ctx, acceptedID, []byte{1})
+	events.Reject(ctx, rejectedID, []byte{2})
+
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := StreamMessage{}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatal(err)
+	}
+	bytesStr, err := formatting.Encode(formatting.Hex, []byte{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case msg.Kind != RejectEvent.String():
+		t.Fatalf("expected a %s event but got %s", RejectEvent, msg.Kind)
+	case msg.ChainID != ctx.ChainID.String():
+		t.Fatalf("expected chain %s but got %s", ctx.ChainID, msg.ChainID)
+	case msg.ContainerID != rejectedID.String():
+		t.Fatalf("expected container %s but got %s", rejectedID, msg.ContainerID)
+	case msg.Bytes != bytesStr:
+		t.Fatalf("expected bytes %s but got %s", bytesStr, msg.Bytes)
+	}
+}
+
+func TestWebsocketStreamRemovedWhenEmpty(t *testing.T) {
+	cipcs, _, ctx, url := newTestStreamServer(t, StreamOptions{})
+
+	conn0, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn1, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	awaitConsumers(t, cipcs, ctx.ChainID, 2)
+
+	if err := conn0.Close(); err != nil {
+		t.Fatal(err)
+	}
+	awaitConsumers(t, cipcs, ctx.ChainID, 1)
+
+	if err := conn1.Close(); err != nil {
+		t.Fatal(err)
+	}
+	awaitConsumers(t, cipcs, ctx.ChainID, -1)
+
+	cipcs.streamsLock.Lock()
+	numChains := len(cipcs.streams)
+	cipcs.streamsLock.Unlock()
+	if numChains != 0 {
+		t.Fatalf("expected no streams but %d chains have streams", numChains)
+	}
+
+	// The stream was unregistered, so it can be created again
+	conn2, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn2.Close()
+	awaitConsumers(t, cipcs, ctx.ChainID, 1)
+}
+
+func TestWebsocketUnknownStream(t *testing.T) {
+	cipcs, _, ctx, _ := newTestStreamServer(t, StreamOptions{})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	if err := cipcs.ServeStream(w, r, ctx.ChainID, "unknown", StreamOptions{}); err != errUnknownStream {
+		t.Fatalf("expected %s but got %v", errUnknownStream, err)
+	}
+	if numChains := len(cipcs.streams); numChains != 0 {
+		t.Fatalf("expected no streams but %d chains have streams", numChains)
+	}
+}
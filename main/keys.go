@@ -67,21 +67,30 @@ const (
 	snowAvalancheBatchSizeKey       = "snow-avalanche-batch-size"
 	snowConcurrentRepollsKey        = "snow-concurrent-repolls"
 	whitelistedSubnetsKey           = "whitelisted-subnets"
+	privateSubnetsKey               = "private-subnets"
 	adminAPIEnabledKey              = "api-admin-enabled"
 	infoAPIEnabledKey               = "api-info-enabled"
 	keystoreAPIEnabledKey           = "api-keystore-enabled"
 	metricsAPIEnabledKey            = "api-metrics-enabled"
+	metricsPushGatewayURLKey        = "metrics-push-gateway-url"
+	metricsPushFileEnabledKey       = "metrics-push-file-enabled"
+	metricsPushFrequencyKey         = "metrics-push-frequency"
 	healthAPIEnabledKey             = "api-health-enabled"
 	xrouterAPIEnabledKey            = "api-xrouter-enabled"
 	ipcAPIEnabledKey                = "api-ipcs-enabled"
+	indexAddressTxsKey              = "index-address-txs"
+	indexAssetsKey                  = "index-assets"
 	xputServerPortKey               = "xput-server-port"
 	xputServerEnabledKey            = "xput-server-enabled"
 	ipcsChainIDsKey                 = "ipcs-chain-ids"
 	ipcsPathKey                     = "ipcs-path"
+	ipcsLogEnabledKey               = "ipcs-log-enabled"
+	ipcsLogRetentionKey             = "ipcs-log-retention"
 	consensusGossipFrequencyKey     = "consensus-gossip-frequency"
 	consensusShutdownTimeoutKey     = "consensus-shutdown-timeout"
 	fdLimitKey                      = "fd-limit"
 	corethConfigKey                 = "coreth-config"
+	chainConfigDirKey               = "chain-config-dir"
 	disconnectedCheckFreqKey        = "disconnected-check-frequency"
 	disconnectedRestartTimeoutKey   = "disconnected-restart-timeout"
 	restartOnDisconnectedKey        = "restart-on-disconnected"
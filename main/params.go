@@ -8,6 +8,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"os"
 	"path"
@@ -18,6 +19,7 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/genesis"
@@ -51,6 +53,7 @@ var (
 	defaultDbDir           = filepath.Join(homeDir, prefixedAppName, "db")
 	defaultStakingKeyPath  = filepath.Join(homeDir, prefixedAppName, "staking", "staker.key")
 	defaultStakingCertPath = filepath.Join(homeDir, prefixedAppName, "staking", "staker.crt")
+	defaultChainConfigDir  = filepath.Join(homeDir, prefixedAppName, "configs", "chains")
 	defaultPluginDirs      = []string{
 		filepath.Join(".", "build", "plugins"),
 		filepath.Join(".", "plugins"),
@@ -62,9 +65,11 @@ var (
 )
 
 var (
-	errBootstrapMismatch    = errors.New("more bootstrap IDs provided than bootstrap IPs")
-	errStakingRequiresTLS   = errors.New("if staking is enabled, network TLS must also be enabled")
-	errInvalidStakerWeights = errors.New("staking weights must be positive")
+	errBootstrapMismatch     = errors.New("more bootstrap IDs provided than bootstrap IPs")
+	errStakingRequiresTLS    = errors.New("if staking is enabled, network TLS must also be enabled")
+	errInvalidStakerWeights  = errors.New("staking weights must be positive")
+	errPrivatePrimaryNetwork = errors.New("the primary network can't be private")
+	errPrivateNotWhitelisted = errors.New("private subnets must be whitelisted")
 )
 
 // avalancheFlagSet returns the complete set of flags for avalanchego
@@ -203,6 +208,13 @@ func avalancheFlagSet() *flag.FlagSet {
 	fs.Bool(healthAPIEnabledKey, true, "If true, this node exposes the Health API")
 	fs.Bool(xrouterAPIEnabledKey, true, "If true, this node exposes the Health API")
 	fs.Bool(ipcAPIEnabledKey, false, "If true, IPCs can be opened")
+	fs.Bool(indexAddressTxsKey, false, "If true, the X-Chain indexes the transactions that touched each address, so they can be fetched with avm.getAddressTxs. If enabled after transactions were accepted, the index is built on startup")
+	fs.Bool(indexAssetsKey, false, "If true, the X-Chain indexes the supply, holders and mint outputs of each asset, so they can be fetched with avm.getAssetSupply, avm.getAssetHolders and avm.getAssetMintOwners. If enabled after transactions were accepted, the index is built on startup")
+
+	// Metrics Push:
+	fs.String(metricsPushGatewayURLKey, "", "URL of a Pushgateway compatible endpoint to periodically push metrics to. If empty, metrics aren't pushed to a gateway")
+	fs.Bool(metricsPushFileEnabledKey, false, "If true, metrics are periodically written to a file in the logging directory")
+	fs.Duration(metricsPushFrequencyKey, 15*time.Second, "Frequency of pushing metrics")
 
 	// Throughput Server
 	fs.Uint(xputServerPortKey, 9652, "Port of the deprecated throughput test server")
@@ -211,6 +223,8 @@ func avalancheFlagSet() *flag.FlagSet {
 	// IPC
 	fs.String(ipcsChainIDsKey, "", "Comma separated list of chain ids to add to the IPC engine. Example: 11111111111111111111111111111111LpoYY,4R5p2RXDGLqaifZE4hHWH9owe34pfoBULn1DrQTWivjg8o4aH")
 	fs.String(ipcsPathKey, defaultString, "The directory (Unix) or named pipe name prefix (Windows) for IPC sockets")
+	fs.Bool(ipcsLogEnabledKey, false, "If true, the events of every chain, whether published over IPC or not, are recorded in a replayable log in the database")
+	fs.Uint64(ipcsLogRetentionKey, 100000, "Number of events to keep in each IPC event log. If 0, every event is kept")
 
 	// Router Configuration:
 	fs.Duration(consensusGossipFrequencyKey, 10*time.Second, "Frequency of gossiping accepted frontiers.")
@@ -228,10 +242,14 @@ func avalancheFlagSet() *flag.FlagSet {
 
 	// Subnet Whitelist
 	fs.String(whitelistedSubnetsKey, "", "Whitelist of subnets to validate.")
+	fs.String(privateSubnetsKey, "", "Subnets whose chains only accept consensus and bootstrap messages from the subnet's validators. Each must also be whitelisted.")
 
 	// Coreth Config
 	fs.String(corethConfigKey, defaultString, "Specifies config to pass into coreth")
 
+	// Chain Configs
+	fs.String(chainConfigDirKey, defaultChainConfigDir, "Chain specific configurations parent directory. The config of a chain is read from [chain-config-dir]/[chainID or alias]/config.json")
+
 	return fs
 }
 
@@ -470,6 +488,22 @@ func setNodeConfig(v *viper.Viper) error {
 		}
 	}
 
+	for _, subnet := range strings.Split(v.GetString(privateSubnetsKey), ",") {
+		if subnet != "" {
+			subnetID, err := ids.FromString(subnet)
+			if err != nil {
+				return fmt.Errorf("couldn't parse subnetID %s: %w", subnet, err)
+			}
+			if subnetID == constants.PrimaryNetworkID {
+				return errPrivatePrimaryNetwork
+			}
+			if !Config.WhitelistedSubnets.Contains(subnetID) {
+				return fmt.Errorf("%w: %s", errPrivateNotWhitelisted, subnetID)
+			}
+			Config.PrivateSubnets.Add(subnetID)
+		}
+	}
+
 	// Plugins
 	pluginDir := v.GetString(pluginDirKey)
 	if pluginDir == defaultString {
@@ -514,6 +548,18 @@ func setNodeConfig(v *viper.Viper) error {
 	Config.HealthAPIEnabled = v.GetBool(healthAPIEnabledKey)
 	Config.XRouterAPIEnabled = v.GetBool(xrouterAPIEnabledKey)
 	Config.IPCAPIEnabled = v.GetBool(ipcAPIEnabledKey)
+	Config.IndexAddressTxs = v.GetBool(indexAddressTxsKey)
+	Config.IndexAssets = v.GetBool(indexAssetsKey)
+
+	// Metrics Push:
+	Config.MetricsPushGatewayURL = v.GetString(metricsPushGatewayURLKey)
+	if v.GetBool(metricsPushFileEnabledKey) {
+		Config.MetricsPushFilePath = path.Join(loggingConfig.Directory, metrics.DefaultPushFileName)
+	}
+	Config.MetricsPushFrequency = v.GetDuration(metricsPushFrequencyKey)
+	if Config.MetricsPushFrequency <= 0 {
+		return errors.New("metrics push frequency must be positive")
+	}
 
 	// Throughput:
 	Config.ThroughputServerEnabled = v.GetBool(xputServerEnabledKey)
@@ -534,6 +580,8 @@ func setNodeConfig(v *viper.Viper) error {
 	} else {
 		Config.IPCPath = ipcsPath
 	}
+	Config.IPCLogEnabled = v.GetBool(ipcsLogEnabledKey)
+	Config.IPCLogRetention = v.GetUint64(ipcsLogRetentionKey)
 
 	// Throttling
 	Config.MaxNonStakerPendingMsgs = v.GetUint(maxNonStakerPendingMsgsKey)
@@ -667,9 +715,43 @@ func setNodeConfig(v *viper.Viper) error {
 	}
 	Config.CorethConfig = corethConfigString
 
+	// Chain Configs
+	chainConfigs, err := readChainConfigs(v.GetString(chainConfigDirKey))
+	if err != nil {
+		return fmt.Errorf("couldn't read chain configs: %w", err)
+	}
+	Config.ChainConfigs = chainConfigs
+
 	return nil
 }
 
+// readChainConfigs returns the contents of [dir]/[chain]/config.json for each
+// subdirectory [chain] of [dir], keyed by [chain]. A chain's subdirectory is
+// named after the chain's ID or one of its aliases. If [dir] doesn't exist, no
+// chain configs are returned.
+func readChainConfigs(dir string) (map[string][]byte, error) {
+	chainConfigs := make(map[string][]byte)
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return chainConfigs, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		configBytes, err := ioutil.ReadFile(filepath.Join(dir, entry.Name(), "config.json"))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		chainConfigs[entry.Name()] = configBytes
+	}
+	return chainConfigs, nil
+}
+
 func parseViper() error {
 	v, err := getViper()
 	if err != nil {
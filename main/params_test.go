@@ -0,0 +1,62 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadChainConfigsMissingDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chain-configs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	chainConfigs, err := readChainConfigs(filepath.Join(dir, "missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chainConfigs) != 0 {
+		t.Fatalf("expected no chain configs but got %d", len(chainConfigs))
+	}
+}
+
+func TestReadChainConfigs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chain-configs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(path string, contents string) {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join("C", "config.json"), `{"c":1}`)
+	write(filepath.Join("X", "config.json"), `{"x":1}`)
+	// A subdirectory without a config.json and a file outside of any
+	// subdirectory aren't chain configs
+	write(filepath.Join("P", "genesis.json"), `{}`)
+	write("config.json", `{}`)
+
+	chainConfigs, err := readChainConfigs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string][]byte{
+		"C": []byte(`{"c":1}`),
+		"X": []byte(`{"x":1}`),
+	}, chainConfigs)
+}
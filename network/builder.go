@@ -172,3 +172,11 @@ func (m Builder) Chits(chainID ids.ID, requestID uint32, containerIDs []ids.ID)
 		ContainerIDs: containerIDBytes,
 	})
 }
+
+// AppGossip message
+func (m Builder) AppGossip(chainID ids.ID, msg []byte) (Msg, error) {
+	return m.Pack(AppGossip, map[Field]interface{}{
+		ChainID:        chainID[:],
+		ContainerBytes: msg,
+	})
+}
@@ -192,6 +192,8 @@ func (op Op) String() string {
 		return "chits"
 	case VersionNak:
 		return "version_nak"
+	case AppGossip:
+		return "app_gossip"
 	default:
 		return "Unknown Op"
 	}
@@ -220,6 +222,8 @@ const (
 	PullQuery
 	Chits
 	VersionNak
+	// VM-defined:
+	AppGossip
 )
 
 // Defines the messages that can be sent/received with this network
@@ -247,5 +251,7 @@ var (
 		Chits:     {ChainID, RequestID, ContainerIDs},
 		// version nak
 		VersionNak: {ErrorNo, Peers},
+		// VM-defined:
+		AppGossip: {ChainID, ContainerBytes},
 	}
 )
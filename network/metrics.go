@@ -57,7 +57,8 @@ type metrics struct {
 	getAcceptedFrontier, acceptedFrontier,
 	getAccepted, accepted,
 	get, getAncestors, put, multiPut,
-	pushQuery, pullQuery, chits, versionNak messageMetrics
+	pushQuery, pullQuery, chits, versionNak,
+	appGossip messageMetrics
 }
 
 func (m *metrics) initialize(registerer prometheus.Registerer) error {
@@ -91,6 +92,7 @@ func (m *metrics) initialize(registerer prometheus.Registerer) error {
 		m.pullQuery.initialize(PullQuery, registerer),
 		m.chits.initialize(Chits, registerer),
 		m.versionNak.initialize(VersionNak, registerer),
+		m.appGossip.initialize(AppGossip, registerer),
 	)
 	return errs.Err
 }
@@ -133,6 +135,8 @@ func (m *metrics) message(msgType Op) *messageMetrics {
 		return &m.chits
 	case VersionNak:
 		return &m.versionNak
+	case AppGossip:
+		return &m.appGossip
 	default:
 		return nil
 	}
@@ -644,6 +644,15 @@ func (n *network) Gossip(chainID, containerID ids.ID, container []byte) {
 	}
 }
 
+// AppGossip implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) AppGossip(chainID ids.ID, appMsg []byte) {
+	if err := n.gossipAppMsg(chainID, appMsg); err != nil {
+		n.log.Debug("failed to AppGossip(%s): %s", chainID, err)
+		n.log.Verbo("message:\n%s", formatting.DumpBytes{Bytes: appMsg})
+	}
+}
+
 // Accept is called after every consensus decision
 // assumes the stateLock is not held.
 func (n *network) Accept(ctx *snow.Context, containerID ids.ID, container []byte) error {
@@ -842,6 +851,44 @@ func (n *network) gossipContainer(chainID, containerID ids.ID, container []byte)
 	return nil
 }
 
+// gossipAppMsg sends [appMsg] to a sample of the connected validators
+// assumes the stateLock is not held.
+func (n *network) gossipAppMsg(chainID ids.ID, appMsg []byte) error {
+	msg, err := n.b.AppGossip(chainID, appMsg)
+	if err != nil {
+		return fmt.Errorf("attempted to pack too large of an AppGossip message.\nMessage length: %d", len(appMsg))
+	}
+
+	validatorPeers := []*peer(nil)
+	for _, peer := range n.getAllPeers() {
+		if peer.connected.GetValue() && n.vdrs.Contains(peer.id) {
+			validatorPeers = append(validatorPeers, peer)
+		}
+	}
+
+	numToGossip := n.gossipSize
+	if numToGossip > len(validatorPeers) {
+		numToGossip = len(validatorPeers)
+	}
+
+	s := sampler.NewUniform()
+	if err := s.Initialize(uint64(len(validatorPeers))); err != nil {
+		return err
+	}
+	indices, err := s.Sample(numToGossip)
+	if err != nil {
+		return err
+	}
+	for _, index := range indices {
+		if validatorPeers[int(index)].Send(msg) {
+			n.appGossip.numSent.Inc()
+		} else {
+			n.appGossip.numFailed.Inc()
+		}
+	}
+	return nil
+}
+
 // assumes the stateLock is held.
 func (n *network) track(ip utils.IPDesc) {
 	if n.closed.GetValue() {
@@ -80,12 +80,25 @@ type Config struct {
 	HealthAPIEnabled   bool
 	XRouterAPIEnabled  bool
 
+	// Metrics push configuration. Metrics are only pushed if a gateway URL or
+	// a file path is provided.
+	MetricsPushGatewayURL string
+	MetricsPushFilePath   string
+	MetricsPushFrequency  time.Duration
+
 	// Logging configuration
 	LoggingConfig logging.Config
 
 	// Plugin directory
 	PluginDir string
 
+	// If true, the X-Chain indexes the transactions that touched each address
+	IndexAddressTxs bool
+
+	// If true, the X-Chain indexes the supply, holders and mint outputs of
+	// each asset
+	IndexAssets bool
+
 	// Consensus configuration
 	ConsensusParams avalanche.Parameters
 
@@ -97,6 +110,8 @@ type Config struct {
 	IPCAPIEnabled      bool
 	IPCPath            string
 	IPCDefaultChainIDs []string
+	IPCLogEnabled      bool
+	IPCLogRetention    uint64
 
 	// Router that is used to handle incoming consensus messages
 	ConsensusRouter          router.Router
@@ -115,6 +130,9 @@ type Config struct {
 	// Subnet Whitelist
 	WhitelistedSubnets ids.Set
 
+	// Subnets whose chains only accept messages from the subnet's validators
+	PrivateSubnets ids.Set
+
 	// Restart on disconnect settings
 	RestartOnDisconnected      bool
 	DisconnectedCheckFreq      time.Duration
@@ -122,4 +140,7 @@ type Config struct {
 
 	// Coreth
 	CorethConfig string
+
+	// Chain configs, keyed by chain ID or alias
+	ChainConfigs map[string][]byte
 }
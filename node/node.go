@@ -18,6 +18,8 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/admin"
 	"github.com/ava-labs/avalanchego/api/health"
@@ -110,6 +112,9 @@ type Node struct {
 
 	IPCs *ipcs.ChainIPCs
 
+	// Periodically pushes this node's metrics, if enabled
+	metricsPusher *metrics.Pusher
+
 	// Net runs the networking stack
 	Net network.Network
 
@@ -465,8 +470,13 @@ func (n *Node) initIPCs() error {
 		chainIDs[i] = id
 	}
 
+	var logDB database.Database
+	if n.Config.IPCLogEnabled {
+		logDB = prefixdb.New([]byte("ipcs"), n.DB)
+	}
+
 	var err error
-	n.IPCs, err = ipcs.NewChainIPCs(n.Log, n.Config.IPCPath, n.Config.NetworkID, n.ConsensusDispatcher, n.DecisionDispatcher, chainIDs)
+	n.IPCs, err = ipcs.NewChainIPCs(n.Log, n.Config.IPCPath, n.Config.NetworkID, n.ConsensusDispatcher, n.DecisionDispatcher, chainIDs, logDB, n.Config.IPCLogRetention)
 	return err
 }
 
@@ -571,6 +581,8 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		TimeoutManager:          &timeoutManager,
 		HealthService:           n.healthService,
 		WhitelistedSubnets:      n.Config.WhitelistedSubnets,
+		PrivateSubnets:          n.Config.PrivateSubnets,
+		ChainConfigs:            n.Config.ChainConfigs,
 	})
 
 	vdrs := n.vdrs
@@ -602,8 +614,10 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			ApricotPhase0Time:  n.Config.ApricotPhase0Time,
 		}),
 		n.vmManager.RegisterVMFactory(avm.ID, &avm.Factory{
-			CreationFee: n.Config.CreationTxFee,
-			Fee:         n.Config.TxFee,
+			CreationFee:     n.Config.CreationTxFee,
+			Fee:             n.Config.TxFee,
+			IndexAddressTxs: n.Config.IndexAddressTxs,
+			IndexAssets:     n.Config.IndexAssets,
 		}),
 		n.vmManager.RegisterVMFactory(evm.ID, &rpcchainvm.Factory{
 			Path:   filepath.Join(n.Config.PluginDir, "evm"),
@@ -657,6 +671,12 @@ func (n *Node) initMetricsAPI() error {
 	// It is assumed by components of the system that the Metrics interface is
 	// non-nil. So, it is set regardless of if the metrics API is available or not.
 	n.Config.ConsensusParams.Metrics = registry
+
+	// Pushing metrics doesn't depend on the API being exposed
+	if err := n.initMetricsPusher(registry); err != nil {
+		return err
+	}
+
 	if !n.Config.MetricsAPIEnabled {
 		n.Log.Info("skipping metrics API initialization because it has been disabled")
 		return nil
@@ -674,6 +694,28 @@ func (n *Node) initMetricsAPI() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "metrics", "", n.HTTPLog)
 }
 
+// initMetricsPusher starts periodically pushing the metrics in [registry] if a
+// push gateway URL or a push file was provided
+func (n *Node) initMetricsPusher(registry prometheus.Gatherer) error {
+	if n.Config.MetricsPushGatewayURL == "" && n.Config.MetricsPushFilePath == "" {
+		return nil
+	}
+
+	n.Log.Info("initializing metrics pusher")
+	pusher, err := metrics.NewPusher(n.Log, registry, metrics.PushConfig{
+		GatewayURL: n.Config.MetricsPushGatewayURL,
+		FilePath:   n.Config.MetricsPushFilePath,
+		NodeID:     n.ID.PrefixedString(constants.NodeIDPrefix),
+		Frequency:  n.Config.MetricsPushFrequency,
+	})
+	if err != nil {
+		return err
+	}
+	n.metricsPusher = pusher
+	go n.Log.RecoverAndPanic(pusher.Dispatch)
+	return nil
+}
+
 // initAdminAPI initializes the Admin API service
 // Assumes n.log, n.chainManager, and n.ValidatorAPI already initialized
 func (n *Node) initAdminAPI() error {
@@ -765,7 +807,14 @@ func (n *Node) initIPCAPI() error {
 	if err != nil {
 		return err
 	}
-	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "ipcs", "", n.HTTPLog)
+	if err := n.APIServer.AddRoute(service, &sync.RWMutex{}, "ipcs", "", n.HTTPLog); err != nil {
+		return err
+	}
+	if !n.Config.APIRequireAuthToken {
+		n.Log.Warn("websocket IPC streams require an auth token, which can only be issued when API authorization is enabled")
+	}
+	streamHandler := ipcsapi.NewStreamHandler(n.Log, n.chainManager, n.IPCs, n.APIServer.Auth())
+	return n.APIServer.AddRoute(streamHandler, &sync.RWMutex{}, "ipcs", "/ws", n.HTTPLog)
 }
 
 // Give chains and VMs aliases as specified by the genesis information
@@ -937,6 +986,9 @@ func (n *Node) shutdown() {
 	if n.chainManager != nil {
 		n.chainManager.Shutdown()
 	}
+	if n.metricsPusher != nil {
+		n.metricsPusher.Stop()
+	}
 	if n.Net != nil {
 		// Close already logs its own error if one occurs, so the error is ignored here
 		_ = n.Net.Close()
@@ -43,6 +43,9 @@ type Consensus interface {
 	// TxIssued returns true if a vertex containing this transanction has been added
 	TxIssued(snowstorm.Tx) bool
 
+	// Returns the set of processing transactions conflicting with <Tx>
+	Conflicts(snowstorm.Tx) ids.Set
+
 	// Returns the set of transaction IDs that are virtuous but not contained in
 	// any preferred vertices.
 	Orphans() ids.Set
@@ -139,6 +139,9 @@ func (ta *Topological) VertexIssued(vtx Vertex) bool {
 // TxIssued implements the Avalanche interface
 func (ta *Topological) TxIssued(tx snowstorm.Tx) bool { return ta.cg.Issued(tx) }
 
+// Conflicts implements the Avalanche interface
+func (ta *Topological) Conflicts(tx snowstorm.Tx) ids.Set { return ta.cg.Conflicts(tx) }
+
 // Orphans implements the Avalanche interface
 func (ta *Topological) Orphans() ids.Set { return ta.orphans }
 
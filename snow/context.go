@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
@@ -68,6 +69,14 @@ type Context struct {
 	BCLookup            AliasLookup
 	SNLookup            SubnetLookup
 
+	// Looks up the validator sets of subnets at past heights of the P-Chain.
+	// Nil if this chain was created before the P-Chain.
+	ValidatorState validators.State
+
+	// Configuration the node operator supplied for this chain. Nil if no
+	// configuration was supplied.
+	ChainConfig []byte
+
 	// Non-zero iff this chain bootstrapped. Should only be accessed atomically.
 	bootstrapped uint32
 	Namespace    string
@@ -298,6 +298,20 @@ func (t *Transitive) PushQuery(vdr ids.ShortID, requestID uint32, vtxID ids.ID,
 	return t.PullQuery(vdr, requestID, vtx.ID())
 }
 
+// AppGossip implements the Engine interface
+func (t *Transitive) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	if !t.Ctx.IsBootstrapped() {
+		t.Ctx.Log.Verbo("dropping AppGossip(%s) due to bootstrapping", nodeID)
+		return nil
+	}
+	appVM, ok := t.VM.(common.AppVM)
+	if !ok {
+		t.Ctx.Log.Debug("dropping AppGossip(%s) as the VM doesn't handle app messages", nodeID)
+		return nil
+	}
+	return appVM.AppGossip(nodeID, msg)
+}
+
 // Chits implements the Engine interface
 func (t *Transitive) Chits(vdr ids.ShortID, requestID uint32, votes []ids.ID) error {
 	if !t.Ctx.IsBootstrapped() {
@@ -23,3 +23,20 @@ type DAGVM interface {
 	// Retrieve a transaction that was submitted previously
 	GetTx(ids.ID) (snowstorm.Tx, error)
 }
+
+// ConflictGraph reports the conflicts between the transactions that consensus
+// is deciding
+type ConflictGraph interface {
+	// Returns the IDs of the processing transactions that spend an input that
+	// [tx] spends
+	Conflicts(tx snowstorm.Tx) ids.Set
+}
+
+// ConflictAwareVM is implemented by DAG VMs that report the conflicts between
+// their processing transactions
+type ConflictAwareVM interface {
+	// SetConflictGraph is called once the VM is initialized with the conflict
+	// graph of the chain's consensus. It must only be queried while
+	// [ctx.Lock] is held.
+	SetConflictGraph(graph ConflictGraph)
+}
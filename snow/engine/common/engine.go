@@ -36,6 +36,7 @@ type ExternalHandler interface {
 	AcceptedHandler
 	FetchHandler
 	QueryHandler
+	AppHandler
 }
 
 // FrontierHandler defines how a consensus engine reacts to frontier messages
@@ -276,6 +277,17 @@ type QueryHandler interface {
 	QueryFailed(validatorID ids.ShortID, requestID uint32) error
 }
 
+// AppHandler defines how a consensus engine reacts to VM-defined messages from
+// other nodes. The engine passes these messages to its VM.
+type AppHandler interface {
+	// Notify this engine of a VM-defined message gossiped by [nodeID].
+	//
+	// This function can be called by any node. It is not safe to assume
+	// anything about the contents of [msg]. However, the nodeID is assumed to
+	// be authenticated.
+	AppGossip(nodeID ids.ShortID, msg []byte) error
+}
+
 // InternalHandler defines how this consensus engine reacts to messages from
 // other components of this validator. Functions only return fatal errors if
 // they occur.
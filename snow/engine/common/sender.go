@@ -15,6 +15,7 @@ type Sender interface {
 	FetchSender
 	QuerySender
 	Gossiper
+	AppSender
 }
 
 // FrontierSender defines how a consensus engine sends frontier messages to
@@ -86,3 +87,10 @@ type Gossiper interface {
 	// Gossip gossips the provided container throughout the network
 	Gossip(containerID ids.ID, container []byte)
 }
+
+// AppSender defines how a VM sends VM-defined messages to the other nodes
+// running its chain
+type AppSender interface {
+	// SendAppGossip gossips [msg] to a sample of the validators
+	SendAppGossip(msg []byte)
+}
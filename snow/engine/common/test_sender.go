@@ -17,7 +17,7 @@ type SenderTest struct {
 	CantGetAccepted, CantAccepted,
 	CantGet, CantGetAncestors, CantPut, CantMultiPut,
 	CantPullQuery, CantPushQuery, CantChits,
-	CantGossip bool
+	CantGossip, CantSendAppGossip bool
 
 	GetAcceptedFrontierF func(ids.ShortSet, uint32)
 	AcceptedFrontierF    func(ids.ShortID, uint32, []ids.ID)
@@ -31,6 +31,7 @@ type SenderTest struct {
 	PullQueryF           func(ids.ShortSet, uint32, ids.ID)
 	ChitsF               func(ids.ShortID, uint32, []ids.ID)
 	GossipF              func(ids.ID, []byte)
+	SendAppGossipF       func([]byte)
 }
 
 // Default set the default callable value to [cant]
@@ -47,6 +48,7 @@ func (s *SenderTest) Default(cant bool) {
 	s.CantPushQuery = cant
 	s.CantChits = cant
 	s.CantGossip = cant
+	s.CantSendAppGossip = cant
 }
 
 // GetAcceptedFrontier calls GetAcceptedFrontierF if it was initialized. If it
@@ -180,3 +182,14 @@ func (s *SenderTest) Gossip(containerID ids.ID, container []byte) {
 		s.T.Fatalf("Unexpectedly called Gossip")
 	}
 }
+
+// SendAppGossip calls SendAppGossipF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *SenderTest) SendAppGossip(msg []byte) {
+	if s.SendAppGossipF != nil {
+		s.SendAppGossipF(msg)
+	} else if s.CantSendAppGossip && s.T != nil {
+		s.T.Fatalf("Unexpectedly called SendAppGossip")
+	}
+}
@@ -65,6 +65,19 @@ type VM interface {
 	Health() (interface{}, error)
 }
 
+// AppVM is implemented by VMs that gossip VM-defined messages between the nodes
+// running their chain
+type AppVM interface {
+	// AppGossip is called when [nodeID] gossiped [msg] to this chain. It's
+	// called while [ctx.Lock] is held. Returned errors are fatal to the chain,
+	// so invalid messages should be dropped rather than reported.
+	AppHandler
+
+	// SetAppSender is called once the VM is initialized with the sender of
+	// the messages this VM gossips
+	SetAppSender(sender AppSender)
+}
+
 // StaticVM describes the functionality that allows a user to interact with a VM
 // statically.
 type StaticVM interface {
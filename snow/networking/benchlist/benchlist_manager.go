@@ -28,6 +28,9 @@ type Manager interface {
 	QueryFailed(ids.ID, ids.ShortID, uint32)
 	// RegisterChain registers a new chain with metrics under [namespac]
 	RegisterChain(*snow.Context, string) error
+	// RemoveChain removes the benchlist of a chain that was stopped, so that
+	// the chain is registered anew when it's restarted
+	RemoveChain(ids.ID)
 }
 
 // Config defines the configuration for a benchlist
@@ -87,6 +90,14 @@ func (bm *benchlistManager) RegisterChain(ctx *snow.Context, namespace string) e
 	return nil
 }
 
+// RemoveChain implements the Manager interface
+func (bm *benchlistManager) RemoveChain(chainID ids.ID) {
+	bm.lock.Lock()
+	defer bm.lock.Unlock()
+
+	delete(bm.chainBenchlists, chainID)
+}
+
 // RegisterQuery implements the Manager interface
 func (bm *benchlistManager) RegisterQuery(
 	chainID ids.ID,
@@ -137,6 +148,7 @@ type noBenchlist struct{}
 func NewNoBenchlist() Manager { return &noBenchlist{} }
 
 func (noBenchlist) RegisterChain(*snow.Context, string) error                         { return nil }
+func (noBenchlist) RemoveChain(ids.ID)                                                {}
 func (noBenchlist) RegisterQuery(ids.ID, ids.ShortID, uint32, constants.MsgType) bool { return true }
 func (noBenchlist) RegisterResponse(ids.ID, ids.ShortID, uint32)                      {}
 func (noBenchlist) QueryFailed(ids.ID, ids.ShortID, uint32)                           {}
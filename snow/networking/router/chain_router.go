@@ -111,7 +111,7 @@ func (sr *ChainRouter) AddChain(chain *Handler) {
 
 	chainID := chain.Context().ChainID
 	sr.log.Debug("registering chain %s with chain router", chainID)
-	chain.toClose = func() { sr.RemoveChain(chainID) }
+	chain.toClose = func() { sr.removeChain(chainID, chain) }
 	sr.chains[chainID] = chain
 
 	for validatorID := range sr.peers {
@@ -121,7 +121,13 @@ func (sr *ChainRouter) AddChain(chain *Handler) {
 
 // RemoveChain removes the specified chain so that incoming
 // messages can't be routed to it
-func (sr *ChainRouter) RemoveChain(chainID ids.ID) {
+func (sr *ChainRouter) RemoveChain(chainID ids.ID) { sr.removeChain(chainID, nil) }
+
+// removeChain removes the chain with ID [chainID]. If [expected] is non-nil,
+// the chain is only removed if [expected] is the handler currently registered
+// for that chain. This prevents a handler that was already replaced, because
+// the chain was restarted, from removing its successor.
+func (sr *ChainRouter) removeChain(chainID ids.ID, expected *Handler) {
 	sr.lock.Lock()
 	chain, exists := sr.chains[chainID]
 	if !exists {
@@ -129,6 +135,11 @@ func (sr *ChainRouter) RemoveChain(chainID ids.ID) {
 		sr.lock.Unlock()
 		return
 	}
+	if expected != nil && chain != expected {
+		sr.log.Debug("not removing chain %s as its handler was replaced", chainID)
+		sr.lock.Unlock()
+		return
+	}
 	delete(sr.chains, chainID)
 	sr.lock.Unlock()
 
@@ -376,6 +387,19 @@ func (sr *ChainRouter) Chits(validatorID ids.ShortID, chainID ids.ID, requestID
 	}
 }
 
+// AppGossip routes an incoming AppGossip message from the validator with ID
+// [validatorID] to the consensus engine working on the chain with ID [chainID]
+func (sr *ChainRouter) AppGossip(validatorID ids.ShortID, chainID ids.ID, msg []byte) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	if chain, exists := sr.chains[chainID]; exists {
+		chain.AppGossip(validatorID, msg)
+	} else {
+		sr.log.Debug("AppGossip(%s, %s) dropped due to unknown chain", validatorID, chainID)
+	}
+}
+
 // QueryFailed routes an incoming QueryFailed message from the validator with ID [validatorID]
 // to the consensus engine working on the chain with ID [chainID]
 func (sr *ChainRouter) QueryFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32) {
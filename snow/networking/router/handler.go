@@ -90,6 +90,10 @@ import (
 type Handler struct {
 	metrics
 
+	// True iff messages from nodes that aren't validators of the chain are
+	// dropped
+	ValidatorOnly bool
+
 	validators validators.Set
 
 	// This is the channel of messages to process
@@ -279,7 +283,7 @@ func (h *Handler) dispatchMsg(msg message) {
 // GetAcceptedFrontier passes a GetAcceptedFrontier message received from the
 // network to the consensus engine.
 func (h *Handler) GetAcceptedFrontier(validatorID ids.ShortID, requestID uint32, deadline time.Time) bool {
-	return h.serviceQueue.PushMessage(message{
+	return h.push(message{
 		messageType: constants.GetAcceptedFrontierMsg,
 		validatorID: validatorID,
 		requestID:   requestID,
@@ -291,7 +295,7 @@ func (h *Handler) GetAcceptedFrontier(validatorID ids.ShortID, requestID uint32,
 // AcceptedFrontier passes a AcceptedFrontier message received from the network
 // to the consensus engine.
 func (h *Handler) AcceptedFrontier(validatorID ids.ShortID, requestID uint32, containerIDs []ids.ID) bool {
-	return h.serviceQueue.PushMessage(message{
+	return h.push(message{
 		messageType:  constants.AcceptedFrontierMsg,
 		validatorID:  validatorID,
 		requestID:    requestID,
@@ -300,6 +304,19 @@ func (h *Handler) AcceptedFrontier(validatorID ids.ShortID, requestID uint32, co
 	})
 }
 
+// push adds [msg], which was received from the network, to the service queue.
+// If only validators may message this chain, messages from other nodes are
+// dropped.
+func (h *Handler) push(msg message) bool {
+	if h.ValidatorOnly && !h.validators.Contains(msg.validatorID) {
+		h.ctx.Log.Debug("dropping message from non-validator %s: %s", msg.validatorID, msg)
+		h.metrics.dropped.Inc()
+		h.metrics.nonValidator.Inc()
+		return false
+	}
+	return h.serviceQueue.PushMessage(msg)
+}
+
 // GetAcceptedFrontierFailed passes a GetAcceptedFrontierFailed message received
 // from the network to the consensus engine.
 func (h *Handler) GetAcceptedFrontierFailed(validatorID ids.ShortID, requestID uint32) {
@@ -313,7 +330,7 @@ func (h *Handler) GetAcceptedFrontierFailed(validatorID ids.ShortID, requestID u
 // GetAccepted passes a GetAccepted message received from the
 // network to the consensus engine.
 func (h *Handler) GetAccepted(validatorID ids.ShortID, requestID uint32, deadline time.Time, containerIDs []ids.ID) bool {
-	return h.serviceQueue.PushMessage(message{
+	return h.push(message{
 		messageType:  constants.GetAcceptedMsg,
 		validatorID:  validatorID,
 		requestID:    requestID,
@@ -326,7 +343,7 @@ func (h *Handler) GetAccepted(validatorID ids.ShortID, requestID uint32, deadlin
 // Accepted passes a Accepted message received from the network to the consensus
 // engine.
 func (h *Handler) Accepted(validatorID ids.ShortID, requestID uint32, containerIDs []ids.ID) bool {
-	return h.serviceQueue.PushMessage(message{
+	return h.push(message{
 		messageType:  constants.AcceptedMsg,
 		validatorID:  validatorID,
 		requestID:    requestID,
@@ -347,7 +364,7 @@ func (h *Handler) GetAcceptedFailed(validatorID ids.ShortID, requestID uint32) {
 
 // GetAncestors passes a GetAncestors message received from the network to the consensus engine.
 func (h *Handler) GetAncestors(validatorID ids.ShortID, requestID uint32, deadline time.Time, containerID ids.ID) bool {
-	return h.serviceQueue.PushMessage(message{
+	return h.push(message{
 		messageType: constants.GetAncestorsMsg,
 		validatorID: validatorID,
 		requestID:   requestID,
@@ -359,7 +376,7 @@ func (h *Handler) GetAncestors(validatorID ids.ShortID, requestID uint32, deadli
 
 // MultiPut passes a MultiPut message received from the network to the consensus engine.
 func (h *Handler) MultiPut(validatorID ids.ShortID, requestID uint32, containers [][]byte) bool {
-	return h.serviceQueue.PushMessage(message{
+	return h.push(message{
 		messageType: constants.MultiPutMsg,
 		validatorID: validatorID,
 		requestID:   requestID,
@@ -379,7 +396,7 @@ func (h *Handler) GetAncestorsFailed(validatorID ids.ShortID, requestID uint32)
 
 // Get passes a Get message received from the network to the consensus engine.
 func (h *Handler) Get(validatorID ids.ShortID, requestID uint32, deadline time.Time, containerID ids.ID) bool {
-	return h.serviceQueue.PushMessage(message{
+	return h.push(message{
 		messageType: constants.GetMsg,
 		validatorID: validatorID,
 		requestID:   requestID,
@@ -391,7 +408,7 @@ func (h *Handler) Get(validatorID ids.ShortID, requestID uint32, deadline time.T
 
 // Put passes a Put message received from the network to the consensus engine.
 func (h *Handler) Put(validatorID ids.ShortID, requestID uint32, containerID ids.ID, container []byte) bool {
-	return h.serviceQueue.PushMessage(message{
+	return h.push(message{
 		messageType: constants.PutMsg,
 		validatorID: validatorID,
 		requestID:   requestID,
@@ -412,7 +429,7 @@ func (h *Handler) GetFailed(validatorID ids.ShortID, requestID uint32) {
 
 // PushQuery passes a PushQuery message received from the network to the consensus engine.
 func (h *Handler) PushQuery(validatorID ids.ShortID, requestID uint32, deadline time.Time, containerID ids.ID, container []byte) bool {
-	return h.serviceQueue.PushMessage(message{
+	return h.push(message{
 		messageType: constants.PushQueryMsg,
 		validatorID: validatorID,
 		requestID:   requestID,
@@ -425,7 +442,7 @@ func (h *Handler) PushQuery(validatorID ids.ShortID, requestID uint32, deadline
 
 // PullQuery passes a PullQuery message received from the network to the consensus engine.
 func (h *Handler) PullQuery(validatorID ids.ShortID, requestID uint32, deadline time.Time, containerID ids.ID) bool {
-	return h.serviceQueue.PushMessage(message{
+	return h.push(message{
 		messageType: constants.PullQueryMsg,
 		validatorID: validatorID,
 		requestID:   requestID,
@@ -437,7 +454,7 @@ func (h *Handler) PullQuery(validatorID ids.ShortID, requestID uint32, deadline
 
 // Chits passes a Chits message received from the network to the consensus engine.
 func (h *Handler) Chits(validatorID ids.ShortID, requestID uint32, votes []ids.ID) bool {
-	return h.serviceQueue.PushMessage(message{
+	return h.push(message{
 		messageType:  constants.ChitsMsg,
 		validatorID:  validatorID,
 		requestID:    requestID,
@@ -446,6 +463,17 @@ func (h *Handler) Chits(validatorID ids.ShortID, requestID uint32, votes []ids.I
 	})
 }
 
+// AppGossip passes an AppGossip message received from the network to the
+// consensus engine.
+func (h *Handler) AppGossip(validatorID ids.ShortID, msg []byte) bool {
+	return h.push(message{
+		messageType: constants.AppGossipMsg,
+		validatorID: validatorID,
+		container:   msg,
+		received:    h.clock.Time(),
+	})
+}
+
 // QueryFailed passes a QueryFailed message received from the network to the consensus engine.
 func (h *Handler) QueryFailed(validatorID ids.ShortID, requestID uint32) {
 	h.sendReliableMsg(message{
@@ -555,6 +583,8 @@ func (h *Handler) handleValidatorMsg(msg message, startTime time.Time) error {
 		err = h.engine.Connected(msg.validatorID)
 	case constants.DisconnectedMsg:
 		err = h.engine.Disconnected(msg.validatorID)
+	case constants.AppGossipMsg:
+		err = h.engine.AppGossip(msg.validatorID, msg.container)
 	}
 	endTime := h.clock.Time()
 	timeConsumed := endTime.Sub(startTime)
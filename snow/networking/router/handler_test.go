@@ -105,6 +105,77 @@ func TestHandlerDoesntDrop(t *testing.T) {
 	}
 }
 
+// Ensure a chain of a private subnet only handles messages from its validators
+func TestHandlerDropsNonValidatorMessages(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = snow.DefaultContextTest
+
+	vdrs := validators.NewSet()
+	vdr0 := ids.GenerateTestShortID()
+	if err := vdrs.AddWeight(vdr0, 1); err != nil {
+		t.Fatal(err)
+	}
+	nonValidator := ids.GenerateTestShortID()
+
+	called := make(chan struct{}, 1)
+	engine.GetAcceptedFrontierF = func(validatorID ids.ShortID, requestID uint32) error {
+		if !validatorID.Equals(vdr0) {
+			t.Fatalf("GetAcceptedFrontier message from a non-validator should have been dropped")
+		}
+		called <- struct{}{}
+		return nil
+	}
+
+	registry := prometheus.NewRegistry()
+	handler := &Handler{ValidatorOnly: true}
+	handler.Initialize(
+		&engine,
+		vdrs,
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		registry,
+	)
+
+	if handler.GetAcceptedFrontier(nonValidator, 1, time.Time{}) {
+		t.Fatal("should have dropped the message from the non-validator")
+	}
+	if handler.Chits(nonValidator, 2, nil) {
+		t.Fatal("should have dropped the message from the non-validator")
+	}
+	if !handler.GetAcceptedFrontier(vdr0, 3, time.Time{}) {
+		t.Fatal("shouldn't have dropped the message from the validator")
+	}
+
+	metrics, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonValidatorDropped := -1.0
+	for _, metric := range metrics {
+		if metric.GetName() == "non_validator_dropped" {
+			nonValidatorDropped = metric.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	if nonValidatorDropped != 2 {
+		t.Fatalf("expected 2 messages counted as dropped from non-validators but got %f", nonValidatorDropped)
+	}
+
+	go handler.Dispatch()
+
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	select {
+	case <-ticker.C:
+		t.Fatalf("Calling engine function timed out")
+	case <-called:
+	}
+}
+
 func TestHandlerClosesOnError(t *testing.T) {
 	engine := common.EngineTest{T: t}
 	engine.Default(false)
@@ -45,6 +45,8 @@ func (m message) String() string {
 		sb.WriteString(fmt.Sprintf("\n    containerID: %s", m.containerID))
 	case constants.MultiPutMsg:
 		sb.WriteString(fmt.Sprintf("\n    numContainers: %d", len(m.containers)))
+	case constants.AppGossipMsg:
+		sb.WriteString(fmt.Sprintf("\n    msgLen: %d", len(m.container)))
 	case constants.NotifyMsg:
 		sb.WriteString(fmt.Sprintf("\n    notification: %s", m.notification))
 	}
@@ -37,6 +37,7 @@ type metrics struct {
 	registerer                  prometheus.Registerer
 	pending                     prometheus.Gauge
 	dropped, expired, throttled prometheus.Counter
+	nonValidator                prometheus.Counter
 	getAcceptedFrontier, acceptedFrontier, getAcceptedFrontierFailed,
 	getAccepted, accepted, getAcceptedFailed,
 	getAncestors, multiPut, getAncestorsFailed,
@@ -45,6 +46,7 @@ type metrics struct {
 	connected, disconnected,
 	notify,
 	gossip,
+	appGossip,
 	cpu,
 	shutdown prometheus.Histogram
 }
@@ -92,6 +94,15 @@ func (m *metrics) Initialize(namespace string, registerer prometheus.Registerer)
 		errs.Add(fmt.Errorf("failed to register throttled statistics due to %w", err))
 	}
 
+	m.nonValidator = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "non_validator_dropped",
+		Help:      "Number of events dropped because the sender isn't a validator of the chain's private subnet",
+	})
+	if err := registerer.Register(m.nonValidator); err != nil {
+		errs.Add(fmt.Errorf("failed to register non_validator_dropped statistics due to %w", err))
+	}
+
 	m.getAcceptedFrontier = initHistogram(namespace, "get_accepted_frontier", registerer, &errs)
 	m.acceptedFrontier = initHistogram(namespace, "accepted_frontier", registerer, &errs)
 	m.getAcceptedFrontierFailed = initHistogram(namespace, "get_accepted_frontier_failed", registerer, &errs)
@@ -112,6 +123,7 @@ func (m *metrics) Initialize(namespace string, registerer prometheus.Registerer)
 	m.disconnected = initHistogram(namespace, "disconnected", registerer, &errs)
 	m.notify = initHistogram(namespace, "notify", registerer, &errs)
 	m.gossip = initHistogram(namespace, "gossip", registerer, &errs)
+	m.appGossip = initHistogram(namespace, "app_gossip", registerer, &errs)
 
 	m.cpu = prometheus.NewHistogram(prometheus.HistogramOpts{
 		Namespace: namespace,
@@ -197,6 +209,8 @@ func (m *metrics) getMSGHistogram(msg constants.MsgType) prometheus.Histogram {
 		return m.connected
 	case constants.DisconnectedMsg:
 		return m.disconnected
+	case constants.AppGossipMsg:
+		return m.appGossip
 	default:
 		panic(fmt.Sprintf("unknown message type %s", msg))
 	}
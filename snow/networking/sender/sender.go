@@ -243,3 +243,9 @@ func (s *Sender) Gossip(containerID ids.ID, container []byte) {
 	s.ctx.Log.Verbo("Gossiping %s", containerID)
 	s.sender.Gossip(s.ctx.ChainID, containerID, container)
 }
+
+// SendAppGossip gossips the provided VM-defined message
+func (s *Sender) SendAppGossip(msg []byte) {
+	s.ctx.Log.Verbo("Gossiping app message of %d bytes", len(msg))
+	s.sender.AppGossip(s.ctx.ChainID, msg)
+}
@@ -40,6 +40,11 @@ func (m *Manager) RegisterChain(ctx *snow.Context, namespace string) error {
 	return m.benchlist.RegisterChain(ctx, namespace)
 }
 
+// RemoveChain forgets the chain with ID [chainID], which was stopped
+func (m *Manager) RemoveChain(chainID ids.ID) {
+	m.benchlist.RemoveChain(chainID)
+}
+
 // Register request to time out unless Manager.Cancel is called
 // before the timeout duration passes, with the same request parameters.
 func (m *Manager) Register(validatorID ids.ShortID, chainID ids.ID, requestID uint32, register bool, msgType constants.MsgType, timeout func()) (time.Time, bool) {
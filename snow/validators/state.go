@@ -0,0 +1,48 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// State allows the lookup of the validator sets of subnets at past heights of
+// the P-Chain
+type State interface {
+	// GetCurrentHeight returns the height of the last accepted P-Chain block
+	GetCurrentHeight() (uint64, error)
+
+	// GetValidatorSet returns the validator set of [subnetID] once the P-Chain
+	// block at [height] was accepted
+	GetValidatorSet(height uint64, subnetID ids.ID) (Set, error)
+}
+
+type lockedState struct {
+	lock sync.Locker
+	s    State
+}
+
+// NewLockedState returns a State that holds [lock] while it calls [s]
+func NewLockedState(lock sync.Locker, s State) State {
+	return &lockedState{
+		lock: lock,
+		s:    s,
+	}
+}
+
+func (s *lockedState) GetCurrentHeight() (uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.s.GetCurrentHeight()
+}
+
+func (s *lockedState) GetValidatorSet(height uint64, subnetID ids.ID) (Set, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.s.GetValidatorSet(height, subnetID)
+}
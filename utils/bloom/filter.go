@@ -0,0 +1,86 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+var (
+	errEmptyFilter    = errors.New("bloom filter has no bits")
+	errNoHashes       = errors.New("bloom filter must use at least one hash")
+	errTooManyHashes  = errors.New("bloom filter uses too many hashes")
+	errFilterTooLarge = errors.New("bloom filter is too large")
+)
+
+// Filter is a bloom filter over byte slices.
+//
+// The positions of an element are derived from the SHA-256 hash of the
+// element: the first 8 bytes, h1, and the next 8 bytes, h2, are read as big
+// endian integers, and the i-th position, for i in [0, numHashes), is
+// (h1 + i*h2) mod (8*len(bits)). Bit p is the bit (p mod 8), counting from
+// the least significant bit, of bits[p/8].
+type Filter struct {
+	bits      []byte
+	numHashes int
+}
+
+// New returns an empty filter with [size] bytes that uses [numHashes] hashes
+func New(size, numHashes, maxSize, maxHashes int) (*Filter, error) {
+	return Parse(make([]byte, size), numHashes, maxSize, maxHashes)
+}
+
+// Parse returns the filter with the given bits. The filter must have at most
+// [maxSize] bytes and use at most [maxHashes] hashes.
+func Parse(bits []byte, numHashes, maxSize, maxHashes int) (*Filter, error) {
+	switch {
+	case len(bits) == 0:
+		return nil, errEmptyFilter
+	case len(bits) > maxSize:
+		return nil, errFilterTooLarge
+	case numHashes <= 0:
+		return nil, errNoHashes
+	case numHashes > maxHashes:
+		return nil, errTooManyHashes
+	}
+	return &Filter{
+		bits:      bits,
+		numHashes: numHashes,
+	}, nil
+}
+
+// Add [elem] to the filter
+func (f *Filter) Add(elem []byte) {
+	h1, h2 := f.hashes(elem)
+	numBits := uint64(len(f.bits)) * 8
+	for i := 0; i < f.numHashes; i++ {
+		pos := (h1 + uint64(i)*h2) % numBits
+		f.bits[pos/8] |= 1 << (pos % 8)
+	}
+}
+
+// Check returns true if [elem] may have been added to the filter. If it
+// returns false, [elem] was definitely not added.
+func (f *Filter) Check(elem []byte) bool {
+	h1, h2 := f.hashes(elem)
+	numBits := uint64(len(f.bits)) * 8
+	for i := 0; i < f.numHashes; i++ {
+		pos := (h1 + uint64(i)*h2) % numBits
+		if f.bits[pos/8]&(1<<(pos%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Bytes returns the bits of the filter
+func (f *Filter) Bytes() []byte { return f.bits }
+
+func (f *Filter) hashes(elem []byte) (uint64, uint64) {
+	hash := hashing.ComputeHash256Array(elem)
+	return binary.BigEndian.Uint64(hash[:8]), binary.BigEndian.Uint64(hash[8:16])
+}
@@ -0,0 +1,53 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bloom
+
+import (
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	f, err := New(64, 3, 1024, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	elems := [][]byte{{0}, {1, 2, 3}, []byte("hello")}
+	for _, elem := range elems {
+		if f.Check(elem) {
+			t.Fatalf("empty filter shouldn't contain %v", elem)
+		}
+		f.Add(elem)
+	}
+	for _, elem := range elems {
+		if !f.Check(elem) {
+			t.Fatalf("filter should contain %v", elem)
+		}
+	}
+
+	parsed, err := Parse(f.Bytes(), 3, 1024, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, elem := range elems {
+		if !parsed.Check(elem) {
+			t.Fatalf("parsed filter should contain %v", elem)
+		}
+	}
+}
+
+func TestFilterLimits(t *testing.T) {
+	if _, err := Parse(nil, 1, 1024, 8); err != errEmptyFilter {
+		t.Fatalf("expected %s but got %v", errEmptyFilter, err)
+	}
+	if _, err := Parse(make([]byte, 1025), 1, 1024, 8); err != errFilterTooLarge {
+		t.Fatalf("expected %s but got %v", errFilterTooLarge, err)
+	}
+	if _, err := Parse(make([]byte, 1), 0, 1024, 8); err != errNoHashes {
+		t.Fatalf("expected %s but got %v", errNoHashes, err)
+	}
+	if _, err := Parse(make([]byte, 1), 9, 1024, 8); err != errTooManyHashes {
+		t.Fatalf("expected %s but got %v", errTooManyHashes, err)
+	}
+}
@@ -30,6 +30,7 @@ const (
 	GetAncestorsMsg
 	MultiPutMsg
 	GetAncestorsFailedMsg
+	AppGossipMsg
 )
 
 func (t MsgType) String() string {
@@ -76,6 +77,8 @@ func (t MsgType) String() string {
 		return "Notify Message"
 	case GossipMsg:
 		return "Gossip Message"
+	case AppGossipMsg:
+		return "App Gossip Message"
 	default:
 		return fmt.Sprintf("Unknown Message Type: %d", t)
 	}
@@ -47,6 +47,9 @@ const (
 	CB58 Encoding = iota
 	// Hex specifies a hex plus 4 byte checksum encoding format
 	Hex
+	// JSON specifies that a value is returned decoded, as a JSON object,
+	// rather than as encoded bytes. It can't be used to encode bytes.
+	JSON
 )
 
 // String ...
@@ -56,11 +59,14 @@ func (enc Encoding) String() string {
 		return "hex"
 	case CB58:
 		return "cb58"
+	case JSON:
+		return "json"
 	default:
 		return errInvalidEncoding.Error()
 	}
 }
 
+// valid returns true if [enc] can be used to encode bytes
 func (enc Encoding) valid() bool {
 	switch enc {
 	case Hex, CB58:
@@ -71,7 +77,7 @@ func (enc Encoding) valid() bool {
 
 // MarshalJSON ...
 func (enc Encoding) MarshalJSON() ([]byte, error) {
-	if !enc.valid() {
+	if !enc.valid() && enc != JSON {
 		return nil, errInvalidEncoding
 	}
 	return []byte("\"" + enc.String() + "\""), nil
@@ -88,6 +94,8 @@ func (enc *Encoding) UnmarshalJSON(b []byte) error {
 		*enc = Hex
 	case "\"cb58\"":
 		*enc = CB58
+	case "\"json\"":
+		*enc = JSON
 	default:
 		return errInvalidEncoding
 	}
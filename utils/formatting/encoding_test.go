@@ -28,6 +28,15 @@ func TestEncodingMarshalJSON(t *testing.T) {
 	if string(jsonBytes) != "\"cb58\"" {
 		t.Fatal("should be 'cb58'")
 	}
+
+	enc3 := JSON
+	jsonBytes, err = enc3.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(jsonBytes) != "\"json\"" {
+		t.Fatal("should be 'json'")
+	}
 }
 
 func TestEncodingUnmarshalJSON(t *testing.T) {
@@ -48,6 +57,14 @@ func TestEncodingUnmarshalJSON(t *testing.T) {
 		t.Fatal("should be cb58")
 	}
 
+	jsonBytes = []byte("\"json\"")
+	if err := json.Unmarshal(jsonBytes, &enc); err != nil {
+		t.Fatal(err)
+	}
+	if enc != JSON {
+		t.Fatal("should be json")
+	}
+
 	jsonBytes = []byte("")
 	if err := json.Unmarshal(jsonBytes, &enc); err == nil {
 		t.Fatal("should have errored due to invalid encoding")
@@ -152,6 +169,16 @@ func TestEncodeNil(t *testing.T) {
 	assert.Equal(t, "0x7852b855", str)
 }
 
+// Test that the JSON encoding can't be used to encode bytes
+func TestEncodeDecodeJSON(t *testing.T) {
+	if _, err := Encode(JSON, []byte{1}); err == nil {
+		t.Fatal("should have errored due to the JSON encoding")
+	}
+	if _, err := Decode(JSON, "{}"); err == nil {
+		t.Fatal("should have errored due to the JSON encoding")
+	}
+}
+
 func TestDecodeHexInvalid(t *testing.T) {
 	invalidHex := []string{"0", "x", "0xg", "0x0017afa0Zd", "0xafafafafaf"}
 	for _, str := range invalidHex {
@@ -0,0 +1,205 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/bloom"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+const (
+	// Maximum number of addresses a connection can filter by
+	maxFilterAddresses = 1024
+
+	// Maximum size of the bloom filter of a connection
+	maxBloomFilterSize = 64 * 1024 // bytes
+
+	// Maximum number of hashes the bloom filter of a connection can use
+	maxBloomFilterHashes = 16
+)
+
+var (
+	errFiltersDisabled     = errors.New("filters aren't supported on this endpoint")
+	errTooManyAddresses    = fmt.Errorf("filters can contain at most %d addresses", maxFilterAddresses)
+	errAddToMissingFilter  = errors.New("can't add addresses to a connection that isn't filtered")
+	errAddWithBloomFilter  = errors.New("can't add a bloom filter to an existing filter")
+	errConnectionNotActive = errors.New("connection isn't active")
+)
+
+// AddressParser returns the bytes of an address that a client gave in a
+// filter
+type AddressParser func(string) ([]byte, error)
+
+// FilterParam is the filter a client subscribes with. A connection with a
+// filter is only sent the messages, published with PublishFiltered, that
+// relate to one of the addresses of the filter. Sending an empty filter
+// removes the filter of the connection.
+type FilterParam struct {
+	// Addresses to filter by
+	Addresses []string `json:"addresses"`
+
+	// If true, [Addresses] are added to the addresses the connection already
+	// filters by, rather than replacing its filter
+	Add bool `json:"add"`
+
+	// Bloom filter of the bytes of the addresses to filter by. Messages that
+	// match either [Addresses] or [Bloom] are sent.
+	Bloom *BloomParam `json:"bloom"`
+}
+
+// BloomParam is a bloom filter a client subscribes with. See bloom.Filter
+// for how addresses are placed in the filter.
+type BloomParam struct {
+	// Bits of the filter
+	Filter string `json:"filter"`
+
+	// Encoding of [Filter]
+	Encoding formatting.Encoding `json:"encoding"`
+
+	// Number of hashes the filter uses
+	Hashes Uint32 `json:"hashes"`
+}
+
+// addressFilter matches messages that relate to one of its addresses
+type addressFilter struct {
+	addresses map[string]struct{}
+	bloom     *bloom.Filter
+}
+
+func (f *addressFilter) matches(addrs [][]byte) bool {
+	for _, addr := range addrs {
+		if _, ok := f.addresses[string(addr)]; ok {
+			return true
+		}
+		if f.bloom != nil && f.bloom.Check(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnableFilters allows connections to filter the messages they are sent by
+// address. [parseAddress] converts the addresses given in filters.
+func (s *PubSubServer) EnableFilters(parseAddress AddressParser) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.parseAddress = parseAddress
+}
+
+// Filtered returns true if any connection filters messages by address. If
+// not, publishers can skip the work of calling PublishFiltered.
+func (s *PubSubServer) Filtered() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.numFiltered > 0
+}
+
+// PublishFiltered sends [msg] to the connections subscribed to [channel]
+// whose filter matches one of [addrs]
+func (s *PubSubServer) PublishFiltered(channel string, msg interface{}, addrs [][]byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	conns, exists := s.channels[channel]
+	if !exists {
+		s.ctx.Log.Warn("attempted to publush to an unknown channel %s", channel)
+		return
+	}
+
+	pubMsg := &publish{
+		Channel: channel,
+		Value:   msg,
+	}
+
+	for conn := range conns {
+		if conn.filter == nil || !conn.filter.matches(addrs) {
+			continue
+		}
+		select {
+		case conn.send <- pubMsg:
+		default:
+			s.ctx.Log.Verbo("dropping message to subscribed connection due to too many pending messages")
+		}
+	}
+}
+
+// updateFilter applies [param] to the filter of [conn]
+func (s *PubSubServer) updateFilter(conn *Connection, param *FilterParam) error {
+	s.lock.Lock()
+	parseAddress := s.parseAddress
+	s.lock.Unlock()
+
+	if parseAddress == nil {
+		return errFiltersDisabled
+	}
+	if len(param.Addresses) > maxFilterAddresses {
+		return errTooManyAddresses
+	}
+
+	// Parse the filter without holding the lock
+	addresses := make(map[string]struct{}, len(param.Addresses))
+	for _, addrStr := range param.Addresses {
+		addr, err := parseAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+		}
+		addresses[string(addr)] = struct{}{}
+	}
+	var bloomFilter *bloom.Filter
+	if param.Bloom != nil {
+		if param.Add {
+			return errAddWithBloomFilter
+		}
+		bits, err := formatting.Decode(param.Bloom.Encoding, param.Bloom.Filter)
+		if err != nil {
+			return fmt.Errorf("couldn't decode bloom filter: %w", err)
+		}
+		bloomFilter, err = bloom.Parse(bits, int(param.Bloom.Hashes), maxBloomFilterSize, maxBloomFilterHashes)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, exists := s.conns[conn]; !exists {
+		return errConnectionNotActive
+	}
+
+	if param.Add {
+		if conn.filter == nil {
+			return errAddToMissingFilter
+		}
+		if len(conn.filter.addresses)+len(addresses) > maxFilterAddresses {
+			return errTooManyAddresses
+		}
+		for addr := range addresses {
+			conn.filter.addresses[addr] = struct{}{}
+		}
+		return nil
+	}
+
+	wasFiltered := conn.filter != nil
+	if len(addresses) == 0 && bloomFilter == nil {
+		conn.filter = nil
+	} else {
+		conn.filter = &addressFilter{
+			addresses: addresses,
+			bloom:     bloomFilter,
+		}
+	}
+	switch isFiltered := conn.filter != nil; {
+	case isFiltered && !wasFiltered:
+		s.numFiltered++
+	case !isFiltered && wasFiltered:
+		s.numFiltered--
+	}
+	return nil
+}
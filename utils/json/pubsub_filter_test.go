@@ -0,0 +1,145 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/bloom"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+func testFilterServer(t *testing.T) (*PubSubServer, *Connection) {
+	s := NewPubSubServer(snow.DefaultContextTest())
+	s.EnableFilters(func(addr string) ([]byte, error) {
+		if addr == "" {
+			return nil, errors.New("empty address")
+		}
+		return []byte(addr), nil
+	})
+	if err := s.Register("accepted"); err != nil {
+		t.Fatal(err)
+	}
+	conn := &Connection{s: s, send: make(chan interface{}, maxPendingMessages)}
+	s.conns[conn] = make(map[string]struct{})
+	s.addChannel(conn, "accepted")
+	return s, conn
+}
+
+func TestPubSubFilterAddresses(t *testing.T) {
+	s, conn := testFilterServer(t)
+
+	if err := s.updateFilter(conn, &FilterParam{Addresses: []string{"alice"}}); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Filtered() {
+		t.Fatal("server should have a filtered connection")
+	}
+
+	s.Publish("accepted", "unfiltered")
+	s.PublishFiltered("accepted", "bob's", [][]byte{[]byte("bob")})
+	s.PublishFiltered("accepted", "alice's", [][]byte{[]byte("bob"), []byte("alice")})
+	if len(conn.send) != 1 {
+		t.Fatalf("expected 1 message but got %d", len(conn.send))
+	}
+	if msg := (<-conn.send).(*publish); msg.Value != "alice's" {
+		t.Fatalf("unexpected message %v", msg.Value)
+	}
+
+	if err := s.updateFilter(conn, &FilterParam{Addresses: []string{"bob"}, Add: true}); err != nil {
+		t.Fatal(err)
+	}
+	s.PublishFiltered("accepted", "bob's", [][]byte{[]byte("bob")})
+	if len(conn.send) != 1 {
+		t.Fatalf("expected 1 message but got %d", len(conn.send))
+	}
+	<-conn.send
+
+	// An empty filter removes the filter
+	if err := s.updateFilter(conn, &FilterParam{}); err != nil {
+		t.Fatal(err)
+	}
+	if s.Filtered() {
+		t.Fatal("server shouldn't have a filtered connection")
+	}
+	s.Publish("accepted", "unfiltered")
+	s.PublishFiltered("accepted", "alice's", [][]byte{[]byte("alice")})
+	if len(conn.send) != 1 {
+		t.Fatalf("expected 1 message but got %d", len(conn.send))
+	}
+}
+
+func TestPubSubFilterBloom(t *testing.T) {
+	s, conn := testFilterServer(t)
+
+	f, err := bloom.New(128, 4, maxBloomFilterSize, maxBloomFilterHashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Add([]byte("alice"))
+	filterStr, err := formatting.Encode(formatting.Hex, f.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.updateFilter(conn, &FilterParam{Bloom: &BloomParam{
+		Filter:   filterStr,
+		Encoding: formatting.Hex,
+		Hashes:   4,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.PublishFiltered("accepted", "alice's", [][]byte{[]byte("alice")})
+	if len(conn.send) != 1 {
+		t.Fatalf("expected 1 message but got %d", len(conn.send))
+	}
+
+	err = s.updateFilter(conn, &FilterParam{Bloom: &BloomParam{
+		Filter:   filterStr,
+		Encoding: formatting.Hex,
+		Hashes:   maxBloomFilterHashes + 1,
+	}})
+	if err == nil {
+		t.Fatal("should have errored due to too many hashes")
+	}
+}
+
+func TestPubSubFilterLimits(t *testing.T) {
+	s, conn := testFilterServer(t)
+
+	addrs := make([]string, maxFilterAddresses+1)
+	for i := range addrs {
+		addrs[i] = fmt.Sprintf("addr%d", i)
+	}
+	if err := s.updateFilter(conn, &FilterParam{Addresses: addrs}); err != errTooManyAddresses {
+		t.Fatalf("expected %s but got %v", errTooManyAddresses, err)
+	}
+	if err := s.updateFilter(conn, &FilterParam{Addresses: addrs[:maxFilterAddresses]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.updateFilter(conn, &FilterParam{Addresses: addrs[maxFilterAddresses:], Add: true}); err != errTooManyAddresses {
+		t.Fatalf("expected %s but got %v", errTooManyAddresses, err)
+	}
+	if err := s.updateFilter(conn, &FilterParam{Addresses: []string{""}}); err == nil {
+		t.Fatal("should have errored due to an invalid address")
+	}
+
+	s.EnableFilters(nil)
+	if err := s.updateFilter(conn, &FilterParam{}); err != errFiltersDisabled {
+		t.Fatalf("expected %s but got %v", errFiltersDisabled, err)
+	}
+
+	s.EnableFilters(func(addr string) ([]byte, error) { return []byte(addr), nil })
+	s.removeConnection(conn)
+	if s.Filtered() {
+		t.Fatal("removed connection shouldn't be filtered")
+	}
+	if err := s.updateFilter(conn, &FilterParam{Addresses: []string{"alice"}}); err != errConnectionNotActive {
+		t.Fatalf("expected %s but got %v", errConnectionNotActive, err)
+	}
+}
@@ -30,8 +30,9 @@ const (
 	// Send pings to peer with this period. Must be less than pongWait.
 	pingPeriod = (pongWait * 9) / 10
 
-	// Maximum message size allowed from peer.
-	maxMessageSize = 512 // bytes
+	// Maximum message size allowed from peer. Large enough to hold a filter
+	// with the maximum number of addresses or the largest bloom filter.
+	maxMessageSize = 256 * 1024 // bytes
 
 	// Maximum number of pending messages to send to a peer.
 	maxPendingMessages = 256 // messages
@@ -51,9 +52,15 @@ var (
 type PubSubServer struct {
 	ctx *snow.Context
 
+	// Converts the addresses given in filters. If nil, connections can't
+	// filter messages.
+	parseAddress AddressParser
+
 	lock     sync.Mutex
 	conns    map[*Connection]map[string]struct{}
 	channels map[string]map[*Connection]struct{}
+	// Number of connections that filter messages by address
+	numFiltered int
 }
 
 // NewPubSubServer ...
@@ -92,6 +99,11 @@ func (s *PubSubServer) Publish(channel string, msg interface{}) {
 	}
 
 	for conn := range conns {
+		if conn.filter != nil {
+			// Filtered connections are only sent messages published with
+			// PublishFiltered
+			continue
+		}
 		select {
 		case conn.send <- pubMsg:
 		default:
@@ -135,6 +147,10 @@ func (s *PubSubServer) removeConnection(conn *Connection) {
 	for channel := range channels {
 		delete(s.channels[channel], conn)
 	}
+	delete(s.conns, conn)
+	if conn.filter != nil {
+		s.numFiltered--
+	}
 }
 
 func (s *PubSubServer) addChannel(conn *Connection, channel string) {
@@ -181,6 +197,13 @@ type publish struct {
 type subscribe struct {
 	Channel     string `json:"channel"`
 	Unsubscribe bool   `json:"unsubscribe"`
+
+	// If provided, updates the filter of the connection
+	Filter *FilterParam `json:"filter"`
+}
+
+type errorMessage struct {
+	Error string `json:"error"`
 }
 
 // Connection is a representation of the websocket connection.
@@ -192,6 +215,10 @@ type Connection struct {
 
 	// Buffered channel of outbound messages.
 	send chan interface{}
+
+	// If non-nil, only messages published with PublishFiltered that match
+	// this filter are sent on this connection. Guarded by the server's lock.
+	filter *addressFilter
 }
 
 // readPump pumps messages from the websocket connection to the hub.
@@ -225,14 +252,31 @@ func (c *Connection) readPump() {
 			}
 			break
 		}
-		if msg.Unsubscribe {
+		if msg.Filter != nil {
+			if err := c.s.updateFilter(c, msg.Filter); err != nil {
+				c.sendError(err)
+				continue
+			}
+		}
+		switch {
+		case msg.Channel == "":
+		case msg.Unsubscribe:
 			c.s.removeChannel(c, msg.Channel)
-		} else {
+		default:
 			c.s.addChannel(c, msg.Channel)
 		}
 	}
 }
 
+// sendError notifies the peer that its last request failed
+func (c *Connection) sendError(err error) {
+	select {
+	case c.send <- &errorMessage{Error: err.Error()}:
+	default:
+		c.s.ctx.Log.Verbo("dropping error message to connection due to too many pending messages")
+	}
+}
+
 // writePump pumps messages from the hub to the websocket connection.
 //
 // A goroutine running writePump is started for each connection. The
@@ -0,0 +1,333 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+const (
+	addressTxsCountPrefix byte = iota
+	addressTxsEntryPrefix
+	addressTxsMetadataPrefix
+)
+
+var (
+	errAddressIndexDisabled  = errors.New("the address index is disabled")
+	errCorruptedAddressIndex = errors.New("address index is corrupted")
+
+	addressTxsBuiltKey = []byte{addressTxsMetadataPrefix}
+)
+
+// addressAsset is an (address, asset) pair that a transaction touched
+type addressAsset struct {
+	address [hashing.AddrLen]byte
+	assetID ids.ID
+}
+
+// addressTxIndex maps (address, assetID) pairs to the IDs of the accepted
+// transactions that consumed or produced UTXOs of the asset owned by the
+// address, in the order they were accepted.
+type addressTxIndex struct {
+	db database.Database
+}
+
+// Built returns true if the index covers every accepted transaction
+func (i *addressTxIndex) Built() (bool, error) {
+	return i.db.Has(addressTxsBuiltKey)
+}
+
+// SetBuilt marks whether the index covers every accepted transaction
+func (i *addressTxIndex) SetBuilt(built bool) error {
+	if built {
+		return i.db.Put(addressTxsBuiltKey, nil)
+	}
+	return i.db.Delete(addressTxsBuiltKey)
+}
+
+// Clear removes every entry of the index
+func (i *addressTxIndex) Clear() error { return clearDatabase(i.db) }
+
+// Add appends [txID] to the transactions of each of the [pairs]
+func (i *addressTxIndex) Add(txID ids.ID, pairs []addressAsset) error {
+	for _, pair := range pairs {
+		count, err := i.Count(pair.address, pair.assetID)
+		if err != nil {
+			return err
+		}
+		if err := i.db.Put(addressTxsEntryKey(pair.address, pair.assetID, count), txID[:]); err != nil {
+			return err
+		}
+		countBytes := make([]byte, wrappers.LongLen)
+		binary.BigEndian.PutUint64(countBytes, count+1)
+		if err := i.db.Put(addressTxsCountKey(pair.address, pair.assetID), countBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Count returns the number of transactions indexed for the pair
+func (i *addressTxIndex) Count(address [hashing.AddrLen]byte, assetID ids.ID) (uint64, error) {
+	countBytes, err := i.db.Get(addressTxsCountKey(address, assetID))
+	switch {
+	case err == database.ErrNotFound:
+		return 0, nil
+	case err != nil:
+		return 0, err
+	case len(countBytes) != wrappers.LongLen:
+		return 0, errCorruptedAddressIndex
+	default:
+		return binary.BigEndian.Uint64(countBytes), nil
+	}
+}
+
+// Get returns up to [limit] transaction IDs of the pair, starting with the
+// transaction at position [start]
+func (i *addressTxIndex) Get(address [hashing.AddrLen]byte, assetID ids.ID, start uint64, limit int) ([]ids.ID, error) {
+	prefix := addressTxsPairKey(addressTxsEntryPrefix, address, assetID)
+	iter := i.db.NewIteratorWithStartAndPrefix(addressTxsEntryKey(address, assetID, start), prefix)
+	defer iter.Release()
+
+	txIDs := []ids.ID(nil)
+	for len(txIDs) < limit && iter.Next() {
+		txID, err := ids.ToID(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, iter.Error()
+}
+
+func addressTxsPairKey(prefix byte, address [hashing.AddrLen]byte, assetID ids.ID) []byte {
+	key := make([]byte, 1+hashing.AddrLen+hashing.HashLen, 1+hashing.AddrLen+hashing.HashLen+wrappers.LongLen)
+	key[0] = prefix
+	copy(key[1:], address[:])
+	copy(key[1+hashing.AddrLen:], assetID[:])
+	return key
+}
+
+func addressTxsCountKey(address [hashing.AddrLen]byte, assetID ids.ID) []byte {
+	return addressTxsPairKey(addressTxsCountPrefix, address, assetID)
+}
+
+func addressTxsEntryKey(address [hashing.AddrLen]byte, assetID ids.ID, index uint64) []byte {
+	key := addressTxsPairKey(addressTxsEntryPrefix, address, assetID)
+	key = key[:len(key)+wrappers.LongLen]
+	binary.BigEndian.PutUint64(key[len(key)-wrappers.LongLen:], index)
+	return key
+}
+
+// addressAssets returns the (address, asset) pairs that own [utxos]
+func addressAssets(utxos []*avax.UTXO) ([]addressAsset, error) {
+	pairs := []addressAsset(nil)
+	seen := make(map[addressAsset]struct{})
+	for _, utxo := range utxos {
+		addressable, ok := utxo.Out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+		assetID := utxo.AssetID()
+		for _, addrBytes := range addressable.Addresses() {
+			addr, err := hashing.ToHash160(addrBytes)
+			if err != nil {
+				return nil, err
+			}
+			pair := addressAsset{
+				address: addr,
+				assetID: assetID,
+			}
+			if _, exists := seen[pair]; exists {
+				continue
+			}
+			seen[pair] = struct{}{}
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs, nil
+}
+
+// indexTxAddresses adds the accepted [tx] to the address index. [consumed] are
+// the UTXOs that [tx] consumed.
+func (vm *VM) indexTxAddresses(tx *Tx, consumed []*avax.UTXO) error {
+	utxos := tx.UTXOs()
+	pairs, err := addressAssets(append(consumed, utxos...))
+	if err != nil {
+		return err
+	}
+	return vm.addressTxs.Add(tx.ID(), pairs)
+}
+
+// spentUTXOs returns the UTXOs of this chain that will be consumed when [tx]
+// is accepted. Must be called before the UTXOs are removed from the state.
+func (vm *VM) spentUTXOs(tx *Tx) ([]*avax.UTXO, error) {
+	utxos := []*avax.UTXO(nil)
+	for _, utxoID := range tx.InputUTXOs() {
+		if utxoID.Symbolic() {
+			continue
+		}
+		utxo, err := vm.state.UTXO(utxoID.InputID())
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, utxo)
+	}
+	return utxos, nil
+}
+
+// importedUTXOs returns the UTXOs that [tx] imports from another chain. Must
+// be called before the UTXOs are removed from shared memory.
+func (vm *VM) importedUTXOs(tx *Tx) ([]*avax.UTXO, error) {
+	utxos := []*avax.UTXO(nil)
+	importTx, ok := tx.UnsignedTx.(*ImportTx)
+	if !ok || len(importTx.ImportedIns) == 0 {
+		return utxos, nil
+	}
+
+	utxoIDs := make([][]byte, len(importTx.ImportedIns))
+	for i, in := range importTx.ImportedIns {
+		inputID := in.UTXOID.InputID()
+		utxoIDs[i] = inputID[:]
+	}
+	allUTXOBytes, err := vm.ctx.SharedMemory.Get(importTx.SourceChain, utxoIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, utxoBytes := range allUTXOBytes {
+		utxo := &avax.UTXO{}
+		if _, err := vm.codec.Unmarshal(utxoBytes, utxo); err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, utxo)
+	}
+	return utxos, nil
+}
+
+// clearDatabase removes every key of [db]
+func clearDatabase(db database.Database) error {
+	iter := db.NewIterator()
+	keys := [][]byte(nil)
+	for iter.Next() {
+		keys = append(keys, append([]byte(nil), iter.Key()...))
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildAddressIndex indexes every accepted transaction in the state. The order
+// in which transactions were accepted isn't persisted, so transactions are
+// indexed after the transactions they spend from. The owners of UTXOs that
+// were imported from other chains aren't known anymore, so only the outputs of
+// import transactions are indexed.
+func (vm *VM) buildAddressIndex() error {
+	vm.ctx.Log.Info("building the address index")
+
+	if err := vm.addressTxs.Clear(); err != nil {
+		return err
+	}
+
+	accepted := make(map[ids.ID]*Tx)
+	iter := vm.state.txDb.NewIterator()
+	for iter.Next() {
+		txID, err := ids.ToID(iter.Key())
+		if err != nil {
+			iter.Release()
+			return err
+		}
+		status, err := vm.state.Status(txID)
+		if err != nil {
+			iter.Release()
+			return err
+		}
+		if status != choices.Accepted {
+			continue
+		}
+		tx, err := vm.state.Tx(txID)
+		if err != nil {
+			iter.Release()
+			return err
+		}
+		accepted[txID] = tx
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	// Index the transactions in topological order, using an explicit stack so
+	// that long chains of transactions don't exhaust the goroutine's stack.
+	indexed := ids.Set{}
+	for txID := range accepted {
+		stack := []ids.ID{txID}
+		for len(stack) > 0 {
+			currentID := stack[len(stack)-1]
+			if indexed.Contains(currentID) {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			current := accepted[currentID]
+
+			pending := false
+			for _, utxoID := range current.InputUTXOs() {
+				if utxoID.Symbolic() {
+					continue
+				}
+				parentID, _ := utxoID.InputSource()
+				if _, ok := accepted[parentID]; ok && !indexed.Contains(parentID) {
+					stack = append(stack, parentID)
+					pending = true
+				}
+			}
+			if pending {
+				continue
+			}
+
+			consumed := []*avax.UTXO(nil)
+			for _, utxoID := range current.InputUTXOs() {
+				if utxoID.Symbolic() {
+					continue
+				}
+				parentID, outputIndex := utxoID.InputSource()
+				parent, ok := accepted[parentID]
+				if !ok {
+					continue
+				}
+				parentUTXOs := parent.UTXOs()
+				if int(outputIndex) >= len(parentUTXOs) {
+					return errInvalidUTXO
+				}
+				consumed = append(consumed, parentUTXOs[outputIndex])
+			}
+			if err := vm.indexTxAddresses(current, consumed); err != nil {
+				return err
+			}
+			indexed.Add(currentID)
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if err := vm.addressTxs.SetBuilt(true); err != nil {
+		return err
+	}
+	vm.ctx.Log.Info("built the address index with %d transactions", indexed.Len())
+	return vm.db.Commit()
+}
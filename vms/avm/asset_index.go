@@ -0,0 +1,472 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	assetSupplyPrefix byte = iota
+	assetBalancePrefix
+	assetRankPrefix
+	assetMintOutputPrefix
+	assetMetadataPrefix
+
+	assetSupplyLen = 3 * wrappers.LongLen
+)
+
+var (
+	errAssetIndexDisabled  = errors.New("the asset index is disabled")
+	errCorruptedAssetIndex = errors.New("asset index is corrupted")
+
+	assetIndexBuiltKey = []byte{assetMetadataPrefix}
+)
+
+// assetSupply is the amount of an asset that was created and destroyed
+type assetSupply struct {
+	// Units of the asset that were created by accepted transactions
+	minted uint64
+	// Units of the asset that were consumed but not produced by accepted
+	// transactions, such as transaction fees
+	burned uint64
+	// Number of addresses that own a non-zero amount of the asset
+	holders uint64
+}
+
+// assetHolder is an address and the amount of an asset it owns
+type assetHolder struct {
+	address [hashing.AddrLen]byte
+	balance uint64
+}
+
+// assetIndex tracks, for each asset, its supply, the amount of the asset each
+// address owns, and the unspent mint outputs of the asset.
+//
+// The amount of a fungible asset an address owns is the sum of the unspent
+// UTXOs of the asset it is an owner of. Each NFT counts as one unit of its
+// asset. UTXOs with several owners count towards the balance of each of them.
+type assetIndex struct {
+	db database.Database
+}
+
+// Built returns true if the index covers every accepted transaction
+func (i *assetIndex) Built() (bool, error) {
+	return i.db.Has(assetIndexBuiltKey)
+}
+
+// SetBuilt marks whether the index covers every accepted transaction
+func (i *assetIndex) SetBuilt(built bool) error {
+	if built {
+		return i.db.Put(assetIndexBuiltKey, nil)
+	}
+	return i.db.Delete(assetIndexBuiltKey)
+}
+
+// Clear removes every entry of the index
+func (i *assetIndex) Clear() error { return clearDatabase(i.db) }
+
+// Supply returns the supply of [assetID]
+func (i *assetIndex) Supply(assetID ids.ID) (assetSupply, error) {
+	supplyBytes, err := i.db.Get(assetKey(assetSupplyPrefix, assetID))
+	switch {
+	case err == database.ErrNotFound:
+		return assetSupply{}, nil
+	case err != nil:
+		return assetSupply{}, err
+	case len(supplyBytes) != assetSupplyLen:
+		return assetSupply{}, errCorruptedAssetIndex
+	default:
+		return assetSupply{
+			minted:  binary.BigEndian.Uint64(supplyBytes),
+			burned:  binary.BigEndian.Uint64(supplyBytes[wrappers.LongLen:]),
+			holders: binary.BigEndian.Uint64(supplyBytes[2*wrappers.LongLen:]),
+		}, nil
+	}
+}
+
+func (i *assetIndex) setSupply(assetID ids.ID, supply assetSupply) error {
+	supplyBytes := make([]byte, assetSupplyLen)
+	binary.BigEndian.PutUint64(supplyBytes, supply.minted)
+	binary.BigEndian.PutUint64(supplyBytes[wrappers.LongLen:], supply.burned)
+	binary.BigEndian.PutUint64(supplyBytes[2*wrappers.LongLen:], supply.holders)
+	return i.db.Put(assetKey(assetSupplyPrefix, assetID), supplyBytes)
+}
+
+// AddSupply records that [minted] units of [assetID] were created and
+// [burned] units were destroyed
+func (i *assetIndex) AddSupply(assetID ids.ID, minted, burned uint64) error {
+	supply, err := i.Supply(assetID)
+	if err != nil {
+		return err
+	}
+	if supply.minted, err = safemath.Add64(supply.minted, minted); err != nil {
+		return err
+	}
+	if supply.burned, err = safemath.Add64(supply.burned, burned); err != nil {
+		return err
+	}
+	return i.setSupply(assetID, supply)
+}
+
+// Balance returns the amount of [assetID] that [address] owns
+func (i *assetIndex) Balance(assetID ids.ID, address [hashing.AddrLen]byte) (uint64, error) {
+	balanceBytes, err := i.db.Get(assetBalanceKey(assetID, address))
+	switch {
+	case err == database.ErrNotFound:
+		return 0, nil
+	case err != nil:
+		return 0, err
+	case len(balanceBytes) != wrappers.LongLen:
+		return 0, errCorruptedAssetIndex
+	default:
+		return binary.BigEndian.Uint64(balanceBytes), nil
+	}
+}
+
+// AddBalance adds [amount] to the balance of [address]. If [credit] is false,
+// [amount] is removed from the balance instead.
+func (i *assetIndex) AddBalance(assetID ids.ID, address [hashing.AddrLen]byte, amount uint64, credit bool) error {
+	if amount == 0 {
+		return nil
+	}
+	oldBalance, err := i.Balance(assetID, address)
+	if err != nil {
+		return err
+	}
+	var newBalance uint64
+	if credit {
+		newBalance, err = safemath.Add64(oldBalance, amount)
+	} else {
+		newBalance, err = safemath.Sub64(oldBalance, amount)
+	}
+	if err != nil {
+		return err
+	}
+
+	if oldBalance != 0 {
+		if err := i.db.Delete(assetRankKey(assetID, oldBalance, address)); err != nil {
+			return err
+		}
+	}
+	if newBalance == 0 {
+		if err := i.db.Delete(assetBalanceKey(assetID, address)); err != nil {
+			return err
+		}
+	} else {
+		balanceBytes := make([]byte, wrappers.LongLen)
+		binary.BigEndian.PutUint64(balanceBytes, newBalance)
+		if err := i.db.Put(assetBalanceKey(assetID, address), balanceBytes); err != nil {
+			return err
+		}
+		if err := i.db.Put(assetRankKey(assetID, newBalance, address), nil); err != nil {
+			return err
+		}
+	}
+
+	if (oldBalance == 0) == (newBalance == 0) {
+		return nil
+	}
+	supply, err := i.Supply(assetID)
+	if err != nil {
+		return err
+	}
+	if newBalance == 0 {
+		supply.holders--
+	} else {
+		supply.holders++
+	}
+	return i.setSupply(assetID, supply)
+}
+
+// Holders returns up to [limit] of the addresses that own [assetID], from the
+// largest balance to the smallest, skipping the first [start] of them
+func (i *assetIndex) Holders(assetID ids.ID, start uint64, limit int) ([]assetHolder, error) {
+	iter := i.db.NewIteratorWithPrefix(assetKey(assetRankPrefix, assetID))
+	defer iter.Release()
+
+	// Skip the holders of previous pages
+	for skipped := uint64(0); skipped < start; skipped++ {
+		if !iter.Next() {
+			return nil, iter.Error()
+		}
+	}
+
+	holders := []assetHolder(nil)
+	for len(holders) < limit && iter.Next() {
+		key := iter.Key()
+		if len(key) != 1+hashing.HashLen+wrappers.LongLen+hashing.AddrLen {
+			return nil, errCorruptedAssetIndex
+		}
+		holder := assetHolder{
+			balance: math.MaxUint64 - binary.BigEndian.Uint64(key[1+hashing.HashLen:]),
+		}
+		copy(holder.address[:], key[1+hashing.HashLen+wrappers.LongLen:])
+		holders = append(holders, holder)
+	}
+	return holders, iter.Error()
+}
+
+// AddMintOutput marks the UTXO [utxoID] as an unspent mint output of [assetID]
+func (i *assetIndex) AddMintOutput(assetID, utxoID ids.ID) error {
+	return i.db.Put(assetMintOutputKey(assetID, utxoID), nil)
+}
+
+// RemoveMintOutput marks the UTXO [utxoID] as spent
+func (i *assetIndex) RemoveMintOutput(assetID, utxoID ids.ID) error {
+	return i.db.Delete(assetMintOutputKey(assetID, utxoID))
+}
+
+// MintOutputs returns the IDs of the unspent mint outputs of [assetID]
+func (i *assetIndex) MintOutputs(assetID ids.ID) ([]ids.ID, error) {
+	iter := i.db.NewIteratorWithPrefix(assetKey(assetMintOutputPrefix, assetID))
+	defer iter.Release()
+
+	utxoIDs := []ids.ID(nil)
+	for iter.Next() {
+		utxoID, err := ids.ToID(iter.Key()[1+hashing.HashLen:])
+		if err != nil {
+			return nil, err
+		}
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	return utxoIDs, iter.Error()
+}
+
+func assetKey(prefix byte, assetID ids.ID) []byte {
+	key := make([]byte, 1+hashing.HashLen, 1+hashing.HashLen+wrappers.LongLen+hashing.HashLen)
+	key[0] = prefix
+	copy(key[1:], assetID[:])
+	return key
+}
+
+func assetBalanceKey(assetID ids.ID, address [hashing.AddrLen]byte) []byte {
+	return append(assetKey(assetBalancePrefix, assetID), address[:]...)
+}
+
+// assetRankKey orders the holders of an asset from the largest balance to the
+// smallest
+func assetRankKey(assetID ids.ID, balance uint64, address [hashing.AddrLen]byte) []byte {
+	key := assetKey(assetRankPrefix, assetID)
+	key = key[:len(key)+wrappers.LongLen]
+	binary.BigEndian.PutUint64(key[len(key)-wrappers.LongLen:], math.MaxUint64-balance)
+	return append(key, address[:]...)
+}
+
+func assetMintOutputKey(assetID, utxoID ids.ID) []byte {
+	return append(assetKey(assetMintOutputPrefix, assetID), utxoID[:]...)
+}
+
+// assetUnits returns the number of units of its asset that [out] holds
+func assetUnits(out interface{}) uint64 {
+	switch out := out.(type) {
+	case avax.TransferableOut:
+		return out.Amount()
+	case *nftfx.TransferOutput:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// isMintOutput returns true if [out] can be used to mint more of its asset
+func isMintOutput(out interface{}) bool {
+	switch out.(type) {
+	case *secp256k1fx.MintOutput, *nftfx.MintOutput:
+		return true
+	default:
+		return false
+	}
+}
+
+// supplyChanges returns the units of each asset that [tx] creates and
+// destroys. Only the transaction itself is needed, so the supply can be
+// calculated after the UTXOs it consumed were removed.
+func supplyChanges(tx *Tx) (map[ids.ID]uint64, map[ids.ID]uint64, error) {
+	consumed := make(map[ids.ID]uint64)
+	produced := make(map[ids.ID]uint64)
+	add := func(amounts map[ids.ID]uint64, assetID ids.ID, amount uint64) error {
+		newAmount, err := safemath.Add64(amounts[assetID], amount)
+		amounts[assetID] = newAmount
+		return err
+	}
+
+	if operationTx, ok := tx.UnsignedTx.(*OperationTx); ok {
+		for _, op := range operationTx.Ops {
+			if _, ok := op.Op.(*nftfx.TransferOperation); ok {
+				// Each NFT transfer consumes an NFT
+				if err := add(consumed, op.AssetID(), uint64(len(op.UTXOIDs))); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+	for _, in := range transferableInputs(tx.UnsignedTx) {
+		if err := add(consumed, in.AssetID(), in.In.Amount()); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, utxo := range outputUTXOs(tx) {
+		if err := add(produced, utxo.AssetID(), assetUnits(utxo.Out)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	minted := make(map[ids.ID]uint64)
+	burned := make(map[ids.ID]uint64)
+	for assetID, amount := range produced {
+		if consumedAmount := consumed[assetID]; amount > consumedAmount {
+			minted[assetID] = amount - consumedAmount
+		}
+	}
+	for assetID, amount := range consumed {
+		if producedAmount := produced[assetID]; amount > producedAmount {
+			burned[assetID] = amount - producedAmount
+		}
+	}
+	return minted, burned, nil
+}
+
+// indexSupply adds the supply changes of [tx] to the asset index
+func (vm *VM) indexSupply(tx *Tx) error {
+	minted, burned, err := supplyChanges(tx)
+	if err != nil {
+		return err
+	}
+	for assetID, amount := range minted {
+		if err := vm.assets.AddSupply(assetID, amount, 0); err != nil {
+			return err
+		}
+	}
+	for assetID, amount := range burned {
+		if err := vm.assets.AddSupply(assetID, 0, amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexUTXO adds [utxo] to, or if [spent] removes it from, the balances and
+// mint outputs of its asset
+func (vm *VM) indexUTXO(utxo *avax.UTXO, spent bool) error {
+	assetID := utxo.AssetID()
+	if isMintOutput(utxo.Out) {
+		if spent {
+			return vm.assets.RemoveMintOutput(assetID, utxo.InputID())
+		}
+		return vm.assets.AddMintOutput(assetID, utxo.InputID())
+	}
+
+	units := assetUnits(utxo.Out)
+	addressable, ok := utxo.Out.(avax.Addressable)
+	if units == 0 || !ok {
+		return nil
+	}
+	for _, addrBytes := range addressable.Addresses() {
+		addr, err := hashing.ToHash160(addrBytes)
+		if err != nil {
+			return err
+		}
+		if err := vm.assets.AddBalance(assetID, addr, units, !spent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexTxAssets adds the accepted [tx] to the asset index. [spent] are the
+// UTXOs of this chain that [tx] consumed.
+func (vm *VM) indexTxAssets(tx *Tx, spent []*avax.UTXO) error {
+	if err := vm.indexSupply(tx); err != nil {
+		return err
+	}
+	for _, utxo := range spent {
+		if err := vm.indexUTXO(utxo, true); err != nil {
+			return err
+		}
+	}
+	for _, utxo := range tx.UTXOs() {
+		if err := vm.indexUTXO(utxo, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildAssetIndex indexes every accepted transaction in the state. The supply
+// only depends on the transactions themselves, and the balances and mint
+// outputs only depend on the UTXOs that are still unspent, so transactions
+// can be indexed in any order.
+func (vm *VM) buildAssetIndex() error {
+	vm.ctx.Log.Info("building the asset index")
+
+	if err := vm.assets.Clear(); err != nil {
+		return err
+	}
+
+	accepted := []*Tx(nil)
+	iter := vm.state.txDb.NewIterator()
+	for iter.Next() {
+		txID, err := ids.ToID(iter.Key())
+		if err != nil {
+			iter.Release()
+			return err
+		}
+		status, err := vm.state.Status(txID)
+		if err != nil {
+			iter.Release()
+			return err
+		}
+		if status != choices.Accepted {
+			continue
+		}
+		tx, err := vm.state.Tx(txID)
+		if err != nil {
+			iter.Release()
+			return err
+		}
+		accepted = append(accepted, tx)
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	for _, tx := range accepted {
+		if err := vm.indexSupply(tx); err != nil {
+			return err
+		}
+		for _, utxo := range tx.UTXOs() {
+			switch _, err := vm.state.UTXO(utxo.InputID()); err {
+			case nil:
+				if err := vm.indexUTXO(utxo, false); err != nil {
+					return err
+				}
+			case database.ErrNotFound:
+				// The UTXO was spent
+			default:
+				return err
+			}
+		}
+	}
+
+	if err := vm.assets.SetBuilt(true); err != nil {
+		return err
+	}
+	vm.ctx.Log.Info("built the asset index with %d transactions", len(accepted))
+	return vm.db.Commit()
+}
@@ -0,0 +1,440 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// Builder builds and signs transactions that spend the UTXOs it is given with
+// the keys of a keychain. The UTXOs are selected, and the inputs and
+// operations that spend them are built, by the embedded wallet.Builder. It
+// doesn't read the state of the chain, so it can be used to build transactions
+// without a node.
+type Builder struct {
+	wallet.Builder
+
+	NetworkID uint32
+	ChainID   ids.ID
+
+	// Fee burned by every transaction other than CreateAssetTxs
+	TxFee uint64
+	// Fee burned by CreateAssetTxs
+	CreateAssetTxFee uint64
+}
+
+// NewCodec returns a codec that serializes the transactions of an X-Chain that
+// runs the secp256k1fx, the nftfx and the propertyfx, in that order. The types
+// are registered the same way VM.Initialize registers them.
+func NewCodec() (codec.Manager, error) {
+	c := codec.NewDefault()
+	m := codec.NewDefaultManager()
+	if err := registerTxTypes(c); err != nil {
+		return nil, err
+	}
+	vm := &secp256k1fx.TestVM{
+		Codec: c,
+		Log:   logging.NoLog{},
+	}
+	fxs := []Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	}
+	for _, fx := range fxs {
+		if err := fx.Initialize(vm); err != nil {
+			return nil, err
+		}
+	}
+	return m, m.RegisterCodec(codecVersion, c)
+}
+
+// registerTxTypes registers the types of the transactions of the X-Chain with
+// [c]. The types of the fxs are registered after them.
+func registerTxTypes(c codec.Registry) error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&BaseTx{}),
+		c.RegisterType(&CreateAssetTx{}),
+		c.RegisterType(&OperationTx{}),
+		c.RegisterType(&ImportTx{}),
+		c.RegisterType(&ExportTx{}),
+	)
+	return errs.Err
+}
+
+// newOperations returns the operations of an OperationTx that perform [ops]
+func newOperations(ops []*wallet.Operation) []*Operation {
+	txOps := make([]*Operation, len(ops))
+	for i, op := range ops {
+		txOps[i] = &Operation{
+			Asset:   op.Asset,
+			UTXOIDs: op.UTXOIDs,
+			Op:      op.Op,
+		}
+	}
+	return txOps
+}
+
+// Mint returns the operations that mint [amounts] of assets to [owners], and
+// the keys that sign them
+func (b *Builder) Mint(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	amounts map[ids.ID]uint64,
+	owners secp256k1fx.OutputOwners,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	walletOps, keys, err := b.Builder.Mint(utxos, kc, amounts, owners)
+	if err != nil {
+		return nil, nil, err
+	}
+	ops := newOperations(walletOps)
+	sortOperationsWithSigners(ops, keys, b.Codec)
+	return ops, keys, nil
+}
+
+// SpendNFT returns the operation that sends an NFT of [assetID] in [groupID]
+// to [to], and the keys that sign it
+func (b *Builder) SpendNFT(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	groupID uint32,
+	to ids.ShortID,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	walletOps, keys, err := b.Builder.SpendNFT(utxos, kc, assetID, groupID, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newOperations(walletOps), keys, nil
+}
+
+// MintNFT returns the operation that mints an NFT of [assetID] that holds
+// [payload] to [to], and the keys that sign it
+func (b *Builder) MintNFT(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	payload []byte,
+	to ids.ShortID,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	walletOps, keys, err := b.Builder.MintNFT(utxos, kc, assetID, payload, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newOperations(walletOps), keys, nil
+}
+
+// NewBaseTx returns a transaction that creates [outs]. The amounts [outs]
+// hold, and the fee, are spent out of [utxos] and any change is sent to
+// [changeAddr].
+func (b *Builder) NewBaseTx(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	outs []*avax.TransferableOutput,
+	changeAddr ids.ShortID,
+	memo []byte,
+) (*Tx, error) {
+	amounts := map[ids.ID]uint64{b.AVAXAssetID: b.TxFee}
+	for _, out := range outs {
+		assetID := out.AssetID()
+		newAmount, err := safemath.Add64(amounts[assetID], out.Out.Amount())
+		if err != nil {
+			return nil, fmt.Errorf("problem calculating required spend amount: %w", err)
+		}
+		amounts[assetID] = newAmount
+	}
+
+	amountsSpent, ins, keys, err := b.Spend(utxos, kc, amounts)
+	if err != nil {
+		return nil, err
+	}
+
+	allOuts := make([]*avax.TransferableOutput, len(outs))
+	copy(allOuts, outs)
+	allOuts = append(allOuts, wallet.ChangeOutputs(amountsSpent, amounts, changeAddr)...)
+	avax.SortTransferableOutputs(allOuts, b.Codec)
+
+	tx := &Tx{UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    b.NetworkID,
+		BlockchainID: b.ChainID,
+		Outs:         allOuts,
+		Ins:          ins,
+		Memo:         memo,
+	}}}
+	return tx, tx.SignSECP256K1Fx(b.Codec, keys)
+}
+
+// spendFee returns the base of a transaction that burns [fee] out of [utxos],
+// and sends the change to [changeAddr], and the keys that sign its inputs
+func (b *Builder) spendFee(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	fee uint64,
+	changeAddr ids.ShortID,
+) (BaseTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	amounts := map[ids.ID]uint64{b.AVAXAssetID: fee}
+	amountsSpent, ins, keys, err := b.Spend(utxos, kc, amounts)
+	if err != nil {
+		return BaseTx{}, nil, err
+	}
+	outs := wallet.ChangeOutputs(amountsSpent, amounts, changeAddr)
+	avax.SortTransferableOutputs(outs, b.Codec)
+	return BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    b.NetworkID,
+		BlockchainID: b.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+	}}, keys, nil
+}
+
+// NewCreateAssetTx returns a transaction that creates an asset with the given
+// [states]. The fee is paid out of [utxos] and any change is sent to
+// [changeAddr].
+func (b *Builder) NewCreateAssetTx(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	name string,
+	symbol string,
+	denomination byte,
+	states []*InitialState,
+	changeAddr ids.ShortID,
+) (*Tx, error) {
+	baseTx, keys, err := b.spendFee(utxos, kc, b.CreateAssetTxFee, changeAddr)
+	if err != nil {
+		return nil, err
+	}
+	for _, state := range states {
+		state.Sort(b.Codec)
+	}
+	sortInitialStates(states)
+
+	tx := &Tx{UnsignedTx: &CreateAssetTx{
+		BaseTx:       baseTx,
+		Name:         name,
+		Symbol:       symbol,
+		Denomination: denomination,
+		States:       states,
+	}}
+	return tx, tx.SignSECP256K1Fx(b.Codec, keys)
+}
+
+// NewMintTx returns a transaction that mints [amounts] of assets to [owners],
+// with the mint outputs in [utxos]. The fee is paid out of [utxos] and any
+// change is sent to [changeAddr].
+func (b *Builder) NewMintTx(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	amounts map[ids.ID]uint64,
+	owners secp256k1fx.OutputOwners,
+	changeAddr ids.ShortID,
+) (*Tx, error) {
+	baseTx, keys, err := b.spendFee(utxos, kc, b.TxFee, changeAddr)
+	if err != nil {
+		return nil, err
+	}
+	ops, opKeys, err := b.Mint(utxos, kc, amounts, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Tx{UnsignedTx: &OperationTx{
+		BaseTx: baseTx,
+		Ops:    ops,
+	}}
+	return tx, tx.SignSECP256K1Fx(b.Codec, append(keys, opKeys...))
+}
+
+// NewSendNFTTx returns a transaction that sends an NFT of [assetID] in
+// [groupID] to [to]. The fee is paid out of [utxos] and any change is sent to
+// [changeAddr].
+func (b *Builder) NewSendNFTTx(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	groupID uint32,
+	to ids.ShortID,
+	changeAddr ids.ShortID,
+) (*Tx, error) {
+	baseTx, keys, err := b.spendFee(utxos, kc, b.TxFee, changeAddr)
+	if err != nil {
+		return nil, err
+	}
+	ops, nftKeys, err := b.SpendNFT(utxos, kc, assetID, groupID, to)
+	if err != nil {
+		return nil, err
+	}
+	return b.signNFTOperationTx(baseTx, ops, keys, nftKeys)
+}
+
+// NewMintNFTTx returns a transaction that mints an NFT of [assetID] that holds
+// [payload] to [to]. The fee is paid out of [utxos] and any change is sent to
+// [changeAddr].
+func (b *Builder) NewMintNFTTx(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	payload []byte,
+	to ids.ShortID,
+	changeAddr ids.ShortID,
+) (*Tx, error) {
+	baseTx, keys, err := b.spendFee(utxos, kc, b.TxFee, changeAddr)
+	if err != nil {
+		return nil, err
+	}
+	ops, nftKeys, err := b.MintNFT(utxos, kc, assetID, payload, to)
+	if err != nil {
+		return nil, err
+	}
+	return b.signNFTOperationTx(baseTx, ops, keys, nftKeys)
+}
+
+// signNFTOperationTx returns an OperationTx that performs the nftfx [ops],
+// with the inputs signed by [secpKeys] and the operations signed by [nftKeys]
+func (b *Builder) signNFTOperationTx(
+	baseTx BaseTx,
+	ops []*Operation,
+	secpKeys [][]*crypto.PrivateKeySECP256K1R,
+	nftKeys [][]*crypto.PrivateKeySECP256K1R,
+) (*Tx, error) {
+	tx := &Tx{UnsignedTx: &OperationTx{
+		BaseTx: baseTx,
+		Ops:    ops,
+	}}
+	if err := tx.SignSECP256K1Fx(b.Codec, secpKeys); err != nil {
+		return nil, err
+	}
+	return tx, tx.SignNFTFx(b.Codec, nftKeys)
+}
+
+// NewExportTx returns a transaction that exports [exportedOuts] to
+// [destinationChain]. The amounts they hold, and the fee, are spent out of
+// [utxos] and any change is sent to [changeAddr].
+func (b *Builder) NewExportTx(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	destinationChain ids.ID,
+	exportedOuts []*avax.TransferableOutput,
+	changeAddr ids.ShortID,
+) (*Tx, error) {
+	amounts := map[ids.ID]uint64{b.AVAXAssetID: b.TxFee}
+	for _, out := range exportedOuts {
+		assetID := out.AssetID()
+		newAmount, err := safemath.Add64(amounts[assetID], out.Out.Amount())
+		if err != nil {
+			return nil, fmt.Errorf("problem calculating required spend amount: %w", err)
+		}
+		amounts[assetID] = newAmount
+	}
+
+	amountsSpent, ins, keys, err := b.Spend(utxos, kc, amounts)
+	if err != nil {
+		return nil, err
+	}
+
+	outs := wallet.ChangeOutputs(amountsSpent, amounts, changeAddr)
+	avax.SortTransferableOutputs(outs, b.Codec)
+	avax.SortTransferableOutputs(exportedOuts, b.Codec)
+
+	tx := &Tx{UnsignedTx: &ExportTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.NetworkID,
+			BlockchainID: b.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+		DestinationChain: destinationChain,
+		ExportedOuts:     exportedOuts,
+	}}
+	return tx, tx.SignSECP256K1Fx(b.Codec, keys)
+}
+
+// NewImportTx returns a transaction that imports every one of [atomicUTXOs],
+// which were exported from [sourceChain], that [kc] can spend, and sends them
+// to [to]. If the imported AVAX doesn't cover the fee, the rest of the fee is
+// paid out of [utxos].
+func (b *Builder) NewImportTx(
+	utxos []*avax.UTXO,
+	atomicUTXOs []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	sourceChain ids.ID,
+	to ids.ShortID,
+) (*Tx, error) {
+	amountsSpent, importInputs, importKeys, err := b.SpendAll(atomicUTXOs, kc)
+	if err != nil {
+		return nil, err
+	}
+	if len(importInputs) == 0 {
+		return nil, errNoImportInputs
+	}
+
+	ins := []*avax.TransferableInput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	if amountSpent := amountsSpent[b.AVAXAssetID]; amountSpent < b.TxFee {
+		var localAmountsSpent map[ids.ID]uint64
+		localAmountsSpent, ins, keys, err = b.Spend(
+			utxos,
+			kc,
+			map[ids.ID]uint64{
+				b.AVAXAssetID: b.TxFee - amountSpent,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		for asset, amount := range localAmountsSpent {
+			newAmount, err := safemath.Add64(amountsSpent[asset], amount)
+			if err != nil {
+				return nil, fmt.Errorf("problem calculating required spend amount: %w", err)
+			}
+			amountsSpent[asset] = newAmount
+		}
+	}
+
+	// Because we ensured that we had enough inputs for the fee, we can
+	// safely just remove it without concern for underflow.
+	amountsSpent[b.AVAXAssetID] -= b.TxFee
+
+	keys = append(keys, importKeys...)
+
+	outs := wallet.ChangeOutputs(amountsSpent, nil, to)
+	avax.SortTransferableOutputs(outs, b.Codec)
+
+	tx := &Tx{UnsignedTx: &ImportTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.NetworkID,
+			BlockchainID: b.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+		SourceChain: sourceChain,
+		ImportedIns: importInputs,
+	}}
+	return tx, tx.SignSECP256K1Fx(b.Codec, keys)
+}
@@ -0,0 +1,136 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet"
+)
+
+func TestNewCodecMatchesVM(t *testing.T) {
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	c, err := NewCodec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Uses the types of the transactions and of both fxs of the VM
+	tx := &Tx{UnsignedTx: &OperationTx{
+		BaseTx: *NewTx(t, genesisBytes, vm).UnsignedTx.(*BaseTx),
+		Ops: []*Operation{{
+			Asset:   avax.Asset{ID: assetID},
+			UTXOIDs: []*avax.UTXOID{{TxID: ids.Empty.Prefix(0)}},
+			Op: &nftfx.MintOperation{
+				MintInput: secp256k1fx.Input{SigIndices: []uint32{0}},
+				GroupID:   1,
+				Payload:   []byte{1},
+				Outputs: []*secp256k1fx.OutputOwners{{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addrs[0]},
+				}},
+			},
+		}},
+	}}
+	if err := tx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SignNFTFx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}); err != nil {
+		t.Fatal(err)
+	}
+
+	txBytes, err := c.Marshal(codecVersion, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(txBytes, tx.Bytes()) {
+		t.Fatalf("NewCodec serialized the tx differently than the VM")
+	}
+}
+
+func TestBuilderNewBaseTx(t *testing.T) {
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	c, err := NewCodec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	avaxTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+	b := &Builder{
+		Builder: wallet.Builder{
+			AVAXAssetID: avaxTx.ID(),
+			Codec:       c,
+		},
+		NetworkID:        networkID,
+		ChainID:          chainID,
+		TxFee:            testTxFee,
+		CreateAssetTxFee: testTxFee,
+	}
+
+	addrSet := ids.ShortSet{}
+	addrSet.Add(addrs[0])
+	utxos, _, _, err := vm.getAllUTXOs(addrSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kc := secp256k1fx.NewKeychain()
+	kc.Add(keys[0])
+
+	to := ids.GenerateTestShortID()
+	tx, err := b.NewBaseTx(utxos, kc, []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: avaxTx.ID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 20,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{to},
+			},
+		},
+	}}, addrs[0], []byte("memo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numCreds := len(tx.Creds); numCreds != len(tx.UnsignedTx.(*BaseTx).Ins) {
+		t.Fatalf("expected a credential per input but got %d", numCreds)
+	}
+
+	// The VM accepts the transaction the builder made without a node
+	if _, err := vm.IssueTx(tx.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := b.NewBaseTx(utxos, kc, []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: avaxTx.ID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: startBalance,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{to},
+			},
+		},
+	}}, addrs[0], nil); err == nil {
+		t.Fatalf("should have failed to send the balance without paying the fee")
+	}
+}
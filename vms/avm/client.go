@@ -42,6 +42,21 @@ func (c *Client) IssueTx(txBytes []byte) (ids.ID, error) {
 	return res.TxID, err
 }
 
+// SimulateTx verifies the signed or unsigned transaction [txBytes] without
+// issuing it
+func (c *Client) SimulateTx(txBytes []byte) (*SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &SimulateTxReply{}
+	err = c.requester.SendRequest("simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res)
+	return res, err
+}
+
 // GetTxStatus returns the status of [txID]
 func (c *Client) GetTxStatus(txID ids.ID) (choices.Status, error) {
 	res := &GetTxStatusReply{}
@@ -51,6 +66,37 @@ func (c *Client) GetTxStatus(txID ids.ID) (choices.Status, error) {
 	return res.Status, err
 }
 
+// GetPendingTxs returns the transactions that were issued but haven't been
+// decided yet
+func (c *Client) GetPendingTxs() ([]PendingTx, error) {
+	res := &GetPendingTxsReply{}
+	err := c.requester.SendRequest("getPendingTxs", &struct{}{}, res)
+	return res.Txs, err
+}
+
+// CancelTx issues a transaction that conflicts with the processing transaction
+// [txID] and burns [fee] AVAX. If [fee] is 0, the fee of [txID] plus the
+// transaction fee is burned.
+func (c *Client) CancelTx(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	txID ids.ID,
+	fee uint64,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("cancelTx", &CancelTxArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		TxID: txID,
+		Fee:  cjson.Uint64(fee),
+	}, res)
+	return res.TxID, err
+}
+
 // GetTx returns the byte representation of [txID]
 func (c *Client) GetTx(txID ids.ID) ([]byte, error) {
 	res := &api.FormattedTx{}
@@ -115,6 +161,51 @@ func (c *Client) GetBalance(addr string, assetID string) (*GetBalanceReply, erro
 	return res, err
 }
 
+// GetAddressTxs returns the IDs of up to [pageSize] transactions that touched
+// [assetID] held by [addr], starting at [cursor], and the cursor of the next
+// page
+func (c *Client) GetAddressTxs(addr string, cursor uint64, pageSize uint64, assetID string) ([]ids.ID, uint64, error) {
+	res := &GetAddressTxsReply{}
+	err := c.requester.SendRequest("getAddressTxs", &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addr},
+		Cursor:      cjson.Uint64(cursor),
+		PageSize:    cjson.Uint64(pageSize),
+		AssetID:     assetID,
+	}, res)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
+// GetAssetSupply returns the amount of [assetID] that was minted and burned
+func (c *Client) GetAssetSupply(assetID string) (*GetAssetSupplyReply, error) {
+	res := &GetAssetSupplyReply{}
+	err := c.requester.SendRequest("getAssetSupply", &GetAssetDescriptionArgs{
+		AssetID: assetID,
+	}, res)
+	return res, err
+}
+
+// GetAssetHolders returns up to [pageSize] addresses that own [assetID],
+// starting at [cursor], and the cursor of the next page
+func (c *Client) GetAssetHolders(assetID string, cursor uint64, pageSize uint64) ([]AssetHolder, uint64, error) {
+	res := &GetAssetHoldersReply{}
+	err := c.requester.SendRequest("getAssetHolders", &GetAssetHoldersArgs{
+		AssetID:  assetID,
+		Cursor:   cjson.Uint64(cursor),
+		PageSize: cjson.Uint64(pageSize),
+	}, res)
+	return res.Holders, uint64(res.Cursor), err
+}
+
+// GetAssetMintOwners returns the owners of the outputs that can mint more of
+// [assetID]
+func (c *Client) GetAssetMintOwners(assetID string) ([]MintOwners, error) {
+	res := &GetAssetMintOwnersReply{}
+	err := c.requester.SendRequest("getAssetMintOwners", &GetAssetDescriptionArgs{
+		AssetID: assetID,
+	}, res)
+	return res.MintOwners, err
+}
+
 // GetAllBalances returns all asset balances for [addr]
 func (c *Client) GetAllBalances(addr string) (*GetAllBalancesReply, error) {
 	res := &GetAllBalancesReply{}
@@ -306,6 +397,37 @@ func (c *Client) SendMultiple(
 	return res.TxID, err
 }
 
+// Consolidate merges the UTXOs of [assetIDs], or of every asset if
+// [assetIDs] is empty, into one UTXO per asset owned by [changeAddr]
+func (c *Client) Consolidate(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetIDs []string,
+) (*ConsolidateReply, error) {
+	res := &ConsolidateReply{}
+	err := c.requester.SendRequest("consolidate", &ConsolidateArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetIDs: assetIDs,
+	}, res)
+	return res, err
+}
+
+// Sweep sends everything [from] can spend to [to]
+func (c *Client) Sweep(user api.UserPass, from []string, to string) (*SweepReply, error) {
+	res := &SweepReply{}
+	err := c.requester.SendRequest("sweep", &SweepArgs{
+		UserPass:      user,
+		JSONFromAddrs: api.JSONFromAddrs{From: from},
+		To:            to,
+	}, res)
+	return res, err
+}
+
 // Mint [amount] of [assetID] to be owned by [to]
 func (c *Client) Mint(
 	user api.UserPass,
@@ -437,3 +559,102 @@ func (c *Client) Export(
 	}, res)
 	return res.TxID, err
 }
+
+// BuildUnsignedSend returns a transaction, without any of its signatures, that
+// sends [outputs] using the funds of [from]
+func (c *Client) BuildUnsignedSend(
+	from []string,
+	changeAddr string,
+	outputs []SendOutput,
+	memo string,
+) (*PartialTxReply, error) {
+	res := &PartialTxReply{}
+	err := c.requester.SendRequest("buildUnsignedSend", &BuildUnsignedSendArgs{
+		UnsignedSpendHeader: UnsignedSpendHeader{
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+			Encoding:       formatting.Hex,
+		},
+		Outputs: outputs,
+		Memo:    memo,
+	}, res)
+	return res, err
+}
+
+// BuildUnsignedExport returns a transaction, without any of its signatures,
+// that exports [amount] of [assetID] from [from] to [to]
+func (c *Client) BuildUnsignedExport(
+	from []string,
+	changeAddr string,
+	amount uint64,
+	to string,
+	assetID string,
+) (*PartialTxReply, error) {
+	res := &PartialTxReply{}
+	err := c.requester.SendRequest("buildUnsignedExport", &BuildUnsignedExportArgs{
+		UnsignedSpendHeader: UnsignedSpendHeader{
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+			Encoding:       formatting.Hex,
+		},
+		Amount:  cjson.Uint64(amount),
+		To:      to,
+		AssetID: assetID,
+	}, res)
+	return res, err
+}
+
+// BuildUnsignedImport returns a transaction, without any of its signatures,
+// that imports to [to] the funds [from] own on [sourceChain]
+func (c *Client) BuildUnsignedImport(
+	from []string,
+	changeAddr string,
+	to string,
+	sourceChain string,
+) (*PartialTxReply, error) {
+	res := &PartialTxReply{}
+	err := c.requester.SendRequest("buildUnsignedImport", &BuildUnsignedImportArgs{
+		UnsignedSpendHeader: UnsignedSpendHeader{
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+			Encoding:       formatting.Hex,
+		},
+		To:          to,
+		SourceChain: sourceChain,
+	}, res)
+	return res, err
+}
+
+// SignTx adds to the partially signed [txBytes] the missing signatures that
+// [user] or [privateKey] can provide. Either may be left empty.
+func (c *Client) SignTx(txBytes []byte, user api.UserPass, privateKey string) (*PartialTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &PartialTxReply{}
+	err = c.requester.SendRequest("signTx", &SignTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+		UserPass:   user,
+		PrivateKey: privateKey,
+	}, res)
+	return res, err
+}
+
+// GetTxSignatures returns which signatures the partially signed [txBytes]
+// contains and which are still missing
+func (c *Client) GetTxSignatures(txBytes []byte) (*PartialTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &PartialTxReply{}
+	err = c.requester.SendRequest("getTxSignatures", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res)
+	return res, err
+}
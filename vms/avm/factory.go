@@ -17,12 +17,20 @@ var (
 type Factory struct {
 	CreationFee uint64
 	Fee         uint64
+
+	// If true, the transactions that touched each address are indexed
+	IndexAddressTxs bool
+
+	// If true, the supply, holders and mint outputs of each asset are indexed
+	IndexAssets bool
 }
 
 // New ...
 func (f *Factory) New(*snow.Context) (interface{}, error) {
 	return &VM{
-		creationTxFee: f.CreationFee,
-		txFee:         f.Fee,
+		creationTxFee:   f.CreationFee,
+		txFee:           f.Fee,
+		indexAddressTxs: f.IndexAddressTxs,
+		indexAssets:     f.IndexAssets,
 	}, nil
 }
@@ -0,0 +1,226 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errTxNotProcessing = errors.New("transaction isn't processing")
+	errNothingToCancel = errors.New("none of the UTXOs the transaction spends can be spent by this user")
+	errFeeTooLow       = errors.New("fee must be higher than the fee of the cancelled transaction")
+)
+
+// pendingTx is a transaction that was issued but hasn't been decided yet
+type pendingTx struct {
+	tx *UniqueTx
+	// True if the transaction hasn't been handed to consensus yet
+	queued bool
+	// Pending transactions that spend at least one of the same UTXOs
+	conflicts []ids.ID
+}
+
+// trackTx marks [tx] as processing until it is decided
+func (vm *VM) trackTx(tx *UniqueTx) {
+	vm.processing[tx.ID()] = tx
+}
+
+// untrackTx marks the transaction [txID] as decided, or as dropped because it
+// failed verification
+func (vm *VM) untrackTx(txID ids.ID) {
+	delete(vm.processing, txID)
+}
+
+// pendingTxs returns the transactions that were issued, either locally or by
+// consensus, and haven't been decided yet, sorted by ID. Two pending
+// transactions conflict if they spend the same UTXO. Only one of them can be
+// accepted. The conflicts between the transactions that consensus has seen
+// are reported by consensus. The queued transactions haven't been handed to
+// consensus yet, so their conflicts with each other are found here.
+func (vm *VM) pendingTxs() []pendingTx {
+	queued := ids.Set{}
+	queuedSpenders := make(map[ids.ID][]ids.ID)
+	for _, tx := range vm.txs {
+		txID := tx.ID()
+		queued.Add(txID)
+		for _, inputID := range tx.InputIDs() {
+			queuedSpenders[inputID] = append(queuedSpenders[inputID], txID)
+		}
+	}
+
+	txIDs := make([]ids.ID, 0, len(vm.processing))
+	for txID := range vm.processing {
+		txIDs = append(txIDs, txID)
+	}
+	ids.SortIDs(txIDs)
+
+	pending := make([]pendingTx, len(txIDs))
+	for i, txID := range txIDs {
+		tx := vm.processing[txID]
+		conflicts := ids.Set{}
+		if vm.conflicts != nil {
+			conflicts.Union(vm.conflicts.Conflicts(tx))
+		}
+		for _, inputID := range tx.InputIDs() {
+			conflicts.Add(queuedSpenders[inputID]...)
+		}
+		conflicts.Remove(txID)
+		conflictList := conflicts.List()
+		ids.SortIDs(conflictList)
+
+		pending[i] = pendingTx{
+			tx:        tx,
+			queued:    queued.Contains(txID),
+			conflicts: conflictList,
+		}
+	}
+	return pending
+}
+
+// txFeePaid returns the amount of AVAX that [tx] burns
+func (vm *VM) txFeePaid(tx *Tx) (uint64, error) {
+	_, burned, err := supplyChanges(tx)
+	if err != nil {
+		return 0, err
+	}
+	return burned[vm.ctx.AVAXAssetID], nil
+}
+
+// newCancelTx returns a transaction that conflicts with the processing
+// transaction [txID] by spending the UTXOs that [kc] can spend out of the UTXOs
+// [txID] spends, and sending them back to [changeAddr]. The transaction burns
+// [fee] AVAX, which must be more than [txID] burns. If needed, the fee is
+// paid using [utxos]. If [fee] is 0, the fee of [txID] plus the transaction
+// fee is burned.
+func (vm *VM) newCancelTx(
+	txID ids.ID,
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	fee uint64,
+	changeAddr ids.ShortID,
+) (*Tx, error) {
+	original := &UniqueTx{
+		vm:   vm,
+		txID: txID,
+	}
+	if status := original.Status(); status != choices.Processing {
+		return nil, errTxNotProcessing
+	}
+
+	originalFee, err := vm.txFeePaid(original.Tx)
+	if err != nil {
+		return nil, err
+	}
+	if fee == 0 {
+		fee, err = safemath.Add64(originalFee, vm.txFee)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if fee <= originalFee {
+		return nil, errFeeTooLow
+	}
+
+	// Spend the UTXOs of the original transaction that this user can spend
+	time := vm.clock.Unix()
+	amountsSpent := make(map[ids.ID]uint64)
+	spent := ids.Set{}
+	ins := []*avax.TransferableInput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	for _, utxoID := range original.InputUTXOs() {
+		if utxoID.Symbolic() {
+			continue
+		}
+		utxo, err := vm.getUTXO(utxoID)
+		if err != nil {
+			continue
+		}
+		inputIntf, signers, err := kc.Spend(utxo.Out, time)
+		if err != nil {
+			// this utxo can't be spent with the current keys right now
+			continue
+		}
+		input, ok := inputIntf.(avax.TransferableIn)
+		if !ok {
+			// this input doesn't have an amount, so it isn't cancelled here
+			continue
+		}
+		assetID := utxo.AssetID()
+		amountsSpent[assetID], err = safemath.Add64(amountsSpent[assetID], input.Amount())
+		if err != nil {
+			return nil, errSpendOverflow
+		}
+		spent.Add(utxo.InputID())
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: assetID},
+			In:     input,
+		})
+		keys = append(keys, signers)
+	}
+	if len(ins) == 0 {
+		return nil, errNothingToCancel
+	}
+
+	// Pay the rest of the fee with other UTXOs of this user
+	avaxSpent := amountsSpent[vm.ctx.AVAXAssetID]
+	if avaxSpent < fee {
+		otherUTXOs := make([]*avax.UTXO, 0, len(utxos))
+		for _, utxo := range utxos {
+			if !spent.Contains(utxo.InputID()) {
+				otherUTXOs = append(otherUTXOs, utxo)
+			}
+		}
+		feeSpent, feeIns, feeKeys, err := vm.txBuilder().Spend(otherUTXOs, kc, map[ids.ID]uint64{
+			vm.ctx.AVAXAssetID: fee - avaxSpent,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("couldn't pay the fee: %w", err)
+		}
+		amountsSpent[vm.ctx.AVAXAssetID] = avaxSpent + feeSpent[vm.ctx.AVAXAssetID]
+		ins = append(ins, feeIns...)
+		keys = append(keys, feeKeys...)
+	}
+	avax.SortTransferableInputsWithSigners(ins, keys)
+
+	outs := []*avax.TransferableOutput{}
+	for assetID, amountSpent := range amountsSpent {
+		if assetID == vm.ctx.AVAXAssetID {
+			amountSpent -= fee
+		}
+		if amountSpent == 0 {
+			continue
+		}
+		outs = append(outs, &avax.TransferableOutput{
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amountSpent,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{changeAddr},
+				},
+			},
+		})
+	}
+	avax.SortTransferableOutputs(outs, vm.codec)
+
+	tx := &Tx{UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    vm.ctx.NetworkID,
+		BlockchainID: vm.ctx.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+	}}}
+	return tx, tx.SignSECP256K1Fx(vm.codec, keys)
+}
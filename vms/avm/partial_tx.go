@@ -0,0 +1,226 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errPartialTxType       = errors.New("transaction type doesn't support partial signing")
+	errPartialInputType    = errors.New("only secp256k1fx inputs can be partially signed")
+	errPartialCredType     = errors.New("only secp256k1fx credentials can be partially signed")
+	errPartialOutputType   = errors.New("only secp256k1fx outputs can be partially signed")
+	errWrongNumberOfCreds  = errors.New("transaction has the wrong number of credentials")
+	errMissingImportedUTXO = errors.New("imported UTXO is missing from shared memory")
+)
+
+// SpendWithAddresses is like Spend, but only needs the addresses that will
+// sign the inputs rather than their keys. The returned inputs are sorted.
+func (vm *VM) SpendWithAddresses(
+	utxos []*avax.UTXO,
+	addrs ids.ShortSet,
+	amounts map[ids.ID]uint64,
+) (
+	map[ids.ID]uint64,
+	[]*avax.TransferableInput,
+	error,
+) {
+	return vm.txBuilder().SpendWithAddresses(utxos, addrs, amounts)
+}
+
+// SpendAllWithAddresses is like SpendAll, but only needs the addresses that
+// will sign the inputs rather than their keys. The returned inputs are sorted.
+func (vm *VM) SpendAllWithAddresses(
+	utxos []*avax.UTXO,
+	addrs ids.ShortSet,
+) (
+	map[ids.ID]uint64,
+	[]*avax.TransferableInput,
+	error,
+) {
+	return vm.txBuilder().SpendAllWithAddresses(utxos, addrs)
+}
+
+// credentialInputs returns the input that each credential of [utx] authorizes
+func credentialInputs(utx UnsignedTx) ([]*avax.TransferableInput, error) {
+	switch utx := utx.(type) {
+	case *BaseTx:
+		return utx.Ins, nil
+	case *CreateAssetTx:
+		return utx.Ins, nil
+	case *ExportTx:
+		return utx.Ins, nil
+	case *ImportTx:
+		ins := make([]*avax.TransferableInput, 0, len(utx.Ins)+len(utx.ImportedIns))
+		ins = append(ins, utx.Ins...)
+		return append(ins, utx.ImportedIns...), nil
+	default:
+		return nil, errPartialTxType
+	}
+}
+
+// secpInput returns the secp256k1fx input that [in] is spent with
+func secpInput(in *avax.TransferableInput) (*secp256k1fx.Input, error) {
+	transferIn, ok := in.In.(*secp256k1fx.TransferInput)
+	if !ok {
+		return nil, errPartialInputType
+	}
+	return &transferIn.Input, nil
+}
+
+// NewUnsignedTx returns [utx] with a credential for each of its inputs that
+// doesn't contain any signatures yet
+func (vm *VM) NewUnsignedTx(utx UnsignedTx) (*Tx, error) {
+	ins, err := credentialInputs(utx)
+	if err != nil {
+		return nil, err
+	}
+	tx := &Tx{UnsignedTx: utx}
+	for _, in := range ins {
+		secpIn, err := secpInput(in)
+		if err != nil {
+			return nil, err
+		}
+		tx.Creds = append(tx.Creds, secp256k1fx.NewUnsignedCredential(secpIn))
+	}
+	return tx, vm.initPartialTx(tx)
+}
+
+// initPartialTx sets the bytes of [tx] after its credentials were modified
+func (vm *VM) initPartialTx(tx *Tx) error {
+	unsignedBytes, err := vm.codec.Marshal(codecVersion, &tx.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+	signedBytes, err := vm.codec.Marshal(codecVersion, tx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+	tx.Initialize(unsignedBytes, signedBytes)
+	return nil
+}
+
+// spentOwners returns the owners of the UTXOs consumed by [ins]. The last
+// [numImported] inputs consume UTXOs that were exported from [sourceChain].
+func (vm *VM) spentOwners(ins []*avax.TransferableInput, sourceChain ids.ID, numImported int) ([]*secp256k1fx.OutputOwners, error) {
+	numLocal := len(ins) - numImported
+	utxos := make([]*avax.UTXO, 0, len(ins))
+	for _, in := range ins[:numLocal] {
+		utxo, err := vm.getUTXO(&in.UTXOID)
+		if err != nil {
+			return nil, fmt.Errorf("problem fetching UTXO %s: %w", in.InputID(), err)
+		}
+		utxos = append(utxos, utxo)
+	}
+
+	if numImported > 0 {
+		utxoIDs := make([][]byte, numImported)
+		for i, in := range ins[numLocal:] {
+			inputID := in.InputID()
+			utxoIDs[i] = inputID[:]
+		}
+		allUTXOBytes, err := vm.ctx.SharedMemory.Get(sourceChain, utxoIDs)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errMissingImportedUTXO, err)
+		}
+		for _, utxoBytes := range allUTXOBytes {
+			utxo := &avax.UTXO{}
+			if _, err := vm.codec.Unmarshal(utxoBytes, utxo); err != nil {
+				return nil, err
+			}
+			utxos = append(utxos, utxo)
+		}
+	}
+
+	owners := make([]*secp256k1fx.OutputOwners, len(utxos))
+	for i, utxo := range utxos {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return nil, errPartialOutputType
+		}
+		owners[i] = &out.OutputOwners
+	}
+	return owners, nil
+}
+
+// partialTxSpends returns, for each credential of [tx], the input it
+// authorizes, the owners of the UTXO the input consumes, and the credential
+func (vm *VM) partialTxSpends(tx *Tx) ([]*secp256k1fx.Input, []*secp256k1fx.OutputOwners, []*secp256k1fx.Credential, error) {
+	ins, err := credentialInputs(tx.UnsignedTx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(ins) != len(tx.Creds) {
+		return nil, nil, nil, errWrongNumberOfCreds
+	}
+
+	sourceChain := ids.Empty
+	numImported := 0
+	if importTx, ok := tx.UnsignedTx.(*ImportTx); ok {
+		sourceChain = importTx.SourceChain
+		numImported = len(importTx.ImportedIns)
+	}
+	owners, err := vm.spentOwners(ins, sourceChain, numImported)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	secpIns := make([]*secp256k1fx.Input, len(ins))
+	creds := make([]*secp256k1fx.Credential, len(ins))
+	for i, in := range ins {
+		secpIns[i], err = secpInput(in)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cred, ok := tx.Creds[i].(*secp256k1fx.Credential)
+		if !ok {
+			return nil, nil, nil, errPartialCredType
+		}
+		creds[i] = cred
+	}
+	return secpIns, owners, creds, nil
+}
+
+// SigSlots returns the signatures each credential of [tx] must contain
+func (vm *VM) SigSlots(tx *Tx) ([][]secp256k1fx.SigSlot, error) {
+	ins, owners, creds, err := vm.partialTxSpends(tx)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([][]secp256k1fx.SigSlot, len(ins))
+	for i, in := range ins {
+		slots[i], err = secp256k1fx.SigSlots(in, owners[i], creds[i])
+		if err != nil {
+			return nil, fmt.Errorf("credential %d: %w", i, err)
+		}
+	}
+	return slots, nil
+}
+
+// SignPartialTx adds to [tx] every missing signature that [kc] can provide.
+// Returns the number of signatures that were added.
+func (vm *VM) SignPartialTx(tx *Tx, kc *secp256k1fx.Keychain) (int, error) {
+	ins, owners, creds, err := vm.partialTxSpends(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	hash := hashing.ComputeHash256(tx.UnsignedBytes())
+	numSigned := 0
+	for i, in := range ins {
+		n, err := kc.SignCredential(hash, in, owners[i], creds[i])
+		if err != nil {
+			return 0, fmt.Errorf("credential %d: %w", i, err)
+		}
+		numSigned += n
+	}
+	return numSigned, vm.initPartialTx(tx)
+}
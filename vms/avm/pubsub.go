@@ -0,0 +1,75 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// TxNotification is sent to the pubsub connections whose filter matches an
+// address that a transaction spends from or sends to
+type TxNotification struct {
+	TxID ids.ID `json:"txID"`
+	Tx   *Tx    `json:"tx"`
+}
+
+// parseFilterAddress returns the bytes of an address given in a pubsub filter
+func (vm *VM) parseFilterAddress(addrStr string) ([]byte, error) {
+	addr, err := vm.ParseLocalAddress(addrStr)
+	if err != nil {
+		return nil, err
+	}
+	return addr.Bytes(), nil
+}
+
+// touchedUTXOs returns the UTXOs that [tx] spends and produces, as far as they
+// can be found. UTXOs that can't be fetched are skipped, as notifications are
+// best effort.
+func (vm *VM) touchedUTXOs(tx *Tx) []*avax.UTXO {
+	utxos := []*avax.UTXO(nil)
+	for _, utxoID := range tx.InputUTXOs() {
+		if utxoID.Symbolic() {
+			continue
+		}
+		if utxo, err := vm.getUTXO(utxoID); err == nil {
+			utxos = append(utxos, utxo)
+		}
+	}
+
+	if imported, err := vm.importedUTXOs(tx); err == nil {
+		utxos = append(utxos, imported...)
+	}
+	return append(utxos, outputUTXOs(tx)...)
+}
+
+// txAddresses returns the addresses that own the UTXOs that [tx] spends and
+// produces
+func (vm *VM) txAddresses(tx *Tx) [][]byte {
+	addrs := [][]byte(nil)
+	seen := make(map[string]struct{})
+	for _, utxo := range vm.touchedUTXOs(tx) {
+		addressable, ok := utxo.Out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+		for _, addr := range addressable.Addresses() {
+			if _, exists := seen[string(addr)]; exists {
+				continue
+			}
+			seen[string(addr)] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// publishTx sends [tx] to the pubsub connections on [channel] whose filter
+// matches [addrs]
+func (vm *VM) publishTx(channel string, tx *Tx, addrs [][]byte) {
+	vm.pubsub.PublishFiltered(channel, &TxNotification{
+		TxID: tx.ID(),
+		Tx:   tx,
+	}, addrs)
+}
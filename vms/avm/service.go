@@ -21,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
@@ -34,19 +35,20 @@ const (
 )
 
 var (
-	errUnknownAssetID         = errors.New("unknown asset ID")
-	errTxNotCreateAsset       = errors.New("transaction doesn't create an asset")
-	errNoMinters              = errors.New("no minters provided")
-	errNoHoldersOrMinters     = errors.New("no minters or initialHolders provided")
-	errInvalidAmount          = errors.New("amount must be positive")
-	errNoOutputs              = errors.New("no outputs to send")
-	errSpendOverflow          = errors.New("spent amount overflows uint64")
-	errInvalidMintAmount      = errors.New("amount minted must be positive")
-	errAddressesCantMintAsset = errors.New("provided addresses don't have the authority to mint the provided asset")
-	errInvalidUTXO            = errors.New("invalid utxo")
-	errNilTxID                = errors.New("nil transaction ID")
-	errNoAddresses            = errors.New("no addresses provided")
-	errNoKeys                 = errors.New("from addresses have no keys or funds")
+	errUnknownAssetID     = errors.New("unknown asset ID")
+	errTxNotCreateAsset   = errors.New("transaction doesn't create an asset")
+	errNoMinters          = errors.New("no minters provided")
+	errNoHoldersOrMinters = errors.New("no minters or initialHolders provided")
+	errInvalidAmount      = errors.New("amount must be positive")
+	errNoOutputs          = errors.New("no outputs to send")
+	errSpendOverflow      = errors.New("spent amount overflows uint64")
+	errInvalidMintAmount  = errors.New("amount minted must be positive")
+	errInvalidUTXO        = errors.New("invalid utxo")
+	errNilTxID            = errors.New("nil transaction ID")
+	errNoAddresses        = errors.New("no addresses provided")
+	errNoKeys             = errors.New("from addresses have no keys or funds")
+	errNoSigners          = errors.New("either a user or a private key must be provided")
+	errNoMissingSigs      = errors.New("provided keys can't add any missing signature")
 )
 
 // Service defines the base service for the asset vm
@@ -74,6 +76,96 @@ func (service *Service) IssueTx(r *http.Request, args *api.FormattedTx, reply *a
 	return nil
 }
 
+// SimulatedUTXO is a UTXO that a simulated transaction consumes or produces
+type SimulatedUTXO struct {
+	UTXOID  avax.UTXOID `json:"utxoID"`
+	AssetID ids.ID      `json:"assetID"`
+	// Units of the asset the UTXO holds. Each NFT is one unit.
+	Amount json.Uint64 `json:"amount"`
+	// The UTXO, encoded with the requested encoding
+	UTXO string `json:"utxo"`
+}
+
+// SimulateTxReply defines the SimulateTx replies returned from the API
+type SimulateTxReply struct {
+	TxID ids.ID `json:"txID"`
+	// True if the transaction was fully signed and its credentials were
+	// verified
+	Signed bool `json:"signed"`
+	// Amount of AVAX the transaction burns
+	Fee json.Uint64 `json:"fee"`
+	// Amount of AVAX a transaction of this type must burn
+	RequiredFee json.Uint64     `json:"requiredFee"`
+	Consumed    []SimulatedUTXO `json:"consumed"`
+	Produced    []SimulatedUTXO `json:"produced"`
+	// True if the transaction would be valid if it were issued now
+	Valid bool `json:"valid"`
+	// Reason the transaction is invalid
+	Error    string              `json:"error,omitempty"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// SimulateTx verifies a transaction against the current state without issuing
+// it. The transaction may be signed or unsigned. The credentials of a
+// transaction are only verified if it is fully signed.
+func (service *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, reply *SimulateTxReply) error {
+	service.vm.ctx.Log.Info("AVM: SimulateTx called")
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := service.vm.parseSimulatedTx(txBytes)
+	if err != nil {
+		return err
+	}
+	sim, err := service.vm.simulateTx(tx)
+	if err != nil {
+		return err
+	}
+
+	reply.TxID = tx.ID()
+	reply.Signed = sim.signed
+	reply.Fee = json.Uint64(sim.fee)
+	reply.RequiredFee = json.Uint64(service.vm.txFee)
+	if _, ok := tx.UnsignedTx.(*CreateAssetTx); ok {
+		reply.RequiredFee = json.Uint64(service.vm.creationTxFee)
+	}
+	if reply.Consumed, err = service.simulatedUTXOs(sim.consumed, args.Encoding); err != nil {
+		return err
+	}
+	if reply.Produced, err = service.simulatedUTXOs(sim.produced, args.Encoding); err != nil {
+		return err
+	}
+	reply.Valid = sim.err == nil
+	if sim.err != nil {
+		reply.Error = sim.err.Error()
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+func (service *Service) simulatedUTXOs(utxos []*avax.UTXO, encoding formatting.Encoding) ([]SimulatedUTXO, error) {
+	simulated := make([]SimulatedUTXO, len(utxos))
+	for i, utxo := range utxos {
+		utxoBytes, err := service.vm.codec.Marshal(codecVersion, utxo)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't serialize UTXO %s: %w", utxo.InputID(), err)
+		}
+		utxoStr, err := formatting.Encode(encoding, utxoBytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't encode UTXO %s: %w", utxo.InputID(), err)
+		}
+		simulated[i] = SimulatedUTXO{
+			UTXOID:  utxo.UTXOID,
+			AssetID: utxo.AssetID(),
+			Amount:  json.Uint64(assetUnits(utxo.Out)),
+			UTXO:    utxoStr,
+		}
+	}
+	return simulated, nil
+}
+
 // GetTxStatusReply defines the GetTxStatus replies returned from the API
 type GetTxStatusReply struct {
 	Status choices.Status `json:"status"`
@@ -96,8 +188,9 @@ func (service *Service) GetTxStatus(r *http.Request, args *api.JSONTxID, reply *
 	return nil
 }
 
-// GetTx returns the specified transaction
-func (service *Service) GetTx(r *http.Request, args *api.GetTxArgs, reply *api.FormattedTx) error {
+// GetTx returns the specified transaction. If the JSON encoding is requested,
+// the transaction is returned decoded.
+func (service *Service) GetTx(r *http.Request, args *api.GetTxArgs, reply *api.GetTxReply) error {
 	service.vm.ctx.Log.Info("AVM: GetTx called with %s", args.TxID)
 
 	if args.TxID == ids.Empty {
@@ -112,15 +205,121 @@ func (service *Service) GetTx(r *http.Request, args *api.GetTxArgs, reply *api.F
 		return errUnknownTx
 	}
 
-	var err error
-	reply.Tx, err = formatting.Encode(args.Encoding, tx.Bytes())
+	reply.Encoding = args.Encoding
+	if args.Encoding == formatting.JSON {
+		txJSON, err := service.vm.txJSON(tx.Tx)
+		if err != nil {
+			return fmt.Errorf("couldn't decode tx: %w", err)
+		}
+		reply.Tx = txJSON
+		return nil
+	}
+
+	txStr, err := formatting.Encode(args.Encoding, tx.Bytes())
 	if err != nil {
 		return fmt.Errorf("couldn't encode tx as string: %s", err)
 	}
-	reply.Encoding = args.Encoding
+	reply.Tx = txStr
+	return nil
+}
+
+// PendingTx is a transaction that was issued but hasn't been decided yet
+type PendingTx struct {
+	TxID ids.ID `json:"txID"`
+	// True if the transaction is waiting to be handed to consensus
+	Queued bool `json:"queued"`
+	// Pending transactions that spend at least one of the same UTXOs. At most
+	// one of the conflicting transactions can be accepted.
+	Conflicts []ids.ID `json:"conflicts"`
+}
+
+// GetPendingTxsReply defines the GetPendingTxs replies returned from the API
+type GetPendingTxsReply struct {
+	Txs []PendingTx `json:"txs"`
+}
+
+// GetPendingTxs returns the transactions that were issued, either locally or
+// by consensus, and haven't been decided yet
+func (service *Service) GetPendingTxs(_ *http.Request, _ *struct{}, reply *GetPendingTxsReply) error {
+	service.vm.ctx.Log.Info("AVM: GetPendingTxs called")
+
+	pending := service.vm.pendingTxs()
+	reply.Txs = make([]PendingTx, len(pending))
+	for i, p := range pending {
+		reply.Txs[i] = PendingTx{
+			TxID:      p.tx.ID(),
+			Queued:    p.queued,
+			Conflicts: p.conflicts,
+		}
+	}
 	return nil
 }
 
+// CancelTxArgs are arguments for passing into CancelTx requests
+type CancelTxArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+
+	// ID of the processing transaction to cancel
+	TxID ids.ID `json:"txID"`
+
+	// Amount of AVAX the cancelling transaction burns. Must be more than the
+	// cancelled transaction burns. If omitted, the fee of the cancelled
+	// transaction plus the transaction fee is burned.
+	Fee json.Uint64 `json:"fee"`
+}
+
+// CancelTx issues a transaction that conflicts with a processing transaction
+// of the user. The issued transaction spends the UTXOs of the processing
+// transaction that the user can spend, sends them back to the change address,
+// and burns a higher fee. At most one of the two transactions is accepted.
+func (service *Service) CancelTx(_ *http.Request, args *CancelTxArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Info("AVM: CancelTx called with username: %s txID: %s", args.Username, args.TxID)
+
+	if args.TxID == ids.Empty {
+		return errNilTxID
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'From' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// Get the UTXOs/keys for the from addresses
+	utxos, kc, err := service.vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	// Parse the change address.
+	if len(kc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := service.vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	tx, err := service.vm.newCancelTx(args.TxID, utxos, kc, uint64(args.Fee), changeAddr)
+	if err != nil {
+		return err
+	}
+
+	txID, err := service.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
 // GetUTXOs gets all utxos for passed in addresses
 func (service *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply *api.GetUTXOsReply) error {
 	service.vm.ctx.Log.Info("AVM: GetUTXOs called for with %s", args.Addresses)
@@ -313,6 +512,240 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 	return nil
 }
 
+// GetAddressTxsArgs are arguments for passing into GetAddressTxs requests
+type GetAddressTxsArgs struct {
+	api.JSONAddress
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize json.Uint64 `json:"pageSize"`
+	// AssetID defaulted to AVAX if omitted or left blank
+	AssetID string `json:"assetID"`
+}
+
+// GetAddressTxsReply represents the response of GetAddressTxs
+type GetAddressTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetAddressTxs returns the IDs of the accepted transactions that consumed or
+// produced UTXOs of [AssetID] owned by [Address], in the order they were
+// accepted. The address index must be enabled.
+func (service *Service) GetAddressTxs(r *http.Request, args *GetAddressTxsArgs, reply *GetAddressTxsReply) error {
+	service.vm.ctx.Log.Info("AVM: GetAddressTxs called with address: %s assetID: %s cursor: %d pageSize: %d", args.Address, args.AssetID, args.Cursor, args.PageSize)
+
+	if service.vm.addressTxs == nil {
+		return errAddressIndexDisabled
+	}
+
+	addr, err := service.vm.ParseLocalAddress(args.Address)
+	if err != nil {
+		return fmt.Errorf("problem parsing address '%s': %w", args.Address, err)
+	}
+
+	assetID := service.vm.ctx.AVAXAssetID
+	if args.AssetID != "" {
+		assetID, err = service.vm.lookupAssetID(args.AssetID)
+		if err != nil {
+			return err
+		}
+	}
+
+	pageSize := int(args.PageSize)
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	txIDs, err := service.vm.addressTxs.Get(addr.Key(), assetID, uint64(args.Cursor), pageSize)
+	if err != nil {
+		return fmt.Errorf("couldn't get the transactions of %s: %w", args.Address, err)
+	}
+
+	reply.TxIDs = txIDs
+	reply.Cursor = args.Cursor + json.Uint64(len(txIDs))
+	return nil
+}
+
+// GetAssetSupplyReply defines the GetAssetSupply replies returned from the API
+type GetAssetSupplyReply struct {
+	FormattedAssetID
+	// Units of the asset that were created
+	Minted json.Uint64 `json:"minted"`
+	// Units of the asset that were destroyed, such as transaction fees
+	Burned json.Uint64 `json:"burned"`
+	// Units of the asset that exist, on this chain or exported to another one
+	Supply json.Uint64 `json:"supply"`
+	// Number of addresses that own some of the asset on this chain
+	Holders json.Uint64 `json:"holders"`
+}
+
+// GetAssetSupply returns the amount of an asset that was minted and burned.
+// The asset index must be enabled.
+func (service *Service) GetAssetSupply(_ *http.Request, args *GetAssetDescriptionArgs, reply *GetAssetSupplyReply) error {
+	service.vm.ctx.Log.Info("AVM: GetAssetSupply called with %s", args.AssetID)
+
+	if service.vm.assets == nil {
+		return errAssetIndexDisabled
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	supply, err := service.vm.assets.Supply(assetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the supply of %s: %w", assetID, err)
+	}
+
+	circulating, err := safemath.Sub64(supply.minted, supply.burned)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate the supply of %s: %w", assetID, err)
+	}
+
+	reply.AssetID = assetID
+	reply.Minted = json.Uint64(supply.minted)
+	reply.Burned = json.Uint64(supply.burned)
+	reply.Supply = json.Uint64(circulating)
+	reply.Holders = json.Uint64(supply.holders)
+	return nil
+}
+
+// GetAssetHoldersArgs are arguments for passing into GetAssetHolders requests
+type GetAssetHoldersArgs struct {
+	AssetID string `json:"assetID"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+// AssetHolder is an address and the amount of an asset it owns
+type AssetHolder struct {
+	Address string      `json:"address"`
+	Balance json.Uint64 `json:"balance"`
+}
+
+// GetAssetHoldersReply defines the GetAssetHolders replies returned from the
+// API
+type GetAssetHoldersReply struct {
+	Holders []AssetHolder `json:"holders"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetAssetHolders returns the addresses that own an asset, from the largest
+// balance to the smallest. The balance of an address is the sum of the UTXOs
+// it is an owner of, so UTXOs with several owners count towards the balance of
+// each of them. The asset index must be enabled.
+func (service *Service) GetAssetHolders(_ *http.Request, args *GetAssetHoldersArgs, reply *GetAssetHoldersReply) error {
+	service.vm.ctx.Log.Info("AVM: GetAssetHolders called with assetID: %s cursor: %d pageSize: %d", args.AssetID, args.Cursor, args.PageSize)
+
+	if service.vm.assets == nil {
+		return errAssetIndexDisabled
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	pageSize := int(args.PageSize)
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	holders, err := service.vm.assets.Holders(assetID, uint64(args.Cursor), pageSize)
+	if err != nil {
+		return fmt.Errorf("couldn't get the holders of %s: %w", assetID, err)
+	}
+
+	reply.Holders = make([]AssetHolder, len(holders))
+	for i, holder := range holders {
+		addr, err := service.vm.FormatLocalAddress(ids.NewShortID(holder.address))
+		if err != nil {
+			return fmt.Errorf("problem formatting address: %w", err)
+		}
+		reply.Holders[i] = AssetHolder{
+			Address: addr,
+			Balance: json.Uint64(holder.balance),
+		}
+	}
+	reply.Cursor = args.Cursor + json.Uint64(len(holders))
+	return nil
+}
+
+// MintOwners are the owners of an output that can mint more of an asset
+type MintOwners struct {
+	UTXOID avax.UTXOID `json:"utxoID"`
+	// Group of the NFTs the output mints. Only set for NFT assets.
+	GroupID   *json.Uint32 `json:"groupID,omitempty"`
+	Locktime  json.Uint64  `json:"locktime"`
+	Threshold json.Uint32  `json:"threshold"`
+	Addresses []string     `json:"addresses"`
+}
+
+// GetAssetMintOwnersReply defines the GetAssetMintOwners replies returned
+// from the API
+type GetAssetMintOwnersReply struct {
+	MintOwners []MintOwners `json:"mintOwners"`
+}
+
+// GetAssetMintOwners returns the owners of the unspent outputs that can mint
+// more of a variable cap or NFT asset. The asset index must be enabled.
+func (service *Service) GetAssetMintOwners(_ *http.Request, args *GetAssetDescriptionArgs, reply *GetAssetMintOwnersReply) error {
+	service.vm.ctx.Log.Info("AVM: GetAssetMintOwners called with %s", args.AssetID)
+
+	if service.vm.assets == nil {
+		return errAssetIndexDisabled
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	utxoIDs, err := service.vm.assets.MintOutputs(assetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the mint outputs of %s: %w", assetID, err)
+	}
+
+	reply.MintOwners = make([]MintOwners, 0, len(utxoIDs))
+	for _, utxoID := range utxoIDs {
+		utxo, err := service.vm.state.UTXO(utxoID)
+		if err != nil {
+			return fmt.Errorf("couldn't get UTXO %s: %w", utxoID, err)
+		}
+
+		mintOwners := MintOwners{UTXOID: utxo.UTXOID}
+		var owners *secp256k1fx.OutputOwners
+		switch out := utxo.Out.(type) {
+		case *secp256k1fx.MintOutput:
+			owners = &out.OutputOwners
+		case *nftfx.MintOutput:
+			groupID := json.Uint32(out.GroupID)
+			mintOwners.GroupID = &groupID
+			owners = &out.OutputOwners
+		default:
+			return errCorruptedAssetIndex
+		}
+
+		mintOwners.Locktime = json.Uint64(owners.Locktime)
+		mintOwners.Threshold = json.Uint32(owners.Threshold)
+		mintOwners.Addresses = make([]string, len(owners.Addrs))
+		for i, addr := range owners.Addrs {
+			mintOwners.Addresses[i], err = service.vm.FormatLocalAddress(addr)
+			if err != nil {
+				return fmt.Errorf("problem formatting address: %w", err)
+			}
+		}
+		reply.MintOwners = append(reply.MintOwners, mintOwners)
+	}
+	return nil
+}
+
 // Balance ...
 type Balance struct {
 	AssetID string      `json:"asset"`
@@ -448,32 +881,6 @@ func (service *Service) CreateAsset(r *http.Request, args *CreateAssetArgs, repl
 		return err
 	}
 
-	amountsSpent, ins, keys, err := service.vm.Spend(
-		utxos,
-		kc,
-		map[ids.ID]uint64{
-			avaxKey: service.vm.creationTxFee,
-		},
-	)
-	if err != nil {
-		return err
-	}
-
-	outs := []*avax.TransferableOutput{}
-	if amountSpent := amountsSpent[service.vm.ctx.AVAXAssetID]; amountSpent > service.vm.creationTxFee {
-		outs = append(outs, &avax.TransferableOutput{
-			Asset: avax.Asset{ID: service.vm.ctx.AVAXAssetID},
-			Out: &secp256k1fx.TransferOutput{
-				Amt: amountSpent - service.vm.creationTxFee,
-				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
-					Threshold: 1,
-					Addrs:     []ids.ShortID{changeAddr},
-				},
-			},
-		})
-	}
-
 	initialState := &InitialState{
 		FxID: 0, // TODO: Should lookup secp256k1fx FxID
 		Outs: make([]verify.State, 0, len(args.InitialHolders)+len(args.MinterSets)),
@@ -491,21 +898,17 @@ func (service *Service) CreateAsset(r *http.Request, args *CreateAssetArgs, repl
 			},
 		})
 	}
-	initialState.Sort(service.vm.codec)
 
-	tx := Tx{UnsignedTx: &CreateAssetTx{
-		BaseTx: BaseTx{BaseTx: avax.BaseTx{
-			NetworkID:    service.vm.ctx.NetworkID,
-			BlockchainID: service.vm.ctx.ChainID,
-			Outs:         outs,
-			Ins:          ins,
-		}},
-		Name:         args.Name,
-		Symbol:       args.Symbol,
-		Denomination: args.Denomination,
-		States:       []*InitialState{initialState},
-	}}
-	if err := tx.SignSECP256K1Fx(service.vm.codec, keys); err != nil {
+	tx, err := service.vm.txBuilder().NewCreateAssetTx(
+		utxos,
+		kc,
+		args.Name,
+		args.Symbol,
+		args.Denomination,
+		[]*InitialState{initialState},
+		changeAddr,
+	)
+	if err != nil {
 		return err
 	}
 
@@ -572,7 +975,7 @@ func (service *Service) CreateVariableCapAsset(r *http.Request, args *CreateVari
 	}
 
 	avaxKey := service.vm.ctx.AVAXAssetID.Key()
-	amountsSpent, ins, keys, err := service.vm.Spend(
+	amountsSpent, ins, keys, err := service.vm.txBuilder().Spend(
 		utxos,
 		kc,
 		map[ids.ID]uint64{
@@ -714,7 +1117,7 @@ func (service *Service) CreateNFTAsset(r *http.Request, args *CreateNFTAssetArgs
 		return err
 	}
 
-	amountsSpent, ins, keys, err := service.vm.Spend(
+	amountsSpent, ins, keys, err := service.vm.txBuilder().Spend(
 		utxos,
 		kc,
 		map[ids.ID]uint64{
@@ -934,22 +1337,11 @@ func (service *Service) ImportKey(r *http.Request, args *ImportKeyArgs, reply *a
 		return fmt.Errorf("keystore user has reached its limit of %d addresses", maxKeystoreAddresses)
 	}
 
-	if !strings.HasPrefix(args.PrivateKey, constants.SecretKeyPrefix) {
-		return fmt.Errorf("private key missing %s prefix", constants.SecretKeyPrefix)
-	}
-	trimmedPrivateKey := strings.TrimPrefix(args.PrivateKey, constants.SecretKeyPrefix)
-	privKeyBytes, err := formatting.Decode(formatting.CB58, trimmedPrivateKey)
+	sk, err := parsePrivateKey(args.PrivateKey)
 	if err != nil {
-		return fmt.Errorf("problem parsing private key: %w", err)
+		return err
 	}
 
-	factory := crypto.FactorySECP256K1R{}
-	skIntf, err := factory.ToPrivateKey(privKeyBytes)
-	if err != nil {
-		return fmt.Errorf("problem parsing private key: %w", err)
-	}
-	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
-
 	if err := user.SetKey(db, sk); err != nil {
 		return fmt.Errorf("problem saving key %w", err)
 	}
@@ -973,6 +1365,38 @@ func (service *Service) ImportKey(r *http.Request, args *ImportKeyArgs, reply *a
 	return db.Close()
 }
 
+// parsePrivateKey parses a private key in the format returned by ExportKey
+func parsePrivateKey(privateKey string) (*crypto.PrivateKeySECP256K1R, error) {
+	if !strings.HasPrefix(privateKey, constants.SecretKeyPrefix) {
+		return nil, fmt.Errorf("private key missing %s prefix", constants.SecretKeyPrefix)
+	}
+	trimmedPrivateKey := strings.TrimPrefix(privateKey, constants.SecretKeyPrefix)
+	privKeyBytes, err := formatting.Decode(formatting.CB58, trimmedPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing private key: %w", err)
+	}
+
+	factory := crypto.FactorySECP256K1R{}
+	skIntf, err := factory.ToPrivateKey(privKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing private key: %w", err)
+	}
+	return skIntf.(*crypto.PrivateKeySECP256K1R), nil
+}
+
+// OutputOwnersArgs describes who can spend an output, in addition to the
+// address the output is sent to
+type OutputOwnersArgs struct {
+	// Additional addresses that own the output
+	Addresses []string `json:"addresses"`
+
+	// Number of owners that must sign to spend the output. Defaults to 1.
+	Threshold json.Uint32 `json:"threshold"`
+
+	// Unix time before which the output can't be spent
+	Locktime json.Uint64 `json:"locktime"`
+}
+
 // SendOutput specifies that [Amount] of asset [AssetID] be sent to [To]
 type SendOutput struct {
 	// The amount of funds to send
@@ -983,6 +1407,38 @@ type SendOutput struct {
 
 	// Address of the recipient
 	To string `json:"to"`
+
+	// Additional owners, threshold and locktime of the output
+	OutputOwnersArgs
+}
+
+// outputOwners returns the owners of an output sent to [to] and
+// [args.Addresses]. [parseAddr] is used to parse [args.Addresses].
+func outputOwners(to ids.ShortID, args OutputOwnersArgs, parseAddr func(string) (ids.ShortID, error)) (secp256k1fx.OutputOwners, error) {
+	addrs := ids.ShortSet{}
+	addrs.Add(to)
+	for _, addrStr := range args.Addresses {
+		addr, err := parseAddr(addrStr)
+		if err != nil {
+			return secp256k1fx.OutputOwners{}, fmt.Errorf("problem parsing owner address %q: %w", addrStr, err)
+		}
+		addrs.Add(addr)
+	}
+
+	threshold := uint32(args.Threshold)
+	if threshold == 0 {
+		threshold = 1
+	}
+	owners := secp256k1fx.OutputOwners{
+		Locktime:  uint64(args.Locktime),
+		Threshold: threshold,
+		Addrs:     addrs.List(),
+	}
+	owners.Sort()
+	if err := owners.Verify(); err != nil {
+		return secp256k1fx.OutputOwners{}, fmt.Errorf("invalid output owners: %w", err)
+	}
+	return owners, nil
 }
 
 // SendArgs are arguments for passing into Send requests
@@ -1055,100 +1511,156 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		return err
 	}
 
-	// Calculate required input amounts and create the desired outputs
+	// Create the desired outputs
+	outs, _, err := service.sendOutputs(args.Outputs)
+	if err != nil {
+		return err
+	}
+
+	tx, err := service.vm.txBuilder().NewBaseTx(utxos, kc, outs, changeAddr, memoBytes)
+	if err != nil {
+		return err
+	}
+
+	txID, err := service.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
+// sendOutputs returns the outputs described by [outputs] and the amount of
+// each asset that must be spent to fund them, including the tx fee
+func (service *Service) sendOutputs(outputs []SendOutput) ([]*avax.TransferableOutput, map[ids.ID]uint64, error) {
 	// String repr. of asset ID --> asset ID
 	assetIDs := make(map[string]ids.ID)
 	// Asset ID --> amount of that asset being sent
 	amounts := make(map[ids.ID]uint64)
 	// Outputs of our tx
 	outs := []*avax.TransferableOutput{}
-	for _, output := range args.Outputs {
+	for _, output := range outputs {
 		if output.Amount == 0 {
-			return errInvalidAmount
+			return nil, nil, errInvalidAmount
 		}
 		assetID, ok := assetIDs[output.AssetID] // Asset ID of next output
 		if !ok {
+			var err error
 			assetID, err = service.vm.lookupAssetID(output.AssetID)
 			if err != nil {
-				return fmt.Errorf("couldn't find asset %s", output.AssetID)
+				return nil, nil, fmt.Errorf("couldn't find asset %s", output.AssetID)
 			}
 			assetIDs[output.AssetID] = assetID
 		}
 		currentAmount := amounts[assetID]
 		newAmount, err := safemath.Add64(currentAmount, uint64(output.Amount))
 		if err != nil {
-			return fmt.Errorf("problem calculating required spend amount: %w", err)
+			return nil, nil, fmt.Errorf("problem calculating required spend amount: %w", err)
 		}
 		amounts[assetID] = newAmount
 
 		// Parse the to address
 		to, err := service.vm.ParseLocalAddress(output.To)
 		if err != nil {
-			return fmt.Errorf("problem parsing to address %q: %w", output.To, err)
+			return nil, nil, fmt.Errorf("problem parsing to address %q: %w", output.To, err)
+		}
+		owners, err := outputOwners(to, output.OutputOwnersArgs, service.vm.ParseLocalAddress)
+		if err != nil {
+			return nil, nil, err
 		}
 
 		// Create the Output
 		outs = append(outs, &avax.TransferableOutput{
 			Asset: avax.Asset{ID: assetID},
 			Out: &secp256k1fx.TransferOutput{
-				Amt: uint64(output.Amount),
-				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
-					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
-				},
+				Amt:          uint64(output.Amount),
+				OutputOwners: owners,
 			},
 		})
 	}
 
-	amountsWithFee := make(map[ids.ID]uint64, len(amounts)+1)
-	for assetKey, amount := range amounts {
-		amountsWithFee[assetKey] = amount
-	}
-
 	amountWithFee, err := safemath.Add64(amounts[service.vm.ctx.AVAXAssetID], service.vm.txFee)
 	if err != nil {
-		return fmt.Errorf("problem calculating required spend amount: %w", err)
+		return nil, nil, fmt.Errorf("problem calculating required spend amount: %w", err)
 	}
-	amountsWithFee[service.vm.ctx.AVAXAssetID] = amountWithFee
+	amounts[service.vm.ctx.AVAXAssetID] = amountWithFee
+	return outs, amounts, nil
+}
 
-	amountsSpent, ins, keys, err := service.vm.Spend(
-		utxos,
-		kc,
-		amountsWithFee,
-	)
+// ConsolidateArgs are arguments for passing into Consolidate requests
+type ConsolidateArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+
+	// Assets whose UTXOs are consolidated. If empty, the UTXOs of every asset
+	// are consolidated.
+	AssetIDs []string `json:"assetIDs"`
+}
+
+// ConsolidateReply defines the Consolidate replies returned from the API
+type ConsolidateReply struct {
+	api.JSONTxIDChangeAddr
+
+	// Number of UTXOs the transaction merges
+	Merged json.Uint64 `json:"merged"`
+
+	// Number of UTXOs that didn't fit in the transaction. Consolidating again
+	// merges them.
+	Remaining json.Uint64 `json:"remaining"`
+}
+
+// Consolidate issues a transaction that merges the UTXOs of the user into one
+// UTXO per asset, owned by the change address. The smallest UTXOs are merged
+// first. Assets the user only holds one UTXO of are left alone.
+func (service *Service) Consolidate(_ *http.Request, args *ConsolidateArgs, reply *ConsolidateReply) error {
+	service.vm.ctx.Log.Info("AVM: Consolidate called with username: %s", args.Username)
+
+	assetIDs := ids.Set{}
+	for _, assetStr := range args.AssetIDs {
+		assetID, err := service.vm.lookupAssetID(assetStr)
+		if err != nil {
+			return err
+		}
+		assetIDs.Add(assetID)
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'From' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// Load user's UTXOs/keys
+	utxos, kc, err := service.vm.LoadUser(args.Username, args.Password, fromAddrs)
 	if err != nil {
 		return err
 	}
 
-	// Add the required change outputs
-	for assetID, amountWithFee := range amountsWithFee {
-		amountSpent := amountsSpent[assetID]
+	// Parse the change address.
+	if len(kc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := service.vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return err
+	}
 
-		if amountSpent > amountWithFee {
-			outs = append(outs, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: assetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: amountSpent - amountWithFee,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{changeAddr},
-					},
-				},
-			})
+	spendable := wallet.KeychainSpendableUTXOs(utxos, kc, service.vm.clock.Unix())
+	for assetID, assetUTXOs := range spendable {
+		if len(assetUTXOs) < 2 || (assetIDs.Len() != 0 && !assetIDs.Contains(assetID)) {
+			delete(spendable, assetID)
 		}
 	}
-	avax.SortTransferableOutputs(outs, service.vm.codec)
+	candidates := service.vm.mergeOrder(spendable, false)
 
-	tx := Tx{UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
-		NetworkID:    service.vm.ctx.NetworkID,
-		BlockchainID: service.vm.ctx.ChainID,
-		Outs:         outs,
-		Ins:          ins,
-		Memo:         memoBytes,
-	}}}
-	if err := tx.SignSECP256K1Fx(service.vm.codec, keys); err != nil {
+	tx, remaining, err := service.vm.newMergeTx(candidates, utxos, kc, changeAddr)
+	if err != nil {
 		return err
 	}
 
@@ -1158,16 +1670,91 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 	}
 
 	reply.TxID = txID
+	reply.Merged = json.Uint64(len(candidates) - remaining)
+	reply.Remaining = json.Uint64(remaining)
 	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
 	return err
 }
 
+// SweepArgs are arguments for passing into Sweep requests
+type SweepArgs struct {
+	api.UserPass
+
+	// Addresses whose funds are swept
+	api.JSONFromAddrs
+
+	// Address the funds are sent to
+	To string `json:"to"`
+}
+
+// SweepReply defines the Sweep replies returned from the API
+type SweepReply struct {
+	api.JSONTxID
+
+	// Number of UTXOs the transaction spends
+	Swept json.Uint64 `json:"swept"`
+
+	// Number of UTXOs that didn't fit in the transaction. Sweeping again moves
+	// them.
+	Remaining json.Uint64 `json:"remaining"`
+}
+
+// Sweep issues a transaction that sends everything the given addresses can
+// spend to one address, as one UTXO per asset. The transaction fee is paid out
+// of the swept AVAX. The largest UTXOs are swept first.
+func (service *Service) Sweep(_ *http.Request, args *SweepArgs, reply *SweepReply) error {
+	service.vm.ctx.Log.Info("AVM: Sweep called with username: %s", args.Username)
+
+	if len(args.From) == 0 {
+		return errNoAddresses
+	}
+	to, err := service.vm.ParseLocalAddress(args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address %q: %w", args.To, err)
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'From' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// Load the UTXOs/keys of the from addresses
+	utxos, kc, err := service.vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	spendable := wallet.KeychainSpendableUTXOs(utxos, kc, service.vm.clock.Unix())
+	candidates := service.vm.mergeOrder(spendable, true)
+
+	tx, remaining, err := service.vm.newMergeTx(candidates, utxos, kc, to)
+	if err != nil {
+		return err
+	}
+
+	txID, err := service.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.Swept = json.Uint64(len(candidates) - remaining)
+	reply.Remaining = json.Uint64(remaining)
+	return nil
+}
+
 // MintArgs are arguments for passing into Mint requests
 type MintArgs struct {
 	api.JSONSpendHeader             // User, password, from addrs, change addr
 	Amount              json.Uint64 `json:"amount"`
 	AssetID             string      `json:"assetID"`
 	To                  string      `json:"to"`
+	OutputOwnersArgs                // Additional owners, threshold and locktime of the minted output
 }
 
 // Mint issues a transaction that mints more of the asset
@@ -1187,6 +1774,10 @@ func (service *Service) Mint(r *http.Request, args *MintArgs, reply *api.JSONTxI
 	if err != nil {
 		return fmt.Errorf("problem parsing to address %q: %w", args.To, err)
 	}
+	owners, err := outputOwners(to, args.OutputOwnersArgs, service.vm.ParseLocalAddress)
+	if err != nil {
+		return err
+	}
 
 	// Parse the from addresses
 	fromAddrs := ids.ShortSet{}
@@ -1213,7 +1804,7 @@ func (service *Service) Mint(r *http.Request, args *MintArgs, reply *api.JSONTxI
 		return err
 	}
 
-	amountsSpent, ins, keys, err := service.vm.Spend(
+	amountsSpent, ins, keys, err := service.vm.txBuilder().Spend(
 		feeUTXOs,
 		feeKc,
 		map[ids.ID]uint64{
@@ -1245,13 +1836,13 @@ func (service *Service) Mint(r *http.Request, args *MintArgs, reply *api.JSONTxI
 		return err
 	}
 
-	ops, opKeys, err := service.vm.Mint(
+	ops, opKeys, err := service.vm.txBuilder().Mint(
 		utxos,
 		kc,
 		map[ids.ID]uint64{
 			assetID.Key(): uint64(args.Amount),
 		},
-		to,
+		owners,
 	)
 	if err != nil {
 		return err
@@ -1330,7 +1921,7 @@ func (service *Service) SendNFT(r *http.Request, args *SendNFTArgs, reply *api.J
 		return err
 	}
 
-	amountsSpent, ins, secpKeys, err := service.vm.Spend(
+	amountsSpent, ins, secpKeys, err := service.vm.txBuilder().Spend(
 		utxos,
 		kc,
 		map[ids.ID]uint64{
@@ -1356,7 +1947,7 @@ func (service *Service) SendNFT(r *http.Request, args *SendNFTArgs, reply *api.J
 		})
 	}
 
-	ops, nftKeys, err := service.vm.SpendNFT(
+	ops, nftKeys, err := service.vm.txBuilder().SpendNFT(
 		utxos,
 		kc,
 		assetID,
@@ -1446,7 +2037,7 @@ func (service *Service) MintNFT(r *http.Request, args *MintNFTArgs, reply *api.J
 		return err
 	}
 
-	amountsSpent, ins, secpKeys, err := service.vm.Spend(
+	amountsSpent, ins, secpKeys, err := service.vm.txBuilder().Spend(
 		feeUTXOs,
 		feeKc,
 		map[ids.ID]uint64{
@@ -1478,7 +2069,7 @@ func (service *Service) MintNFT(r *http.Request, args *MintNFTArgs, reply *api.J
 		return err
 	}
 
-	ops, nftKeys, err := service.vm.MintNFT(
+	ops, nftKeys, err := service.vm.txBuilder().MintNFT(
 		utxos,
 		kc,
 		assetID,
@@ -1558,74 +2149,11 @@ func (service *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSO
 		return fmt.Errorf("problem retrieving user's atomic UTXOs: %w", err)
 	}
 
-	amountsSpent, importInputs, importKeys, err := service.vm.SpendAll(atomicUTXOs, kc)
+	tx, err := service.vm.txBuilder().NewImportTx(utxos, atomicUTXOs, kc, chainID, to)
 	if err != nil {
 		return err
 	}
 
-	ins := []*avax.TransferableInput{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-
-	avaxKey := service.vm.ctx.AVAXAssetID.Key()
-	if amountSpent := amountsSpent[avaxKey]; amountSpent < service.vm.txFee {
-		var localAmountsSpent map[ids.ID]uint64
-		localAmountsSpent, ins, keys, err = service.vm.Spend(
-			utxos,
-			kc,
-			map[ids.ID]uint64{
-				avaxKey: service.vm.txFee - amountSpent,
-			},
-		)
-		if err != nil {
-			return err
-		}
-		for asset, amount := range localAmountsSpent {
-			newAmount, err := safemath.Add64(amountsSpent[asset], amount)
-			if err != nil {
-				return fmt.Errorf("problem calculating required spend amount: %w", err)
-			}
-			amountsSpent[asset] = newAmount
-		}
-	}
-
-	// Because we ensured that we had enough inputs for the fee, we can
-	// safely just remove it without concern for underflow.
-	amountsSpent[service.vm.ctx.AVAXAssetID] -= service.vm.txFee
-
-	keys = append(keys, importKeys...)
-
-	outs := []*avax.TransferableOutput{}
-	for assetID, amount := range amountsSpent {
-		if amount > 0 {
-			outs = append(outs, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: assetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: amount,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{to},
-					},
-				},
-			})
-		}
-	}
-	avax.SortTransferableOutputs(outs, service.vm.codec)
-
-	tx := Tx{UnsignedTx: &ImportTx{
-		BaseTx: BaseTx{BaseTx: avax.BaseTx{
-			NetworkID:    service.vm.ctx.NetworkID,
-			BlockchainID: service.vm.ctx.ChainID,
-			Outs:         outs,
-			Ins:          ins,
-		}},
-		SourceChain: chainID,
-		ImportedIns: importInputs,
-	}}
-	if err := tx.SignSECP256K1Fx(service.vm.codec, keys); err != nil {
-		return err
-	}
-
 	txID, err := service.vm.IssueTx(tx.Bytes())
 	if err != nil {
 		return fmt.Errorf("problem issuing transaction: %w", err)
@@ -1645,6 +2173,10 @@ type ExportAVAXArgs struct {
 	// ID of the address that will receive the AVAX. This address includes the
 	// chainID, which is used to determine what the destination chain is.
 	To string `json:"to"`
+
+	// Additional owners, threshold and locktime of the exported output. The
+	// addresses must be on the same chain as [To].
+	OutputOwnersArgs
 }
 
 // ExportAVAX sends AVAX from this chain to the address specified by [to].
@@ -1670,21 +2202,11 @@ type ExportArgs struct {
 func (service *Service) Export(_ *http.Request, args *ExportArgs, reply *api.JSONTxIDChangeAddr) error {
 	service.vm.ctx.Log.Info("AVM: Export called with username: %s", args.Username)
 
-	// Parse the asset ID
-	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	chainID, exportOuts, _, err := service.exportOutputs(args.AssetID, args.To, uint64(args.Amount), args.OutputOwnersArgs)
 	if err != nil {
 		return err
 	}
 
-	chainID, to, err := service.vm.ParseAddress(args.To)
-	if err != nil {
-		return err
-	}
-
-	if args.Amount == 0 {
-		return errInvalidAmount
-	}
-
 	// Parse the from addresses
 	fromAddrs := ids.ShortSet{}
 	for _, addrStr := range args.From {
@@ -1710,56 +2232,294 @@ func (service *Service) Export(_ *http.Request, args *ExportArgs, reply *api.JSO
 		return err
 	}
 
+	tx, err := service.vm.txBuilder().NewExportTx(utxos, kc, chainID, exportOuts, changeAddr)
+	if err != nil {
+		return err
+	}
+
+	txID, err := service.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
+// exportOutputs returns the ID of the chain [toStr] is on, the output that
+// exports [amount] of [assetIDStr] to [toStr], and the amount of each asset
+// that must be spent to fund it, including the tx fee
+func (service *Service) exportOutputs(
+	assetIDStr string,
+	toStr string,
+	amount uint64,
+	ownersArgs OutputOwnersArgs,
+) (ids.ID, []*avax.TransferableOutput, map[ids.ID]uint64, error) {
+	// Parse the asset ID
+	assetID, err := service.vm.lookupAssetID(assetIDStr)
+	if err != nil {
+		return ids.ID{}, nil, nil, err
+	}
+
+	chainID, to, err := service.vm.ParseAddress(toStr)
+	if err != nil {
+		return ids.ID{}, nil, nil, err
+	}
+	owners, err := outputOwners(to, ownersArgs, func(addrStr string) (ids.ShortID, error) {
+		addrChainID, addr, err := service.vm.ParseAddress(addrStr)
+		if err != nil {
+			return ids.ShortID{}, err
+		}
+		if addrChainID != chainID {
+			return ids.ShortID{}, fmt.Errorf("expected an address on chain %s but got one on chain %s", chainID, addrChainID)
+		}
+		return addr, nil
+	})
+	if err != nil {
+		return ids.ID{}, nil, nil, err
+	}
+
+	if amount == 0 {
+		return ids.ID{}, nil, nil, errInvalidAmount
+	}
+
 	amounts := map[ids.ID]uint64{}
-	avaxKey := service.vm.ctx.AVAXAssetID.Key()
-	if assetID.Equals(service.vm.ctx.AVAXAssetID) {
-		amountWithFee, err := safemath.Add64(uint64(args.Amount), service.vm.txFee)
+	if assetID == service.vm.ctx.AVAXAssetID {
+		amountWithFee, err := safemath.Add64(amount, service.vm.txFee)
 		if err != nil {
-			return fmt.Errorf("problem calculating required spend amount: %w", err)
+			return ids.ID{}, nil, nil, fmt.Errorf("problem calculating required spend amount: %w", err)
 		}
 		amounts[service.vm.ctx.AVAXAssetID] = amountWithFee
 	} else {
 		amounts[service.vm.ctx.AVAXAssetID] = service.vm.txFee
-		amounts[assetID] = uint64(args.Amount)
-	}
-
-	amountsSpent, ins, keys, err := service.vm.Spend(utxos, kc, amounts)
-	if err != nil {
-		return err
+		amounts[assetID] = amount
 	}
 
 	exportOuts := []*avax.TransferableOutput{{
 		Asset: avax.Asset{ID: assetID},
 		Out: &secp256k1fx.TransferOutput{
-			Amt: uint64(args.Amount),
-			OutputOwners: secp256k1fx.OutputOwners{
-				Locktime:  0,
-				Threshold: 1,
-				Addrs:     []ids.ShortID{to},
-			},
+			Amt:          amount,
+			OutputOwners: owners,
 		},
 	}}
+	return chainID, exportOuts, amounts, nil
+}
 
-	outs := []*avax.TransferableOutput{}
-	for assetID, amountSpent := range amountsSpent {
-		amountToSend := amounts[assetID]
-		if amountSpent > amountToSend {
-			outs = append(outs, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: assetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: amountSpent - amountToSend,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{changeAddr},
-					},
-				},
+// UnsignedSpendHeader is the arguments to a method that builds an unsigned
+// transaction
+type UnsignedSpendHeader struct {
+	// Addresses whose funds are spent. Their keys aren't needed to build the
+	// transaction.
+	api.JSONFromAddrs
+
+	// Address change is sent to. Defaults to the first of the from addresses.
+	api.JSONChangeAddr
+
+	// Encoding of the returned transaction
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// parse returns the from addresses and the change address of the header
+func (h *UnsignedSpendHeader) parse(vm *VM) (ids.ShortSet, ids.ShortID, error) {
+	if len(h.From) == 0 {
+		return nil, ids.ShortID{}, errNoAddresses
+	}
+	fromAddrs := ids.ShortSet{}
+	firstAddr := ids.ShortID{}
+	for i, addrStr := range h.From {
+		addr, err := vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return nil, ids.ShortID{}, fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		if i == 0 {
+			firstAddr = addr
+		}
+		fromAddrs.Add(addr)
+	}
+	changeAddr, err := vm.selectChangeAddr(firstAddr, h.ChangeAddr)
+	return fromAddrs, changeAddr, err
+}
+
+// SignatureSlot is a signature that a transaction must contain
+type SignatureSlot struct {
+	// Index of the credential the signature belongs in
+	Credential json.Uint32 `json:"credential"`
+
+	// Address whose signature belongs in this slot
+	Address string `json:"address"`
+
+	// True if the signature has been provided
+	Signed bool `json:"signed"`
+}
+
+// PartialTxReply is a transaction that may be missing some of its signatures
+type PartialTxReply struct {
+	api.FormattedTx
+
+	// Every signature the transaction must contain
+	Signatures []SignatureSlot `json:"signatures"`
+
+	// True if every signature has been provided, in which case the
+	// transaction can be issued with IssueTx
+	Complete bool `json:"complete"`
+}
+
+// partialTxReply sets [reply] to describe [tx]
+func (service *Service) partialTxReply(tx *Tx, encoding formatting.Encoding, reply *PartialTxReply) error {
+	slots, err := service.vm.SigSlots(tx)
+	if err != nil {
+		return fmt.Errorf("problem inspecting signatures: %w", err)
+	}
+
+	reply.Tx, err = formatting.Encode(encoding, tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx as string: %w", err)
+	}
+	reply.Encoding = encoding
+	reply.Signatures = []SignatureSlot{}
+	reply.Complete = true
+	for i, credSlots := range slots {
+		for _, slot := range credSlots {
+			addr, err := service.vm.FormatLocalAddress(slot.Address)
+			if err != nil {
+				return fmt.Errorf("problem formatting address: %w", err)
+			}
+			reply.Signatures = append(reply.Signatures, SignatureSlot{
+				Credential: json.Uint32(i),
+				Address:    addr,
+				Signed:     slot.Signed,
 			})
+			reply.Complete = reply.Complete && slot.Signed
 		}
 	}
+	return nil
+}
+
+// parsePartialTx decodes a transaction that may be missing some of its
+// signatures
+func (service *Service) parsePartialTx(args *api.FormattedTx) (*Tx, error) {
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := service.vm.parsePrivateTx(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// BuildUnsignedSendArgs are arguments for passing into BuildUnsignedSend
+// requests
+type BuildUnsignedSendArgs struct {
+	// From addrs, change addr, encoding
+	UnsignedSpendHeader
+
+	// The outputs of the transaction
+	Outputs []SendOutput `json:"outputs"`
+
+	// Memo field
+	Memo string `json:"memo"`
+}
+
+// BuildUnsignedSend returns a transaction that sends funds from the given
+// addresses, without any of its signatures
+func (service *Service) BuildUnsignedSend(_ *http.Request, args *BuildUnsignedSendArgs, reply *PartialTxReply) error {
+	service.vm.ctx.Log.Info("AVM: BuildUnsignedSend called")
+
+	memoBytes := []byte(args.Memo)
+	if l := len(memoBytes); l > avax.MaxMemoSize {
+		return fmt.Errorf("max memo length is %d but provided memo field is length %d", avax.MaxMemoSize, l)
+	} else if len(args.Outputs) == 0 {
+		return errNoOutputs
+	}
+
+	fromAddrs, changeAddr, err := args.parse(service.vm)
+	if err != nil {
+		return err
+	}
+	outs, amountsWithFee, err := service.sendOutputs(args.Outputs)
+	if err != nil {
+		return err
+	}
+
+	utxos, _, _, err := service.vm.GetUTXOs(fromAddrs, ids.ShortEmpty, ids.Empty, -1, false)
+	if err != nil {
+		return fmt.Errorf("problem retrieving UTXOs: %w", err)
+	}
+	amountsSpent, ins, err := service.vm.SpendWithAddresses(utxos, fromAddrs, amountsWithFee)
+	if err != nil {
+		return err
+	}
+
+	outs = append(outs, wallet.ChangeOutputs(amountsSpent, amountsWithFee, changeAddr)...)
 	avax.SortTransferableOutputs(outs, service.vm.codec)
 
-	tx := Tx{UnsignedTx: &ExportTx{
+	tx, err := service.vm.NewUnsignedTx(&BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    service.vm.ctx.NetworkID,
+		BlockchainID: service.vm.ctx.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+		Memo:         memoBytes,
+	}})
+	if err != nil {
+		return err
+	}
+	return service.partialTxReply(tx, args.Encoding, reply)
+}
+
+// BuildUnsignedExportArgs are arguments for passing into BuildUnsignedExport
+// requests
+type BuildUnsignedExportArgs struct {
+	// From addrs, change addr, encoding
+	UnsignedSpendHeader
+
+	// Amount of the asset to export
+	Amount json.Uint64 `json:"amount"`
+
+	// ID of the asset to export. Defaults to AVAX.
+	AssetID string `json:"assetID"`
+
+	// Address, including the destination chain, that receives the asset
+	To string `json:"to"`
+
+	// Additional owners, threshold and locktime of the exported output. The
+	// addresses must be on the same chain as [To].
+	OutputOwnersArgs
+}
+
+// BuildUnsignedExport returns a transaction that exports funds from the
+// given addresses to another chain, without any of its signatures
+func (service *Service) BuildUnsignedExport(_ *http.Request, args *BuildUnsignedExportArgs, reply *PartialTxReply) error {
+	service.vm.ctx.Log.Info("AVM: BuildUnsignedExport called")
+
+	assetID := args.AssetID
+	if assetID == "" {
+		assetID = service.vm.ctx.AVAXAssetID.String()
+	}
+	chainID, exportOuts, amounts, err := service.exportOutputs(assetID, args.To, uint64(args.Amount), args.OutputOwnersArgs)
+	if err != nil {
+		return err
+	}
+	fromAddrs, changeAddr, err := args.parse(service.vm)
+	if err != nil {
+		return err
+	}
+
+	utxos, _, _, err := service.vm.GetUTXOs(fromAddrs, ids.ShortEmpty, ids.Empty, -1, false)
+	if err != nil {
+		return fmt.Errorf("problem retrieving UTXOs: %w", err)
+	}
+	amountsSpent, ins, err := service.vm.SpendWithAddresses(utxos, fromAddrs, amounts)
+	if err != nil {
+		return err
+	}
+
+	outs := wallet.ChangeOutputs(amountsSpent, amounts, changeAddr)
+	avax.SortTransferableOutputs(outs, service.vm.codec)
+
+	tx, err := service.vm.NewUnsignedTx(&ExportTx{
 		BaseTx: BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    service.vm.ctx.NetworkID,
 			BlockchainID: service.vm.ctx.ChainID,
@@ -1768,17 +2528,174 @@ func (service *Service) Export(_ *http.Request, args *ExportArgs, reply *api.JSO
 		}},
 		DestinationChain: chainID,
 		ExportedOuts:     exportOuts,
-	}}
-	if err := tx.SignSECP256K1Fx(service.vm.codec, keys); err != nil {
+	})
+	if err != nil {
 		return err
 	}
+	return service.partialTxReply(tx, args.Encoding, reply)
+}
 
-	txID, err := service.vm.IssueTx(tx.Bytes())
+// BuildUnsignedImportArgs are arguments for passing into BuildUnsignedImport
+// requests
+type BuildUnsignedImportArgs struct {
+	// Addresses that own the exported funds and pay the fee, change addr,
+	// encoding
+	UnsignedSpendHeader
+
+	// Chain the funds are coming from
+	SourceChain string `json:"sourceChain"`
+
+	// Address receiving the imported funds
+	To string `json:"to"`
+}
+
+// BuildUnsignedImport returns a transaction that imports the funds the given
+// addresses own on another chain, without any of its signatures
+func (service *Service) BuildUnsignedImport(_ *http.Request, args *BuildUnsignedImportArgs, reply *PartialTxReply) error {
+	service.vm.ctx.Log.Info("AVM: BuildUnsignedImport called")
+
+	chainID, err := service.vm.ctx.BCLookup.Lookup(args.SourceChain)
 	if err != nil {
-		return fmt.Errorf("problem issuing transaction: %w", err)
+		return fmt.Errorf("problem parsing chainID %q: %w", args.SourceChain, err)
+	}
+	to, err := service.vm.ParseLocalAddress(args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address %q: %w", args.To, err)
+	}
+	fromAddrs, changeAddr, err := args.parse(service.vm)
+	if err != nil {
+		return err
 	}
 
-	reply.TxID = txID
-	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
-	return err
+	atomicUTXOs, _, _, err := service.vm.GetAtomicUTXOs(chainID, fromAddrs, ids.ShortEmpty, ids.Empty, -1)
+	if err != nil {
+		return fmt.Errorf("problem retrieving atomic UTXOs: %w", err)
+	}
+	amountsImported, importInputs, err := service.vm.SpendAllWithAddresses(atomicUTXOs, fromAddrs)
+	if err != nil {
+		return err
+	}
+	if len(importInputs) == 0 {
+		return errNoImportInputs
+	}
+
+	// If the imported AVAX doesn't cover the fee, the rest is paid from the
+	// local funds of the from addresses.
+	ins := []*avax.TransferableInput{}
+	outs := []*avax.TransferableOutput{}
+	avaxAssetID := service.vm.ctx.AVAXAssetID
+	if amountImported := amountsImported[avaxAssetID]; amountImported < service.vm.txFee {
+		utxos, _, _, err := service.vm.GetUTXOs(fromAddrs, ids.ShortEmpty, ids.Empty, -1, false)
+		if err != nil {
+			return fmt.Errorf("problem retrieving UTXOs: %w", err)
+		}
+		amounts := map[ids.ID]uint64{
+			avaxAssetID: service.vm.txFee - amountImported,
+		}
+		var amountsSpent map[ids.ID]uint64
+		amountsSpent, ins, err = service.vm.SpendWithAddresses(utxos, fromAddrs, amounts)
+		if err != nil {
+			return err
+		}
+		outs = wallet.ChangeOutputs(amountsSpent, amounts, changeAddr)
+		amountsImported[avaxAssetID] = 0
+	} else {
+		amountsImported[avaxAssetID] -= service.vm.txFee
+	}
+	outs = append(outs, wallet.ChangeOutputs(amountsImported, nil, to)...)
+	avax.SortTransferableOutputs(outs, service.vm.codec)
+
+	tx, err := service.vm.NewUnsignedTx(&ImportTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    service.vm.ctx.NetworkID,
+			BlockchainID: service.vm.ctx.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+		SourceChain: chainID,
+		ImportedIns: importInputs,
+	})
+	if err != nil {
+		return err
+	}
+	return service.partialTxReply(tx, args.Encoding, reply)
+}
+
+// SignTxArgs are arguments for passing into SignTx requests
+type SignTxArgs struct {
+	// The partially signed transaction
+	api.FormattedTx
+
+	// User whose keys sign the transaction, if any
+	api.UserPass
+
+	// Key that signs the transaction, if any, in the format returned by
+	// ExportKey
+	PrivateKey string `json:"privateKey"`
+}
+
+// SignTx adds to a partially signed transaction the missing signatures that
+// the given user or key can provide. The returned transaction is encoded the
+// same way as the provided one.
+func (service *Service) SignTx(_ *http.Request, args *SignTxArgs, reply *PartialTxReply) error {
+	service.vm.ctx.Log.Info("AVM: SignTx called")
+
+	if args.Username == "" && args.PrivateKey == "" {
+		return errNoSigners
+	}
+	tx, err := service.parsePartialTx(&args.FormattedTx)
+	if err != nil {
+		return err
+	}
+
+	kc := secp256k1fx.NewKeychain()
+	if args.PrivateKey != "" {
+		sk, err := parsePrivateKey(args.PrivateKey)
+		if err != nil {
+			return err
+		}
+		kc.Add(sk)
+	}
+	if args.Username != "" {
+		db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+		if err != nil {
+			return fmt.Errorf("problem retrieving user: %w", err)
+		}
+		// Drop any potential error closing the database to report the
+		// original error
+		defer db.Close()
+
+		user := userState{vm: service.vm}
+		userKC, err := user.Keychain(db, nil)
+		if err != nil {
+			return err
+		}
+		for _, key := range userKC.Keys {
+			kc.Add(key)
+		}
+		if err := db.Close(); err != nil {
+			return err
+		}
+	}
+
+	numSigned, err := service.vm.SignPartialTx(tx, kc)
+	if err != nil {
+		return err
+	}
+	if numSigned == 0 {
+		return errNoMissingSigs
+	}
+	return service.partialTxReply(tx, args.Encoding, reply)
+}
+
+// GetTxSignatures returns which signatures a partially signed transaction
+// contains and which are still missing
+func (service *Service) GetTxSignatures(_ *http.Request, args *api.FormattedTx, reply *PartialTxReply) error {
+	service.vm.ctx.Log.Info("AVM: GetTxSignatures called")
+
+	tx, err := service.parsePartialTx(args)
+	if err != nil {
+		return err
+	}
+	return service.partialTxReply(tx, args.Encoding, reply)
 }
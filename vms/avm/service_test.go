@@ -5,6 +5,8 @@ package avm
 
 import (
 	"bytes"
+	stdjson "encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -15,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -181,6 +184,70 @@ func TestServiceIssueTx(t *testing.T) {
 	}
 }
 
+func TestServiceSimulateTx(t *testing.T) {
+	genesisBytes, vm, s, _ := setup(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	tx := NewTx(t, genesisBytes, vm)
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}
+	reply := &SimulateTxReply{}
+	assert.NoError(t, s.SimulateTx(nil, args, reply))
+	assert.True(t, reply.Valid, reply.Error)
+	assert.True(t, reply.Signed)
+	assert.Equal(t, tx.ID(), reply.TxID)
+	assert.Equal(t, startBalance, uint64(reply.Fee))
+	assert.Equal(t, vm.txFee, uint64(reply.RequiredFee))
+	assert.Len(t, reply.Consumed, 1)
+	assert.Equal(t, startBalance, uint64(reply.Consumed[0].Amount))
+	assert.Empty(t, reply.Produced)
+
+	// Simulating the transaction must not issue it
+	uniqueTx := &UniqueTx{
+		vm:   vm,
+		txID: tx.ID(),
+	}
+	assert.Equal(t, choices.Unknown, uniqueTx.Status())
+
+	// The unsigned transaction is valid, apart from its credentials
+	args.Tx, err = formatting.Encode(formatting.Hex, tx.UnsignedBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply = &SimulateTxReply{}
+	assert.NoError(t, s.SimulateTx(nil, args, reply))
+	assert.True(t, reply.Valid, reply.Error)
+	assert.False(t, reply.Signed)
+	assert.Equal(t, tx.ID(), reply.TxID)
+
+	// A transaction with an invalid signature is signed but invalid
+	tx.Creds[0].(*secp256k1fx.Credential).Sigs[0][0] ^= 1
+	txBytes, err := vm.codec.Marshal(codecVersion, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args.Tx, err = formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply = &SimulateTxReply{}
+	assert.NoError(t, s.SimulateTx(nil, args, reply))
+	assert.False(t, reply.Valid)
+	assert.True(t, reply.Signed)
+	assert.NotEmpty(t, reply.Error)
+}
+
 func TestServiceGetTxStatus(t *testing.T) {
 	genesisBytes, vm, s, _ := setup(t)
 	defer func() {
@@ -261,6 +328,350 @@ func TestServiceGetBalance(t *testing.T) {
 	assert.Len(t, balanceReply.UTXOIDs, 1, "should have only returned 1 utxoID")
 }
 
+func TestServiceGetAddressTxs(t *testing.T) {
+	genesisBytes, vm, s, _ := setup(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	genesisTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+	assetID := genesisTx.ID()
+	addr := keys[0].PublicKey().Address()
+	addrStr, err := vm.FormatLocalAddress(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addrStr},
+		AssetID:     assetID.String(),
+	}
+	reply := &GetAddressTxsReply{}
+	err = s.GetAddressTxs(nil, args, reply)
+	assert.Equal(t, errAddressIndexDisabled, err)
+
+	// Enabling the index after the genesis was accepted should index it
+	vm.indexAddressTxs = true
+	if err := vm.initAddressIndex(); err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.GetAddressTxs(nil, args, reply)
+	assert.NoError(t, err)
+	assert.Equal(t, []ids.ID{assetID}, reply.TxIDs)
+	assert.Equal(t, uint64(1), uint64(reply.Cursor))
+
+	args.Cursor = reply.Cursor
+	reply = &GetAddressTxsReply{}
+	err = s.GetAddressTxs(nil, args, reply)
+	assert.NoError(t, err)
+	assert.Empty(t, reply.TxIDs)
+	assert.Equal(t, uint64(1), uint64(reply.Cursor))
+}
+
+func TestServiceGetAssetSupply(t *testing.T) {
+	genesisBytes, vm, s, _ := setup(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	genesisTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+	assetID := genesisTx.ID()
+
+	args := &GetAssetDescriptionArgs{AssetID: assetID.String()}
+	reply := &GetAssetSupplyReply{}
+	err := s.GetAssetSupply(nil, args, reply)
+	assert.Equal(t, errAssetIndexDisabled, err)
+
+	// Enabling the index after the genesis was accepted should index it
+	vm.indexAssets = true
+	if err := vm.initAssetIndex(); err != nil {
+		t.Fatal(err)
+	}
+
+	minted := uint64(0)
+	balances := make(map[[20]byte]uint64)
+	for _, utxo := range genesisTx.UTXOs() {
+		out := utxo.Out.(*secp256k1fx.TransferOutput)
+		minted += out.Amt
+		for _, addr := range out.Addrs {
+			balances[addr.Key()] += out.Amt
+		}
+	}
+
+	err = s.GetAssetSupply(nil, args, reply)
+	assert.NoError(t, err)
+	assert.Equal(t, assetID, reply.AssetID)
+	assert.Equal(t, minted, uint64(reply.Minted))
+	assert.Equal(t, uint64(0), uint64(reply.Burned))
+	assert.Equal(t, minted, uint64(reply.Supply))
+	assert.Equal(t, uint64(len(balances)), uint64(reply.Holders))
+
+	holdersReply := &GetAssetHoldersReply{}
+	err = s.GetAssetHolders(nil, &GetAssetHoldersArgs{AssetID: assetID.String()}, holdersReply)
+	assert.NoError(t, err)
+	assert.Len(t, holdersReply.Holders, len(balances))
+	assert.Equal(t, uint64(len(balances)), uint64(holdersReply.Cursor))
+	for i, holder := range holdersReply.Holders {
+		if i > 0 {
+			assert.LessOrEqual(t, uint64(holder.Balance), uint64(holdersReply.Holders[i-1].Balance))
+		}
+		addr, err := vm.ParseLocalAddress(holder.Address)
+		assert.NoError(t, err)
+		assert.Equal(t, balances[addr.Key()], uint64(holder.Balance))
+	}
+
+	mintReply := &GetAssetMintOwnersReply{}
+	err = s.GetAssetMintOwners(nil, args, mintReply)
+	assert.NoError(t, err)
+	assert.Empty(t, mintReply.MintOwners)
+}
+
+func TestServiceGetAssetSupplyAccept(t *testing.T) {
+	genesisBytes, vm, s, _ := setupWithKeys(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	vm.indexAssets = true
+	if err := vm.initAssetIndex(); err != nil {
+		t.Fatal(err)
+	}
+	vm.timer.Cancel()
+
+	avaxID := GetAVAXTxFromGenesisTest(genesisBytes, t).ID()
+	varCapID, err := vm.lookupAssetID("asset3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrStrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addrStrs[i], err = vm.FormatLocalAddress(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	toAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userPass := api.UserPass{
+		Username: username,
+		Password: password,
+	}
+
+	accept := func(txID ids.ID) {
+		tx, err := vm.GetTx(txID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Verify(); err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Accept(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertSupply := func(assetID ids.ID, minted, burned uint64, holders map[string]uint64) {
+		reply := &GetAssetSupplyReply{}
+		if err := s.GetAssetSupply(nil, &GetAssetDescriptionArgs{AssetID: assetID.String()}, reply); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, minted, uint64(reply.Minted))
+		assert.Equal(t, burned, uint64(reply.Burned))
+		assert.Equal(t, minted-burned, uint64(reply.Supply))
+		assert.Equal(t, uint64(len(holders)), uint64(reply.Holders))
+
+		holdersReply := &GetAssetHoldersReply{}
+		if err := s.GetAssetHolders(nil, &GetAssetHoldersArgs{AssetID: assetID.String()}, holdersReply); err != nil {
+			t.Fatal(err)
+		}
+		balances := make(map[string]uint64, len(holdersReply.Holders))
+		for _, holder := range holdersReply.Holders {
+			balances[holder.Address] = uint64(holder.Balance)
+		}
+		assert.Equal(t, holders, balances)
+	}
+
+	// A transfer of the whole balance of an address removes it from the
+	// holders and burns the fee
+	sendReply := &api.JSONTxIDChangeAddr{}
+	err = s.Send(nil, &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       userPass,
+			JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStrs[2]}},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStrs[2]},
+		},
+		SendOutput: SendOutput{
+			Amount:  json.Uint64(startBalance - vm.txFee),
+			AssetID: avaxID.String(),
+			To:      toAddrStr,
+		},
+	}, sendReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept(sendReply.TxID)
+	assertSupply(avaxID, 3*startBalance, vm.txFee, map[string]uint64{
+		addrStrs[0]: startBalance,
+		addrStrs[1]: startBalance,
+		toAddrStr:   startBalance - vm.txFee,
+	})
+
+	mintOwnersReply := &GetAssetMintOwnersReply{}
+	if err := s.GetAssetMintOwners(nil, &GetAssetDescriptionArgs{AssetID: varCapID.String()}, mintOwnersReply); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, mintOwnersReply.MintOwners, 1)
+	genesisMintUTXOID := mintOwnersReply.MintOwners[0].UTXOID
+
+	// A mint creates units of the asset and replaces the mint output it spends
+	mintReply := &api.JSONTxIDChangeAddr{}
+	err = s.Mint(nil, &MintArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       userPass,
+			JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStrs[0]}},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStrs[0]},
+		},
+		Amount:  100,
+		AssetID: varCapID.String(),
+		To:      addrStrs[1],
+	}, mintReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept(mintReply.TxID)
+	assertSupply(varCapID, 100, 0, map[string]uint64{
+		addrStrs[1]: 100,
+	})
+	assertSupply(avaxID, 3*startBalance, 2*vm.txFee, map[string]uint64{
+		addrStrs[0]: startBalance - vm.txFee,
+		addrStrs[1]: startBalance,
+		toAddrStr:   startBalance - vm.txFee,
+	})
+
+	mintOwnersReply = &GetAssetMintOwnersReply{}
+	if err := s.GetAssetMintOwners(nil, &GetAssetDescriptionArgs{AssetID: varCapID.String()}, mintOwnersReply); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, mintOwnersReply.MintOwners, 1)
+	mintOwners := mintOwnersReply.MintOwners[0]
+	assert.Equal(t, mintReply.TxID, mintOwners.UTXOID.TxID)
+	assert.NotEqual(t, genesisMintUTXOID.InputID(), mintOwners.UTXOID.InputID())
+	assert.Equal(t, []string{addrStrs[0]}, mintOwners.Addresses)
+
+	// A transaction that produces less of an asset than it consumes burns the
+	// difference
+	addrSet := ids.ShortSet{}
+	addrSet.Add(addrs[1])
+	utxos, _, _, err := vm.getAllUTXOs(addrSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kc := secp256k1fx.NewKeychain()
+	kc.Add(keys[1])
+	amountsSpent, ins, signers, err := vm.txBuilder().Spend(utxos, kc, map[ids.ID]uint64{
+		varCapID: 100,
+		avaxID:   vm.txFee,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outs := []*avax.TransferableOutput{
+		{
+			Asset: avax.Asset{ID: varCapID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 40,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addrs[1]},
+				},
+			},
+		},
+		{
+			Asset: avax.Asset{ID: avaxID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amountsSpent[avaxID] - vm.txFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addrs[1]},
+				},
+			},
+		},
+	}
+	avax.SortTransferableOutputs(outs, vm.codec)
+	burnTx := &Tx{UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    vm.ctx.NetworkID,
+		BlockchainID: vm.ctx.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+	}}}
+	if err := burnTx.SignSECP256K1Fx(vm.codec, signers); err != nil {
+		t.Fatal(err)
+	}
+	burnTxID, err := vm.IssueTx(burnTx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept(burnTxID)
+	assertSupply(varCapID, 100, 60, map[string]uint64{
+		addrStrs[1]: 40,
+	})
+	assertSupply(avaxID, 3*startBalance, 3*vm.txFee, map[string]uint64{
+		addrStrs[0]: startBalance - vm.txFee,
+		addrStrs[1]: startBalance - vm.txFee,
+		toAddrStr:   startBalance - vm.txFee,
+	})
+}
+
+func TestOutputOwners(t *testing.T) {
+	addr0 := keys[0].PublicKey().Address()
+	addr1 := keys[1].PublicKey().Address()
+	addrs := map[string]ids.ShortID{
+		"addr0": addr0,
+		"addr1": addr1,
+	}
+	parseAddr := func(addrStr string) (ids.ShortID, error) {
+		addr, ok := addrs[addrStr]
+		if !ok {
+			return ids.ShortID{}, errors.New("unknown address")
+		}
+		return addr, nil
+	}
+
+	owners, err := outputOwners(addr0, OutputOwnersArgs{}, parseAddr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), owners.Threshold)
+	assert.Equal(t, uint64(0), owners.Locktime)
+	assert.Len(t, owners.Addrs, 1)
+
+	owners, err = outputOwners(addr0, OutputOwnersArgs{
+		Addresses: []string{"addr1", "addr0"},
+		Threshold: 2,
+		Locktime:  1234,
+	}, parseAddr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), owners.Threshold)
+	assert.Equal(t, uint64(1234), owners.Locktime)
+	assert.Len(t, owners.Addrs, 2)
+	assert.True(t, ids.IsSortedAndUniqueShortIDs(owners.Addrs))
+
+	_, err = outputOwners(addr0, OutputOwnersArgs{Threshold: 2}, parseAddr)
+	assert.Error(t, err, "should have failed with an unspendable threshold")
+
+	_, err = outputOwners(addr0, OutputOwnersArgs{Addresses: []string{"unknown"}}, parseAddr)
+	assert.Error(t, err, "should have failed to parse the address")
+}
+
 func TestServiceGetAllBalances(t *testing.T) {
 	genesisBytes, vm, s, _ := setup(t)
 	defer func() {
@@ -309,7 +720,7 @@ func TestServiceGetTx(t *testing.T) {
 	genesisTxBytes := genesisTx.Bytes()
 	txID := genesisTx.ID()
 
-	reply := api.FormattedTx{}
+	reply := api.GetTxReply{}
 	err := s.GetTx(nil, &api.GetTxArgs{
 		TxID: txID,
 	}, &reply)
@@ -317,13 +728,59 @@ func TestServiceGetTx(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	txBytes, err := formatting.Decode(reply.Encoding, reply.Tx)
+	txBytes, err := formatting.Decode(reply.Encoding, reply.Tx.(string))
 	if err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, genesisTxBytes, txBytes, "Wrong tx returned from service.GetTx")
 }
 
+func TestServiceGetTxJSON(t *testing.T) {
+	genesisBytes, vm, s, _ := setup(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	genesisTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+
+	reply := api.GetTxReply{}
+	err := s.GetTx(nil, &api.GetTxArgs{
+		TxID:     genesisTx.ID(),
+		Encoding: formatting.JSON,
+	}, &reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, formatting.JSON, reply.Encoding)
+
+	txJSON, ok := reply.Tx.(*JSONTx)
+	if !ok {
+		t.Fatalf("expected *JSONTx but got %T", reply.Tx)
+	}
+	assert.Equal(t, genesisTx.ID(), txJSON.ID)
+	assert.Equal(t, "avm.CreateAssetTx", txJSON.Type)
+
+	createAssetTx := txJSON.UnsignedTx.(*JSONCreateAssetTx)
+	assert.Equal(t, "AVAX", createAssetTx.Name)
+	if assert.Len(t, createAssetTx.InitialStates, 1) && assert.NotEmpty(t, createAssetTx.InitialStates[0].Outputs) {
+		out := createAssetTx.InitialStates[0].Outputs[0]
+		assert.Equal(t, "secp256k1fx.TransferOutput", out.Type)
+		transferOut := out.Output.(*secp256k1fx.JSONTransferOutput)
+		if assert.Len(t, transferOut.Addresses, 1) {
+			addr, err := vm.ParseLocalAddress(transferOut.Addresses[0])
+			assert.NoError(t, err)
+			assert.Equal(t, genesisTx.UnsignedTx.(*CreateAssetTx).States[0].Outs[0].(*secp256k1fx.TransferOutput).Addrs[0], addr)
+		}
+	}
+
+	// The decoded transaction can be marshalled
+	_, err = stdjson.Marshal(reply)
+	assert.NoError(t, err)
+}
+
 func TestServiceGetNilTx(t *testing.T) {
 	_, vm, s, _ := setup(t)
 	defer func() {
@@ -333,7 +790,7 @@ func TestServiceGetNilTx(t *testing.T) {
 		vm.ctx.Lock.Unlock()
 	}()
 
-	reply := api.FormattedTx{}
+	reply := api.GetTxReply{}
 	err := s.GetTx(nil, &api.GetTxArgs{}, &reply)
 	assert.Error(t, err, "Nil TxID should have returned an error")
 }
@@ -347,7 +804,7 @@ func TestServiceGetUnknownTx(t *testing.T) {
 		vm.ctx.Lock.Unlock()
 	}()
 
-	reply := api.FormattedTx{}
+	reply := api.GetTxReply{}
 	err := s.GetTx(nil, &api.GetTxArgs{TxID: ids.Empty}, &reply)
 	assert.Error(t, err, "Unknown TxID should have returned an error")
 }
@@ -1139,6 +1596,329 @@ func TestSend(t *testing.T) {
 	}
 }
 
+func TestCancelTx(t *testing.T) {
+	genesisBytes, vm, s, _ := setupWithKeys(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	genesisTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+	assetID := genesisTx.ID()
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userPass := api.UserPass{
+		Username: username,
+		Password: password,
+	}
+
+	vm.timer.Cancel()
+	sendReply := &api.JSONTxIDChangeAddr{}
+	err = s.Send(nil, &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{UserPass: userPass},
+		SendOutput: SendOutput{
+			Amount:  500,
+			AssetID: assetID.String(),
+			To:      addrStr,
+		},
+	}, sendReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sentTxID := sendReply.TxID
+
+	pendingReply := &GetPendingTxsReply{}
+	assert.NoError(t, s.GetPendingTxs(nil, nil, pendingReply))
+	assert.Equal(t, []PendingTx{{
+		TxID:      sentTxID,
+		Queued:    true,
+		Conflicts: []ids.ID{},
+	}}, pendingReply.Txs)
+
+	cancelArgs := &CancelTxArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       userPass,
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		TxID: sentTxID,
+		Fee:  json.Uint64(vm.txFee),
+	}
+	cancelReply := &api.JSONTxIDChangeAddr{}
+	err = s.CancelTx(nil, cancelArgs, cancelReply)
+	assert.Equal(t, errFeeTooLow, err)
+
+	cancelArgs.Fee = 0
+	assert.NoError(t, s.CancelTx(nil, cancelArgs, cancelReply))
+	assert.Equal(t, changeAddrStr, cancelReply.ChangeAddr)
+	cancelTxID := cancelReply.TxID
+
+	cancelTx, err := vm.state.Tx(cancelTxID)
+	assert.NoError(t, err)
+	fee, err := vm.txFeePaid(cancelTx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*vm.txFee, fee)
+
+	pendingReply = &GetPendingTxsReply{}
+	assert.NoError(t, s.GetPendingTxs(nil, nil, pendingReply))
+	assert.Len(t, pendingReply.Txs, 2)
+	for _, pending := range pendingReply.Txs {
+		assert.True(t, pending.Queued)
+		switch pending.TxID {
+		case sentTxID:
+			assert.Equal(t, []ids.ID{cancelTxID}, pending.Conflicts)
+		case cancelTxID:
+			assert.Equal(t, []ids.ID{sentTxID}, pending.Conflicts)
+		default:
+			t.Fatalf("unexpected pending tx %s", pending.TxID)
+		}
+	}
+
+	// A transaction that was decided can't be cancelled
+	cancelArgs.TxID = assetID
+	err = s.CancelTx(nil, cancelArgs, cancelReply)
+	assert.Equal(t, errTxNotProcessing, err)
+}
+
+type testConflictGraph struct{ conflicts ids.Set }
+
+func (cg *testConflictGraph) Conflicts(snowstorm.Tx) ids.Set { return cg.conflicts }
+
+func TestGetPendingTxsConflictsAndPruning(t *testing.T) {
+	genesisBytes, vm, s, _ := setupWithKeys(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Consensus reports a conflict the VM doesn't know about
+	consensusConflictID := ids.GenerateTestID()
+	graph := &testConflictGraph{}
+	graph.conflicts.Add(consensusConflictID)
+	vm.SetConflictGraph(graph)
+
+	genesisTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userPass := api.UserPass{
+		Username: username,
+		Password: password,
+	}
+	send := func() ids.ID {
+		reply := &api.JSONTxIDChangeAddr{}
+		if err := s.Send(nil, &SendArgs{
+			JSONSpendHeader: api.JSONSpendHeader{UserPass: userPass},
+			SendOutput: SendOutput{
+				Amount:  500,
+				AssetID: genesisTx.ID().String(),
+				To:      addrStr,
+			},
+		}, reply); err != nil {
+			t.Fatal(err)
+		}
+		return reply.TxID
+	}
+	getPending := func() []PendingTx {
+		reply := &GetPendingTxsReply{}
+		if err := s.GetPendingTxs(nil, nil, reply); err != nil {
+			t.Fatal(err)
+		}
+		return reply.Txs
+	}
+
+	vm.timer.Cancel()
+	sentTxID := send()
+	assert.Equal(t, []PendingTx{{
+		TxID:      sentTxID,
+		Queued:    true,
+		Conflicts: []ids.ID{consensusConflictID},
+	}}, getPending())
+
+	cancelReply := &api.JSONTxIDChangeAddr{}
+	err = s.CancelTx(nil, &CancelTxArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       userPass,
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		TxID: sentTxID,
+	}, cancelReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancelTxID := cancelReply.TxID
+
+	pending := getPending()
+	assert.Len(t, pending, 2)
+	for _, pendingTx := range pending {
+		conflicts := []ids.ID{consensusConflictID}
+		switch pendingTx.TxID {
+		case sentTxID:
+			conflicts = append(conflicts, cancelTxID)
+		case cancelTxID:
+			conflicts = append(conflicts, sentTxID)
+		default:
+			t.Fatalf("unexpected pending tx %s", pendingTx.TxID)
+		}
+		ids.SortIDs(conflicts)
+		assert.Equal(t, conflicts, pendingTx.Conflicts)
+	}
+
+	// Accepting the cancel tx removes it from the pending txs
+	cancelTx, err := vm.GetTx(cancelTxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cancelTx.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cancelTx.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	pending = getPending()
+	assert.Len(t, pending, 1)
+	assert.Equal(t, sentTxID, pending[0].TxID)
+
+	// The cancelled tx spends a consumed UTXO, so it fails verification when
+	// it is issued and is removed from the pending txs
+	sentTx, err := vm.GetTx(sentTxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sentTx.Verify(); err == nil {
+		t.Fatalf("should have failed to verify the cancelled tx")
+	}
+	assert.Empty(t, getPending())
+
+	// Rejecting a tx removes it from the pending txs
+	rejectedTxID := send()
+	assert.Len(t, getPending(), 1)
+	rejectedTx, err := vm.GetTx(rejectedTxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rejectedTx.Reject(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, getPending())
+}
+
+func TestPartiallySignedSend(t *testing.T) {
+	genesisBytes, vm, s, _ := setupWithKeys(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	genesisTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+	fromAddrs := make([]string, 2)
+	for i := range fromAddrs {
+		addr, err := vm.FormatLocalAddress(addrs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromAddrs[i] = addr
+	}
+	toAddr, err := vm.FormatLocalAddress(testChangeAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// More than a single address owns, so both addresses must sign
+	built := &PartialTxReply{}
+	err = s.BuildUnsignedSend(nil, &BuildUnsignedSendArgs{
+		UnsignedSpendHeader: UnsignedSpendHeader{
+			JSONFromAddrs: api.JSONFromAddrs{From: fromAddrs},
+			Encoding:      formatting.Hex,
+		},
+		Outputs: []SendOutput{{
+			Amount:  json.Uint64(startBalance),
+			AssetID: genesisTx.ID().String(),
+			To:      toAddr,
+		}},
+	}, built)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if built.Complete {
+		t.Fatal("unsigned tx shouldn't be complete")
+	}
+	signers := map[string]bool{}
+	for _, slot := range built.Signatures {
+		if slot.Signed {
+			t.Fatal("unsigned tx shouldn't contain signatures")
+		}
+		signers[slot.Address] = true
+	}
+	if len(signers) != 2 || !signers[fromAddrs[0]] || !signers[fromAddrs[1]] {
+		t.Fatalf("expected signatures from %v but got %+v", fromAddrs, built.Signatures)
+	}
+
+	signWithKey := func(partial *PartialTxReply, key *crypto.PrivateKeySECP256K1R) *PartialTxReply {
+		keyStr, err := formatting.Encode(formatting.CB58, key.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		signed := &PartialTxReply{}
+		if err := s.SignTx(nil, &SignTxArgs{
+			FormattedTx: partial.FormattedTx,
+			PrivateKey:  constants.SecretKeyPrefix + keyStr,
+		}, signed); err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	partial := signWithKey(built, keys[0])
+	if partial.Complete {
+		t.Fatal("tx signed by one of two addresses shouldn't be complete")
+	}
+	for _, slot := range partial.Signatures {
+		if slot.Signed != (slot.Address == fromAddrs[0]) {
+			t.Fatalf("unexpected signature slot %+v", slot)
+		}
+	}
+	if err := s.IssueTx(nil, &partial.FormattedTx, &api.JSONTxID{}); err == nil {
+		t.Fatal("should have failed to issue a partially signed tx")
+	}
+
+	signed := signWithKey(partial, keys[1])
+	if !signed.Complete {
+		t.Fatalf("signed tx should be complete but has signatures %+v", signed.Signatures)
+	}
+	inspected := &PartialTxReply{}
+	if err := s.GetTxSignatures(nil, &signed.FormattedTx, inspected); err != nil {
+		t.Fatal(err)
+	}
+	if !inspected.Complete || len(inspected.Signatures) != len(built.Signatures) {
+		t.Fatalf("unexpected signatures %+v", inspected.Signatures)
+	}
+
+	issued := &api.JSONTxID{}
+	if err := s.IssueTx(nil, &signed.FormattedTx, issued); err != nil {
+		t.Fatal(err)
+	}
+	if status := (&UniqueTx{vm: vm, txID: issued.TxID}).Status(); status != choices.Processing {
+		t.Fatalf("issued tx should be processing but is %s", status)
+	}
+}
+
 func TestSendMultiple(t *testing.T) {
 	genesisBytes, vm, s, _ := setupWithKeys(t)
 	defer func() {
@@ -0,0 +1,210 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// simulation is the result of verifying a transaction without issuing it
+type simulation struct {
+	// True if the credentials of the transaction were verified
+	signed bool
+	// UTXOs the transaction consumes, as far as they could be found
+	consumed []*avax.UTXO
+	// UTXOs the transaction produces, including the UTXOs it exports
+	produced []*avax.UTXO
+	// Amount of AVAX the transaction burns
+	fee uint64
+	// Reason the transaction is invalid, or nil if it is valid
+	err error
+}
+
+// parseSimulatedTx parses [txBytes], which may be a signed transaction or only
+// an unsigned transaction. An unsigned transaction is returned without
+// credentials.
+func (vm *VM) parseSimulatedTx(txBytes []byte) (*Tx, error) {
+	tx, err := vm.parsePrivateTx(txBytes)
+	if err == nil {
+		return tx, nil
+	}
+
+	var utx UnsignedTx
+	if _, unsignedErr := vm.codec.Unmarshal(txBytes, &utx); unsignedErr != nil {
+		return nil, fmt.Errorf("couldn't parse bytes as a signed transaction (%s) or as an unsigned transaction (%s)", err, unsignedErr)
+	}
+	tx = &Tx{UnsignedTx: utx}
+	return tx, vm.initPartialTx(tx)
+}
+
+// isFullySigned returns true if [tx] has a credential for each of its inputs
+// and none of the credentials is missing signatures
+func isFullySigned(tx *Tx) bool {
+	if len(tx.Creds) != tx.NumCredentials() {
+		return false
+	}
+	for _, credIntf := range tx.Creds {
+		var cred *secp256k1fx.Credential
+		switch c := credIntf.(type) {
+		case *secp256k1fx.Credential:
+			cred = c
+		case *nftfx.Credential:
+			cred = &c.Credential
+		case *propertyfx.Credential:
+			cred = &c.Credential
+		default:
+			continue
+		}
+		for _, sig := range cred.Sigs {
+			if sig == [crypto.SECP256K1RSigLen]byte{} {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// inputUTXOs returns the UTXOs that [tx] consumes, including UTXOs that are
+// produced by processing transactions and UTXOs imported from another chain
+func (vm *VM) inputUTXOs(tx *Tx) ([]*avax.UTXO, error) {
+	utxos := []*avax.UTXO(nil)
+	for _, utxoID := range tx.InputUTXOs() {
+		if utxoID.Symbolic() {
+			continue
+		}
+		utxo, err := vm.getUTXO(utxoID)
+		if err != nil {
+			return utxos, fmt.Errorf("problem fetching UTXO %s: %w", utxoID.InputID(), err)
+		}
+		utxos = append(utxos, utxo)
+	}
+
+	imported, err := vm.importedUTXOs(tx)
+	if err != nil {
+		return utxos, fmt.Errorf("%w: %s", errMissingImportedUTXO, err)
+	}
+	return append(utxos, imported...), nil
+}
+
+// outputUTXOs returns the UTXOs that [tx] produces, including the UTXOs it
+// exports to another chain
+func outputUTXOs(tx *Tx) []*avax.UTXO {
+	utxos := tx.UTXOs()
+	exportTx, ok := tx.UnsignedTx.(*ExportTx)
+	if !ok {
+		return utxos
+	}
+	txID := tx.ID()
+	for i, out := range exportTx.ExportedOuts {
+		utxos = append(utxos, &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        txID,
+				OutputIndex: uint32(len(exportTx.Outs) + i),
+			},
+			Asset: avax.Asset{ID: out.AssetID()},
+			Out:   out.Out,
+		})
+	}
+	return utxos
+}
+
+// simulateTx verifies [tx] against the current state without issuing it. The
+// credentials of [tx] are only verified if it is fully signed. Otherwise, only
+// the availability of the UTXOs it consumes is checked.
+func (vm *VM) simulateTx(tx *Tx) (*simulation, error) {
+	fee, err := vm.txFeePaid(tx)
+	if err != nil {
+		return nil, err
+	}
+	sim := &simulation{
+		signed:   isFullySigned(tx),
+		produced: outputUTXOs(tx),
+		fee:      fee,
+	}
+
+	// The consumed UTXOs are reported even if some of them are missing
+	sim.consumed, err = vm.inputUTXOs(tx)
+
+	if sim.signed {
+		sim.err = tx.SyntacticVerify(
+			vm.ctx,
+			vm.codec,
+			vm.ctx.AVAXAssetID,
+			vm.txFee,
+			vm.creationTxFee,
+			len(vm.fxs),
+		)
+		if sim.err == nil {
+			sim.err = tx.SemanticVerify(vm, tx.UnsignedTx)
+		}
+		return sim, nil
+	}
+
+	sim.err = tx.UnsignedTx.SyntacticVerify(
+		vm.ctx,
+		vm.codec,
+		vm.ctx.AVAXAssetID,
+		vm.txFee,
+		vm.creationTxFee,
+		len(vm.fxs),
+	)
+	switch {
+	case sim.err != nil:
+	case err != nil:
+		sim.err = err
+	default:
+		sim.err = vm.verifyUnsignedInputs(tx.UnsignedTx, sim.consumed)
+	}
+	return sim, nil
+}
+
+// verifyUnsignedInputs verifies that [utxos], the UTXOs [utx] consumes, can be
+// consumed by [utx], except for the credentials that authorize spending them
+func (vm *VM) verifyUnsignedInputs(utx UnsignedTx, utxos []*avax.UTXO) error {
+	utxosByID := make(map[ids.ID]*avax.UTXO, len(utxos))
+	for _, utxo := range utxos {
+		utxosByID[utxo.InputID()] = utxo
+	}
+	for _, in := range transferableInputs(utx) {
+		utxo, ok := utxosByID[in.InputID()]
+		if !ok {
+			return errMissingUTXO
+		}
+		if utxo.AssetID() != in.AssetID() {
+			return errAssetIDMismatch
+		}
+		if out, ok := utxo.Out.(avax.TransferableOut); ok && out.Amount() != in.In.Amount() {
+			return fmt.Errorf("input %s spends %d but the UTXO holds %d", in.InputID(), in.In.Amount(), out.Amount())
+		}
+	}
+	return nil
+}
+
+// transferableInputs returns the inputs of [utx], other than its operations,
+// including the inputs it imports from another chain
+func transferableInputs(utx UnsignedTx) []*avax.TransferableInput {
+	switch utx := utx.(type) {
+	case *BaseTx:
+		return utx.Ins
+	case *CreateAssetTx:
+		return utx.Ins
+	case *OperationTx:
+		return utx.Ins
+	case *ImportTx:
+		ins := make([]*avax.TransferableInput, 0, len(utx.Ins)+len(utx.ImportedIns))
+		ins = append(ins, utx.Ins...)
+		return append(ins, utx.ImportedIns...)
+	case *ExportTx:
+		return utx.Ins
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,130 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errNothingToMerge   = errors.New("there are no UTXOs to merge")
+	errMergeFeeTooLarge = errors.New("the AVAX being merged doesn't cover the transaction fee")
+)
+
+// mergeOrder returns the UTXOs of [spendable] in the order they are merged.
+// The AVAX UTXOs are first, so that the fee can be paid out of them, followed
+// by the UTXOs of the other assets, ordered by asset ID. The UTXOs of each
+// asset are sorted by amount.
+func (vm *VM) mergeOrder(spendable map[ids.ID][]*wallet.SpendableUTXO, descending bool) []*wallet.SpendableUTXO {
+	assetIDs := make([]ids.ID, 0, len(spendable))
+	for assetID := range spendable {
+		if assetID != vm.ctx.AVAXAssetID {
+			assetIDs = append(assetIDs, assetID)
+		}
+	}
+	ids.SortIDs(assetIDs)
+	if _, ok := spendable[vm.ctx.AVAXAssetID]; ok {
+		assetIDs = append([]ids.ID{vm.ctx.AVAXAssetID}, assetIDs...)
+	}
+
+	utxos := []*wallet.SpendableUTXO(nil)
+	for _, assetID := range assetIDs {
+		assetUTXOs := spendable[assetID]
+		wallet.SortByAmount(assetUTXOs, descending)
+		utxos = append(utxos, assetUTXOs...)
+	}
+	return utxos
+}
+
+// newMergeTx returns a transaction that spends [candidates], in order, until
+// no more fit in a transaction, and sends to [to] one output per asset that
+// holds everything that was spent of the asset, except for the transaction
+// fee. If none of [candidates] hold AVAX, the fee is paid with [utxos] and
+// [kc]. Otherwise, it's paid with the AVAX being merged. Returns the
+// transaction and the number of [candidates] that weren't spent.
+func (vm *VM) newMergeTx(
+	candidates []*wallet.SpendableUTXO,
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	to ids.ShortID,
+) (*Tx, int, error) {
+	if len(candidates) == 0 {
+		return nil, 0, errNothingToMerge
+	}
+
+	mergesAVAX := false
+	merging := ids.Set{}
+	for _, utxo := range candidates {
+		merging.Add(utxo.UTXO.InputID())
+		mergesAVAX = mergesAVAX || utxo.UTXO.AssetID() == vm.ctx.AVAXAssetID
+	}
+
+	amountsSpent := make(map[ids.ID]uint64)
+	ins := []*avax.TransferableInput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	size := 0
+	if !mergesAVAX {
+		feeUTXOs := make([]*avax.UTXO, 0, len(utxos))
+		for _, utxo := range utxos {
+			if !merging.Contains(utxo.InputID()) {
+				feeUTXOs = append(feeUTXOs, utxo)
+			}
+		}
+		feeSpent, feeIns, feeKeys, err := vm.txBuilder().Spend(feeUTXOs, kc, map[ids.ID]uint64{
+			vm.ctx.AVAXAssetID: vm.txFee,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("couldn't pay the fee: %w", err)
+		}
+		amountsSpent[vm.ctx.AVAXAssetID] = feeSpent[vm.ctx.AVAXAssetID]
+		ins = append(ins, feeIns...)
+		keys = append(keys, feeKeys...)
+		for _, signers := range feeKeys {
+			size += wallet.InputSize(len(signers))
+		}
+	}
+
+	numMerged := 0
+	for _, utxo := range candidates {
+		size += utxo.Size()
+		if size > wallet.SpendSizeLimit {
+			break
+		}
+		assetID := utxo.UTXO.AssetID()
+		newAmountSpent, err := safemath.Add64(amountsSpent[assetID], utxo.Amount())
+		if err != nil {
+			return nil, 0, errSpendOverflow
+		}
+		amountsSpent[assetID] = newAmountSpent
+		ins = append(ins, utxo.Input())
+		keys = append(keys, utxo.Signers)
+		numMerged++
+	}
+	if amountsSpent[vm.ctx.AVAXAssetID] < vm.txFee {
+		return nil, 0, errMergeFeeTooLarge
+	}
+	avax.SortTransferableInputsWithSigners(ins, keys)
+
+	outs := wallet.ChangeOutputs(amountsSpent, map[ids.ID]uint64{
+		vm.ctx.AVAXAssetID: vm.txFee,
+	}, to)
+	avax.SortTransferableOutputs(outs, vm.codec)
+
+	tx := &Tx{UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    vm.ctx.NetworkID,
+		BlockchainID: vm.ctx.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+	}}}
+	return tx, len(candidates) - numMerged, tx.SignSECP256K1Fx(vm.codec, keys)
+}
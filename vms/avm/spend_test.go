@@ -0,0 +1,94 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSpendableUTXOs(assetID ids.ID, amounts ...uint64) []*wallet.SpendableUTXO {
+	utxos := make([]*wallet.SpendableUTXO, len(amounts))
+	for i, amount := range amounts {
+		utxos[i] = &wallet.SpendableUTXO{
+			UTXO: &avax.UTXO{
+				UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+				Asset:  avax.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+					},
+				},
+			},
+			In: &secp256k1fx.TransferInput{
+				Amt:   amount,
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		}
+	}
+	return utxos
+}
+
+func TestNewMergeTx(t *testing.T) {
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	assetID := ids.GenerateTestID()
+	avaxUTXOs := testSpendableUTXOs(ctx.AVAXAssetID, 3000, 1000, 2000)
+	assetUTXOs := testSpendableUTXOs(assetID, 7, 3)
+	utxos := []*avax.UTXO(nil)
+	for _, utxo := range append(avaxUTXOs, assetUTXOs...) {
+		utxos = append(utxos, utxo.UTXO)
+	}
+	kc := secp256k1fx.NewKeychain()
+	kc.Add(keys[0])
+
+	spendable := wallet.KeychainSpendableUTXOs(utxos, kc, vm.clock.Unix())
+	candidates := vm.mergeOrder(spendable, false)
+	if assert.Len(t, candidates, 5) {
+		// The AVAX is merged first, smallest first
+		assert.Equal(t, uint64(1000), candidates[0].Amount())
+		assert.Equal(t, uint64(3), candidates[3].Amount())
+	}
+
+	to := ids.GenerateTestShortID()
+	tx, remaining, err := vm.newMergeTx(candidates, utxos, kc, to)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, remaining)
+
+	baseTx := tx.UnsignedTx.(*BaseTx)
+	assert.Len(t, baseTx.Ins, 5)
+	amounts := make(map[ids.ID]uint64)
+	for _, out := range baseTx.Outs {
+		amounts[out.AssetID()] += out.Out.Amount()
+		assert.Equal(t, []ids.ShortID{to}, out.Out.(*secp256k1fx.TransferOutput).Addrs)
+	}
+	assert.Equal(t, map[ids.ID]uint64{
+		ctx.AVAXAssetID: 6000 - vm.txFee,
+		assetID:         10,
+	}, amounts)
+
+	// Merging only the other asset pays the fee with the AVAX UTXOs
+	tx, _, err = vm.newMergeTx(vm.mergeOrder(map[ids.ID][]*wallet.SpendableUTXO{
+		assetID: spendable[assetID],
+	}, false), utxos, kc, to)
+	assert.NoError(t, err)
+	assert.Len(t, tx.UnsignedTx.(*BaseTx).Ins, 3)
+
+	_, _, err = vm.newMergeTx(nil, utxos, kc, to)
+	assert.Equal(t, errNothingToMerge, err)
+}
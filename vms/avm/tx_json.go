@@ -0,0 +1,178 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+var errUnknownTxType = errors.New("unknown transaction type")
+
+// JSONTx is the JSON representation of a transaction, with its inputs, outputs,
+// operations and credentials decoded and its addresses formatted
+type JSONTx struct {
+	ID ids.ID `json:"id"`
+	// Type of the unsigned transaction, such as "avm.BaseTx"
+	Type        string                `json:"type"`
+	UnsignedTx  interface{}           `json:"unsignedTx"`
+	Credentials []avax.JSONCredential `json:"credentials"`
+}
+
+// JSONCreateAssetTx is the JSON representation of CreateAssetTx
+type JSONCreateAssetTx struct {
+	*avax.JSONBaseTx
+	Name          string             `json:"name"`
+	Symbol        string             `json:"symbol"`
+	Denomination  byte               `json:"denomination"`
+	InitialStates []JSONInitialState `json:"initialStates"`
+}
+
+// JSONInitialState is the JSON representation of InitialState
+type JSONInitialState struct {
+	FxID    uint32            `json:"fxID"`
+	Outputs []avax.JSONOutput `json:"outputs"`
+}
+
+// JSONOperationTx is the JSON representation of OperationTx
+type JSONOperationTx struct {
+	*avax.JSONBaseTx
+	Operations []JSONOperation `json:"operations"`
+}
+
+// JSONOperation is the JSON representation of Operation
+type JSONOperation struct {
+	AssetID  ids.ID         `json:"assetID"`
+	InputIDs []*avax.UTXOID `json:"inputIDs"`
+	// Type of the operation, such as "secp256k1fx.MintOperation"
+	Type      string      `json:"type"`
+	Operation interface{} `json:"operation"`
+}
+
+// JSONImportTx is the JSON representation of ImportTx
+type JSONImportTx struct {
+	*avax.JSONBaseTx
+	SourceChain    ids.ID                        `json:"sourceChain"`
+	ImportedInputs []*avax.JSONTransferableInput `json:"importedInputs"`
+}
+
+// JSONExportTx is the JSON representation of ExportTx
+type JSONExportTx struct {
+	*avax.JSONBaseTx
+	DestinationChain ids.ID                         `json:"destinationChain"`
+	ExportedOutputs  []*avax.JSONTransferableOutput `json:"exportedOutputs"`
+}
+
+// txJSON returns the JSON representation of [tx], with the addresses formatted
+// as addresses of this chain
+func (vm *VM) txJSON(tx *Tx) (*JSONTx, error) {
+	typeName, utxJSON, err := vm.unsignedTxJSON(tx.UnsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	creds := make([]avax.JSONCredential, len(tx.Creds))
+	for i, cred := range tx.Creds {
+		creds[i], err = avax.CredentialJSON(cred, vm.FormatLocalAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &JSONTx{
+		ID:          tx.ID(),
+		Type:        typeName,
+		UnsignedTx:  utxJSON,
+		Credentials: creds,
+	}, nil
+}
+
+// unsignedTxJSON returns the name of the type of [utx] and its JSON
+// representation
+func (vm *VM) unsignedTxJSON(utx UnsignedTx) (string, interface{}, error) {
+	formatAddr := vm.FormatLocalAddress
+	switch utx := utx.(type) {
+	case *BaseTx:
+		baseTx, err := utx.BaseTx.JSON(formatAddr)
+		return "avm.BaseTx", baseTx, err
+	case *CreateAssetTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		states := make([]JSONInitialState, len(utx.States))
+		for i, state := range utx.States {
+			outs := make([]avax.JSONOutput, len(state.Outs))
+			for j, out := range state.Outs {
+				outs[j], err = avax.OutputJSON(out, formatAddr)
+				if err != nil {
+					return "", nil, err
+				}
+			}
+			states[i] = JSONInitialState{
+				FxID:    state.FxID,
+				Outputs: outs,
+			}
+		}
+		return "avm.CreateAssetTx", &JSONCreateAssetTx{
+			JSONBaseTx:    baseTx,
+			Name:          utx.Name,
+			Symbol:        utx.Symbol,
+			Denomination:  utx.Denomination,
+			InitialStates: states,
+		}, nil
+	case *OperationTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		ops := make([]JSONOperation, len(utx.Ops))
+		for i, op := range utx.Ops {
+			typeName, opJSON, err := avax.FxJSON(op.Op, formatAddr)
+			if err != nil {
+				return "", nil, err
+			}
+			ops[i] = JSONOperation{
+				AssetID:   op.AssetID(),
+				InputIDs:  op.UTXOIDs,
+				Type:      typeName,
+				Operation: opJSON,
+			}
+		}
+		return "avm.OperationTx", &JSONOperationTx{
+			JSONBaseTx: baseTx,
+			Operations: ops,
+		}, nil
+	case *ImportTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		ins, err := avax.InputsJSON(utx.ImportedIns, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "avm.ImportTx", &JSONImportTx{
+			JSONBaseTx:     baseTx,
+			SourceChain:    utx.SourceChain,
+			ImportedInputs: ins,
+		}, nil
+	case *ExportTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		outs, err := avax.OutputsJSON(utx.ExportedOuts, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "avm.ExportTx", &JSONExportTx{
+			JSONBaseTx:       baseTx,
+			DestinationChain: utx.DestinationChain,
+			ExportedOutputs:  outs,
+		}, nil
+	default:
+		return "", nil, errUnknownTxType
+	}
+}
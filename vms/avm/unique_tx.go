@@ -117,6 +117,38 @@ func (tx *UniqueTx) Accept() error {
 
 	defer tx.vm.db.Abort()
 
+	// The spent UTXOs must be looked up before they are removed
+	var addrs [][]byte
+	if tx.vm.pubsub.Filtered() {
+		addrs = tx.vm.txAddresses(tx.Tx)
+	}
+
+	if tx.vm.addressTxs != nil || tx.vm.assets != nil {
+		spent, err := tx.vm.spentUTXOs(tx.Tx)
+		if err != nil {
+			tx.vm.ctx.Log.Error("Failed to fetch the utxos consumed by %s due to %s", tx.txID, err)
+			return err
+		}
+		if tx.vm.addressTxs != nil {
+			imported, err := tx.vm.importedUTXOs(tx.Tx)
+			if err != nil {
+				tx.vm.ctx.Log.Error("Failed to fetch the utxos imported by %s due to %s", tx.txID, err)
+				return err
+			}
+			consumed := append(append([]*avax.UTXO(nil), spent...), imported...)
+			if err := tx.vm.indexTxAddresses(tx.Tx, consumed); err != nil {
+				tx.vm.ctx.Log.Error("Failed to index the addresses of %s due to %s", tx.txID, err)
+				return err
+			}
+		}
+		if tx.vm.assets != nil {
+			if err := tx.vm.indexTxAssets(tx.Tx, spent); err != nil {
+				tx.vm.ctx.Log.Error("Failed to index the assets of %s due to %s", tx.txID, err)
+				return err
+			}
+		}
+	}
+
 	// Remove spent utxos
 	for _, utxo := range tx.InputUTXOs() {
 		if utxo.Symbolic() {
@@ -157,7 +189,11 @@ func (tx *UniqueTx) Accept() error {
 
 	tx.vm.ctx.Log.Verbo("Accepted Tx: %s", txID)
 
+	tx.vm.untrackTx(txID)
 	tx.vm.pubsub.Publish("accepted", txID)
+	if addrs != nil {
+		tx.vm.publishTx("accepted", tx.Tx, addrs)
+	}
 	tx.vm.walletService.decided(txID)
 
 	tx.deps = nil // Needed to prevent a memory leak
@@ -182,7 +218,11 @@ func (tx *UniqueTx) Reject() error {
 		return err
 	}
 
+	tx.vm.untrackTx(txID)
 	tx.vm.pubsub.Publish("rejected", txID)
+	if tx.vm.pubsub.Filtered() {
+		tx.vm.publishTx("rejected", tx.Tx, tx.vm.txAddresses(tx.Tx))
+	}
 	tx.vm.walletService.decided(txID)
 
 	tx.deps = nil // Needed to prevent a memory leak
@@ -289,11 +329,17 @@ func (tx *UniqueTx) verifyWithoutCacheWrites() error {
 // Verify the validity of this transaction
 func (tx *UniqueTx) Verify() error {
 	if err := tx.verifyWithoutCacheWrites(); err != nil {
+		// The transaction won't be issued, so it will never be decided
+		tx.vm.untrackTx(tx.ID())
 		return err
 	}
 
 	tx.verifiedState = true
+	tx.vm.trackTx(tx)
 	tx.vm.pubsub.Publish("verified", tx.ID())
+	if tx.vm.pubsub.Filtered() {
+		tx.vm.publishTx("verified", tx.Tx, tx.vm.txAddresses(tx.Tx))
+	}
 	return nil
 }
 
@@ -26,18 +26,16 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/codec"
 	"github.com/ava-labs/avalanchego/utils/constants"
-	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
-	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
-	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 const (
@@ -48,6 +46,7 @@ const (
 	txCacheSize     = 10000
 	utxoCacheSize   = 10000
 	maxUTXOsToFetch = 1024
+	maxPageSize     = 1024
 )
 
 var (
@@ -56,9 +55,9 @@ var (
 	errGenesisAssetMustHaveState = errors.New("genesis asset must have non-empty state")
 	errWrongBlockchainID         = errors.New("wrong blockchain ID")
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
-	errInsufficientFunds         = errors.New("insufficient funds")
 
-	_ vertex.DAGVM = &VM{}
+	_ vertex.DAGVM           = &VM{}
+	_ vertex.ConflictAwareVM = &VM{}
 )
 
 // VM implements the avalanche.DAGVM interface
@@ -89,6 +88,18 @@ type VM struct {
 	// fee that must be burned by every non-state creating transaction
 	txFee uint64
 
+	// If true, [addressTxs] is maintained as transactions are accepted
+	indexAddressTxs bool
+	// Address, asset ID --> IDs of the accepted transactions that touched them.
+	// Nil if the index is disabled.
+	addressTxs *addressTxIndex
+
+	// If true, [assets] is maintained as transactions are accepted
+	indexAssets bool
+	// Asset ID --> supply, holders and mint outputs of the asset. Nil if the
+	// index is disabled.
+	assets *assetIndex
+
 	// Asset ID --> Bit set with fx IDs the asset supports
 	assetToFxCache *cache.LRU
 
@@ -98,6 +109,13 @@ type VM struct {
 	txs          []snowstorm.Tx
 	toEngine     chan<- common.Message
 
+	// Transactions that were issued, either locally or by consensus, and
+	// haven't been decided yet
+	processing map[ids.ID]*UniqueTx
+
+	// Reports the conflicts between the transactions consensus is deciding
+	conflicts vertex.ConflictGraph
+
 	baseDB database.Database
 	db     *versiondb.Database
 
@@ -130,6 +148,7 @@ func (vm *VM) Initialize(
 	vm.assetToFxCache = &cache.LRU{Size: assetToFxCacheSize}
 
 	vm.pubsub = cjson.NewPubSubServer(ctx)
+	vm.pubsub.EnableFilters(vm.parseFilterAddress)
 
 	genesisCodec := codec.New(codec.DefaultTagName, 1<<20)
 	c := codec.NewDefault()
@@ -145,18 +164,10 @@ func (vm *VM) Initialize(
 		vm.pubsub.Register("rejected"),
 		vm.pubsub.Register("verified"),
 
-		c.RegisterType(&BaseTx{}),
-		c.RegisterType(&CreateAssetTx{}),
-		c.RegisterType(&OperationTx{}),
-		c.RegisterType(&ImportTx{}),
-		c.RegisterType(&ExportTx{}),
+		registerTxTypes(c),
 		vm.codec.RegisterCodec(codecVersion, c),
 
-		genesisCodec.RegisterType(&BaseTx{}),
-		genesisCodec.RegisterType(&CreateAssetTx{}),
-		genesisCodec.RegisterType(&OperationTx{}),
-		genesisCodec.RegisterType(&ImportTx{}),
-		genesisCodec.RegisterType(&ExportTx{}),
+		registerTxTypes(genesisCodec),
 		vm.genesisCodec.RegisterCodec(codecVersion, genesisCodec),
 	)
 	if errs.Errored() {
@@ -214,6 +225,13 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	if err := vm.initAddressIndex(); err != nil {
+		return err
+	}
+	if err := vm.initAssetIndex(); err != nil {
+		return err
+	}
+
 	vm.timer = timer.NewTimer(func() {
 		ctx.Lock.Lock()
 		defer ctx.Lock.Unlock()
@@ -223,6 +241,7 @@ func (vm *VM) Initialize(
 	go ctx.Log.RecoverAndPanic(vm.timer.Dispatch)
 	vm.batchTimeout = batchTimeout
 
+	vm.processing = make(map[ids.ID]*UniqueTx)
 	vm.walletService.vm = vm
 	vm.walletService.pendingTxMap = make(map[ids.ID]*list.Element)
 	vm.walletService.pendingTxOrdering = list.New()
@@ -322,6 +341,11 @@ func (vm *VM) PendingTxs() []snowstorm.Tx {
 	return txs
 }
 
+// SetConflictGraph implements the vertex.ConflictAwareVM interface
+func (vm *VM) SetConflictGraph(graph vertex.ConflictGraph) {
+	vm.conflicts = graph
+}
+
 // ParseTx implements the avalanche.DAGVM interface
 func (vm *VM) ParseTx(b []byte) (snowstorm.Tx, error) {
 	vm.metrics.numParseTxCalls.Inc()
@@ -363,6 +387,7 @@ func (vm *VM) IssueTx(b []byte) (ids.ID, error) {
 	if err := tx.verifyWithoutCacheWrites(); err != nil {
 		return ids.ID{}, err
 	}
+	vm.trackTx(tx)
 	vm.issueTx(tx)
 	return tx.ID(), nil
 }
@@ -570,6 +595,21 @@ func (vm *VM) getAllUniqueAddressUTXOs(addr ids.ShortID, seen *ids.Set, utxos *[
 // Clock returns a reference to the internal clock of this VM
 func (vm *VM) Clock() *timer.Clock { return &vm.clock }
 
+// txBuilder returns a Builder that builds transactions for this chain
+func (vm *VM) txBuilder() *Builder {
+	return &Builder{
+		Builder: wallet.Builder{
+			AVAXAssetID: vm.ctx.AVAXAssetID,
+			Codec:       vm.codec,
+			Clock:       &vm.clock,
+		},
+		NetworkID:        vm.ctx.NetworkID,
+		ChainID:          vm.ctx.ChainID,
+		TxFee:            vm.txFee,
+		CreateAssetTxFee: vm.creationTxFee,
+	}
+}
+
 // Codec returns a reference to the internal codec of this VM
 func (vm *VM) Codec() codec.Manager { return vm.codec }
 
@@ -667,6 +707,44 @@ func (vm *VM) initState(genesisBytes []byte) error {
 	return vm.state.SetDBInitialized(choices.Processing)
 }
 
+// initAddressIndex builds the address index if it was enabled after
+// transactions were accepted. If the index is disabled, it is marked as stale
+// so that it is rebuilt if it is enabled again.
+func (vm *VM) initAddressIndex() error {
+	index := &addressTxIndex{
+		db: prefixdb.NewNested([]byte("addressTxs"), vm.db),
+	}
+	if !vm.indexAddressTxs {
+		return index.SetBuilt(false)
+	}
+
+	vm.addressTxs = index
+	built, err := index.Built()
+	if err != nil || built {
+		return err
+	}
+	return vm.buildAddressIndex()
+}
+
+// initAssetIndex builds the asset index if it was enabled after transactions
+// were accepted. If the index is disabled, it is marked as stale so that it is
+// rebuilt if it is enabled again.
+func (vm *VM) initAssetIndex() error {
+	index := &assetIndex{
+		db: prefixdb.NewNested([]byte("assets"), vm.db),
+	}
+	if !vm.indexAssets {
+		return index.SetBuilt(false)
+	}
+
+	vm.assets = index
+	built, err := index.Built()
+	if err != nil || built {
+		return err
+	}
+	return vm.buildAssetIndex()
+}
+
 func (vm *VM) parseTx(bytes []byte) (*UniqueTx, error) {
 	rawTx, err := vm.parsePrivateTx(bytes)
 	if err != nil {
@@ -849,7 +927,8 @@ func (vm *VM) verifyOperation(tx UnsignedTx, op *Operation, cred verify.Verifiab
 // 1) The UTXOs that reference one or more addresses controlled by the given user
 // 2) A keychain that contains this user's keys
 // If [addrsToUse] has positive length, returns UTXOs that reference one or more
-// addresses controlled by the given user that are also in [addrsToUse].
+// addresses controlled by the given user that are also in [addrsToUse]. The
+// keys of [addrsToUse] are first in the keychain.
 func (vm *VM) LoadUser(
 	username string,
 	password string,
@@ -879,345 +958,20 @@ func (vm *VM) LoadUser(
 		return nil, nil, fmt.Errorf("problem retrieving user's UTXOs: %w", err)
 	}
 
-	return utxos, kc, db.Close()
-}
-
-// Spend ...
-func (vm *VM) Spend(
-	utxos []*avax.UTXO,
-	kc *secp256k1fx.Keychain,
-	amounts map[ids.ID]uint64,
-) (
-	map[ids.ID]uint64,
-	[]*avax.TransferableInput,
-	[][]*crypto.PrivateKeySECP256K1R,
-	error,
-) {
-	amountsSpent := make(map[ids.ID]uint64, len(amounts))
-	time := vm.clock.Unix()
-
-	ins := []*avax.TransferableInput{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-	for _, utxo := range utxos {
-		assetID := utxo.AssetID()
-		amount := amounts[assetID]
-		amountSpent := amountsSpent[assetID]
-
-		if amountSpent >= amount {
-			// we already have enough inputs allocated to this asset
-			continue
-		}
-
-		inputIntf, signers, err := kc.Spend(utxo.Out, time)
+	// The user's other keys aren't used to select UTXOs, but they are added
+	// after the keys of [addrsToUse] so that multisig UTXOs can be co-signed
+	// by every key the user holds.
+	if addrsToUse.Len() != 0 {
+		allKC, err := user.Keychain(db, nil)
 		if err != nil {
-			// this utxo can't be spent with the current keys right now
-			continue
-		}
-		input, ok := inputIntf.(avax.TransferableIn)
-		if !ok {
-			// this input doesn't have an amount, so I don't care about it here
-			continue
-		}
-		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
-		if err != nil {
-			// there was an error calculating the consumed amount, just error
-			return nil, nil, nil, errSpendOverflow
-		}
-		amountsSpent[assetID] = newAmountSpent
-
-		// add the new input to the array
-		ins = append(ins, &avax.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  avax.Asset{ID: assetID},
-			In:     input,
-		})
-		// add the required keys to the array
-		keys = append(keys, signers)
-	}
-
-	for asset, amount := range amounts {
-		if amountsSpent[asset] < amount {
-			return nil, nil, nil, fmt.Errorf("want to spend %d of asset %s but only have %d",
-				amount,
-				asset,
-				amountsSpent[asset],
-			)
-		}
-	}
-
-	avax.SortTransferableInputsWithSigners(ins, keys)
-	return amountsSpent, ins, keys, nil
-}
-
-// SpendNFT ...
-func (vm *VM) SpendNFT(
-	utxos []*avax.UTXO,
-	kc *secp256k1fx.Keychain,
-	assetID ids.ID,
-	groupID uint32,
-	to ids.ShortID,
-) (
-	[]*Operation,
-	[][]*crypto.PrivateKeySECP256K1R,
-	error,
-) {
-	time := vm.clock.Unix()
-
-	ops := []*Operation{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-
-	for _, utxo := range utxos {
-		// makes sure that the variable isn't overwritten with the next iteration
-		utxo := utxo
-
-		if len(ops) > 0 {
-			// we have already been able to create the operation needed
-			break
-		}
-
-		if utxo.AssetID() != assetID {
-			// wrong asset ID
-			continue
-		}
-		out, ok := utxo.Out.(*nftfx.TransferOutput)
-		if !ok {
-			// wrong output type
-			continue
-		}
-		if out.GroupID != groupID {
-			// wrong group id
-			continue
+			return nil, nil, err
 		}
-		indices, signers, ok := kc.Match(&out.OutputOwners, time)
-		if !ok {
-			// unable to spend the output
-			continue
+		for _, key := range allKC.Keys {
+			kc.Add(key)
 		}
-
-		// add the new operation to the array
-		ops = append(ops, &Operation{
-			Asset:   utxo.Asset,
-			UTXOIDs: []*avax.UTXOID{&utxo.UTXOID},
-			Op: &nftfx.TransferOperation{
-				Input: secp256k1fx.Input{
-					SigIndices: indices,
-				},
-				Output: nftfx.TransferOutput{
-					GroupID: out.GroupID,
-					Payload: out.Payload,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Threshold: 1,
-						Addrs:     []ids.ShortID{to},
-					},
-				},
-			},
-		})
-		// add the required keys to the array
-		keys = append(keys, signers)
 	}
 
-	if len(ops) == 0 {
-		return nil, nil, errInsufficientFunds
-	}
-
-	sortOperationsWithSigners(ops, keys, vm.codec)
-	return ops, keys, nil
-}
-
-// SpendAll ...
-func (vm *VM) SpendAll(
-	utxos []*avax.UTXO,
-	kc *secp256k1fx.Keychain,
-) (
-	map[ids.ID]uint64,
-	[]*avax.TransferableInput,
-	[][]*crypto.PrivateKeySECP256K1R,
-	error,
-) {
-	amountsSpent := make(map[ids.ID]uint64)
-	time := vm.clock.Unix()
-
-	ins := []*avax.TransferableInput{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-	for _, utxo := range utxos {
-		assetID := utxo.AssetID()
-		amountSpent := amountsSpent[assetID]
-
-		inputIntf, signers, err := kc.Spend(utxo.Out, time)
-		if err != nil {
-			// this utxo can't be spent with the current keys right now
-			continue
-		}
-		input, ok := inputIntf.(avax.TransferableIn)
-		if !ok {
-			// this input doesn't have an amount, so I don't care about it here
-			continue
-		}
-		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
-		if err != nil {
-			// there was an error calculating the consumed amount, just error
-			return nil, nil, nil, errSpendOverflow
-		}
-		amountsSpent[assetID] = newAmountSpent
-
-		// add the new input to the array
-		ins = append(ins, &avax.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  avax.Asset{ID: assetID},
-			In:     input,
-		})
-		// add the required keys to the array
-		keys = append(keys, signers)
-	}
-
-	avax.SortTransferableInputsWithSigners(ins, keys)
-	return amountsSpent, ins, keys, nil
-}
-
-// Mint ...
-func (vm *VM) Mint(
-	utxos []*avax.UTXO,
-	kc *secp256k1fx.Keychain,
-	amounts map[ids.ID]uint64,
-	to ids.ShortID,
-) (
-	[]*Operation,
-	[][]*crypto.PrivateKeySECP256K1R,
-	error,
-) {
-	time := vm.clock.Unix()
-
-	ops := []*Operation{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-
-	for _, utxo := range utxos {
-		// makes sure that the variable isn't overwritten with the next iteration
-		utxo := utxo
-
-		assetID := utxo.AssetID()
-		amount := amounts[assetID]
-		if amount == 0 {
-			continue
-		}
-
-		out, ok := utxo.Out.(*secp256k1fx.MintOutput)
-		if !ok {
-			continue
-		}
-
-		inIntf, signers, err := kc.Spend(out, time)
-		if err != nil {
-			continue
-		}
-
-		in, ok := inIntf.(*secp256k1fx.Input)
-		if !ok {
-			continue
-		}
-
-		// add the operation to the array
-		ops = append(ops, &Operation{
-			Asset:   utxo.Asset,
-			UTXOIDs: []*avax.UTXOID{&utxo.UTXOID},
-			Op: &secp256k1fx.MintOperation{
-				MintInput:  *in,
-				MintOutput: *out,
-				TransferOutput: secp256k1fx.TransferOutput{
-					Amt: amount,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Threshold: 1,
-						Addrs:     []ids.ShortID{to},
-					},
-				},
-			},
-		})
-		// add the required keys to the array
-		keys = append(keys, signers)
-
-		// remove the asset from the required amounts to mint
-		delete(amounts, assetID)
-	}
-
-	for _, amount := range amounts {
-		if amount > 0 {
-			return nil, nil, errAddressesCantMintAsset
-		}
-	}
-
-	sortOperationsWithSigners(ops, keys, vm.codec)
-	return ops, keys, nil
-}
-
-// MintNFT ...
-func (vm *VM) MintNFT(
-	utxos []*avax.UTXO,
-	kc *secp256k1fx.Keychain,
-	assetID ids.ID,
-	payload []byte,
-	to ids.ShortID,
-) (
-	[]*Operation,
-	[][]*crypto.PrivateKeySECP256K1R,
-	error,
-) {
-	time := vm.clock.Unix()
-
-	ops := []*Operation{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-
-	for _, utxo := range utxos {
-		// makes sure that the variable isn't overwritten with the next iteration
-		utxo := utxo
-
-		if len(ops) > 0 {
-			// we have already been able to create the operation needed
-			break
-		}
-
-		if utxo.AssetID() != assetID {
-			// wrong asset id
-			continue
-		}
-		out, ok := utxo.Out.(*nftfx.MintOutput)
-		if !ok {
-			// wrong output type
-			continue
-		}
-
-		indices, signers, ok := kc.Match(&out.OutputOwners, time)
-		if !ok {
-			// unable to spend the output
-			continue
-		}
-
-		// add the operation to the array
-		ops = append(ops, &Operation{
-			Asset: avax.Asset{ID: assetID},
-			UTXOIDs: []*avax.UTXOID{
-				&utxo.UTXOID,
-			},
-			Op: &nftfx.MintOperation{
-				MintInput: secp256k1fx.Input{
-					SigIndices: indices,
-				},
-				GroupID: out.GroupID,
-				Payload: payload,
-				Outputs: []*secp256k1fx.OutputOwners{{
-					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
-				}},
-			},
-		})
-		// add the required keys to the array
-		keys = append(keys, signers)
-	}
-
-	if len(ops) == 0 {
-		return nil, nil, errAddressesCantMintAsset
-	}
-
-	sortOperationsWithSigners(ops, keys, vm.codec)
-	return ops, keys, nil
+	return utxos, kc, db.Close()
 }
 
 // ParseLocalAddress takes in an address for this chain and produces the ID
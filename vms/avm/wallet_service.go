@@ -14,7 +14,6 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	"github.com/ava-labs/avalanchego/utils/formatting"
-	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 // WalletService ...
@@ -152,11 +151,9 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		return err
 	}
 
-	// Calculate required input amounts and create the desired outputs
+	// Create the desired outputs
 	// String repr. of asset ID --> asset ID
 	assetIDs := make(map[string]ids.ID)
-	// Asset ID --> amount of that asset being sent
-	amounts := make(map[ids.ID]uint64)
 	// Outputs of our tx
 	outs := []*avax.TransferableOutput{}
 	for _, output := range args.Outputs {
@@ -171,81 +168,28 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 			}
 			assetIDs[output.AssetID] = assetID
 		}
-		currentAmount := amounts[assetID]
-		newAmount, err := safemath.Add64(currentAmount, uint64(output.Amount))
-		if err != nil {
-			return fmt.Errorf("problem calculating required spend amount: %w", err)
-		}
-		amounts[assetID] = newAmount
-
 		// Parse the to address
 		to, err := w.vm.ParseLocalAddress(output.To)
 		if err != nil {
 			return fmt.Errorf("problem parsing to address %q: %w", output.To, err)
 		}
+		owners, err := outputOwners(to, output.OutputOwnersArgs, w.vm.ParseLocalAddress)
+		if err != nil {
+			return err
+		}
 
 		// Create the Output
 		outs = append(outs, &avax.TransferableOutput{
 			Asset: avax.Asset{ID: assetID},
 			Out: &secp256k1fx.TransferOutput{
-				Amt: uint64(output.Amount),
-				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
-					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
-				},
+				Amt:          uint64(output.Amount),
+				OutputOwners: owners,
 			},
 		})
 	}
 
-	amountsWithFee := make(map[ids.ID]uint64, len(amounts)+1)
-	for assetKey, amount := range amounts {
-		amountsWithFee[assetKey] = amount
-	}
-
-	amountWithFee, err := safemath.Add64(amounts[w.vm.ctx.AVAXAssetID], w.vm.txFee)
+	tx, err := w.vm.txBuilder().NewBaseTx(utxos, kc, outs, changeAddr, memoBytes)
 	if err != nil {
-		return fmt.Errorf("problem calculating required spend amount: %w", err)
-	}
-	amountsWithFee[w.vm.ctx.AVAXAssetID] = amountWithFee
-
-	amountsSpent, ins, keys, err := w.vm.Spend(
-		utxos,
-		kc,
-		amountsWithFee,
-	)
-	if err != nil {
-		return err
-	}
-
-	// Add the required change outputs
-	for assetID, amountWithFee := range amountsWithFee {
-		amountSpent := amountsSpent[assetID]
-
-		if amountSpent > amountWithFee {
-			outs = append(outs, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: assetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: amountSpent - amountWithFee,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{changeAddr},
-					},
-				},
-			})
-		}
-	}
-	avax.SortTransferableOutputs(outs, w.vm.codec)
-
-	tx := Tx{UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
-		NetworkID:    w.vm.ctx.NetworkID,
-		BlockchainID: w.vm.ctx.ChainID,
-		Outs:         outs,
-		Ins:          ins,
-		Memo:         memoBytes,
-	}}}
-	if err := tx.SignSECP256K1Fx(w.vm.codec, keys); err != nil {
 		return err
 	}
 
@@ -0,0 +1,189 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avax
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+// JSONer is implemented by values whose JSON representation contains
+// addresses, which are formatted by [formatAddr]
+type JSONer interface {
+	JSON(formatAddr func(ids.ShortID) (string, error)) (interface{}, error)
+}
+
+// FxJSON returns the name of the type of [value], such as
+// "secp256k1fx.TransferOutput", and the JSON representation of [value]. If
+// [value] doesn't implement JSONer, [value] is its own JSON representation.
+func FxJSON(value interface{}, formatAddr func(ids.ShortID) (string, error)) (string, interface{}, error) {
+	typeName := strings.TrimPrefix(fmt.Sprintf("%T", value), "*")
+	jsoner, ok := value.(JSONer)
+	if !ok {
+		return typeName, value, nil
+	}
+	valueJSON, err := jsoner.JSON(formatAddr)
+	return typeName, valueJSON, err
+}
+
+// JSONOutput is the JSON representation of an output of an fx
+type JSONOutput struct {
+	Type   string      `json:"type"`
+	Output interface{} `json:"output"`
+}
+
+// OutputJSON returns the JSON representation of [out], with the addresses
+// formatted by [formatAddr]
+func OutputJSON(out interface{}, formatAddr func(ids.ShortID) (string, error)) (JSONOutput, error) {
+	typeName, outJSON, err := FxJSON(out, formatAddr)
+	return JSONOutput{
+		Type:   typeName,
+		Output: outJSON,
+	}, err
+}
+
+// JSONTransferableOutput is the JSON representation of TransferableOutput
+type JSONTransferableOutput struct {
+	AssetID ids.ID `json:"assetID"`
+	JSONOutput
+}
+
+// JSON returns the JSON representation of [out], with the addresses formatted
+// by [formatAddr]
+func (out *TransferableOutput) JSON(formatAddr func(ids.ShortID) (string, error)) (*JSONTransferableOutput, error) {
+	outJSON, err := OutputJSON(out.Out, formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONTransferableOutput{
+		AssetID:    out.AssetID(),
+		JSONOutput: outJSON,
+	}, nil
+}
+
+// JSONInput is the JSON representation of an input of an fx
+type JSONInput struct {
+	Type  string      `json:"type"`
+	Input interface{} `json:"input"`
+}
+
+// InputJSON returns the JSON representation of [in], with the addresses
+// formatted by [formatAddr]
+func InputJSON(in interface{}, formatAddr func(ids.ShortID) (string, error)) (JSONInput, error) {
+	typeName, inJSON, err := FxJSON(in, formatAddr)
+	return JSONInput{
+		Type:  typeName,
+		Input: inJSON,
+	}, err
+}
+
+// JSONTransferableInput is the JSON representation of TransferableInput
+type JSONTransferableInput struct {
+	TxID        ids.ID `json:"txID"`
+	OutputIndex uint32 `json:"outputIndex"`
+	AssetID     ids.ID `json:"assetID"`
+	JSONInput
+}
+
+// JSON returns the JSON representation of [in], with the addresses formatted
+// by [formatAddr]
+func (in *TransferableInput) JSON(formatAddr func(ids.ShortID) (string, error)) (*JSONTransferableInput, error) {
+	inJSON, err := InputJSON(in.In, formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONTransferableInput{
+		TxID:        in.TxID,
+		OutputIndex: in.OutputIndex,
+		AssetID:     in.AssetID(),
+		JSONInput:   inJSON,
+	}, nil
+}
+
+// JSONCredential is the JSON representation of a credential of an fx
+type JSONCredential struct {
+	Type       string      `json:"type"`
+	Credential interface{} `json:"credential"`
+}
+
+// CredentialJSON returns the JSON representation of [cred], with the addresses
+// formatted by [formatAddr]
+func CredentialJSON(cred interface{}, formatAddr func(ids.ShortID) (string, error)) (JSONCredential, error) {
+	typeName, credJSON, err := FxJSON(cred, formatAddr)
+	return JSONCredential{
+		Type:       typeName,
+		Credential: credJSON,
+	}, err
+}
+
+// JSONBaseTx is the JSON representation of BaseTx. The memo is hex encoded. If
+// the memo is valid UTF-8, it's also given as text.
+type JSONBaseTx struct {
+	NetworkID    uint32                    `json:"networkID"`
+	BlockchainID ids.ID                    `json:"blockchainID"`
+	Outputs      []*JSONTransferableOutput `json:"outputs"`
+	Inputs       []*JSONTransferableInput  `json:"inputs"`
+	Memo         string                    `json:"memo"`
+	MemoText     string                    `json:"memoText,omitempty"`
+}
+
+// JSON returns the JSON representation of [t], with the addresses formatted by
+// [formatAddr]
+func (t *BaseTx) JSON(formatAddr func(ids.ShortID) (string, error)) (*JSONBaseTx, error) {
+	outs, err := OutputsJSON(t.Outs, formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	ins, err := InputsJSON(t.Ins, formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	memo, err := formatting.Encode(formatting.Hex, t.Memo)
+	if err != nil {
+		return nil, err
+	}
+	txJSON := &JSONBaseTx{
+		NetworkID:    t.NetworkID,
+		BlockchainID: t.BlockchainID,
+		Outputs:      outs,
+		Inputs:       ins,
+		Memo:         memo,
+	}
+	if utf8.Valid(t.Memo) {
+		txJSON.MemoText = string(t.Memo)
+	}
+	return txJSON, nil
+}
+
+// OutputsJSON returns the JSON representations of [outs], with the addresses
+// formatted by [formatAddr]
+func OutputsJSON(outs []*TransferableOutput, formatAddr func(ids.ShortID) (string, error)) ([]*JSONTransferableOutput, error) {
+	outsJSON := make([]*JSONTransferableOutput, len(outs))
+	for i, out := range outs {
+		outJSON, err := out.JSON(formatAddr)
+		if err != nil {
+			return nil, err
+		}
+		outsJSON[i] = outJSON
+	}
+	return outsJSON, nil
+}
+
+// InputsJSON returns the JSON representations of [ins], with the addresses
+// formatted by [formatAddr]
+func InputsJSON(ins []*TransferableInput, formatAddr func(ids.ShortID) (string, error)) ([]*JSONTransferableInput, error) {
+	insJSON := make([]*JSONTransferableInput, len(ins))
+	for i, in := range ins {
+		inJSON, err := in.JSON(formatAddr)
+		if err != nil {
+			return nil, err
+		}
+		insJSON[i] = inJSON
+	}
+	return insJSON, nil
+}
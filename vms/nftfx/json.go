@@ -0,0 +1,109 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nftfx
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// JSONMintOutput is the JSON representation of MintOutput
+type JSONMintOutput struct {
+	GroupID uint32 `json:"groupID"`
+	secp256k1fx.JSONOutputOwners
+}
+
+// JSON returns the JSON representation of [out], with the addresses formatted
+// by [formatAddr]
+func (out *MintOutput) JSON(formatAddr func(ids.ShortID) (string, error)) (interface{}, error) {
+	owners, err := out.FormatOwners(formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONMintOutput{
+		GroupID:          out.GroupID,
+		JSONOutputOwners: owners,
+	}, nil
+}
+
+// JSONTransferOutput is the JSON representation of TransferOutput. The payload
+// is hex encoded.
+type JSONTransferOutput struct {
+	GroupID uint32 `json:"groupID"`
+	Payload string `json:"payload"`
+	secp256k1fx.JSONOutputOwners
+}
+
+// JSON returns the JSON representation of [out], with the addresses formatted
+// by [formatAddr]
+func (out *TransferOutput) JSON(formatAddr func(ids.ShortID) (string, error)) (interface{}, error) {
+	return out.transferOutputJSON(formatAddr)
+}
+
+func (out *TransferOutput) transferOutputJSON(formatAddr func(ids.ShortID) (string, error)) (*JSONTransferOutput, error) {
+	owners, err := out.FormatOwners(formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := formatting.Encode(formatting.Hex, out.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONTransferOutput{
+		GroupID:          out.GroupID,
+		Payload:          payload,
+		JSONOutputOwners: owners,
+	}, nil
+}
+
+// JSONMintOperation is the JSON representation of MintOperation. The payload is
+// hex encoded.
+type JSONMintOperation struct {
+	MintInput secp256k1fx.Input              `json:"mintInput"`
+	GroupID   uint32                         `json:"groupID"`
+	Payload   string                         `json:"payload"`
+	Outputs   []secp256k1fx.JSONOutputOwners `json:"outputs"`
+}
+
+// JSON returns the JSON representation of [op], with the addresses formatted
+// by [formatAddr]
+func (op *MintOperation) JSON(formatAddr func(ids.ShortID) (string, error)) (interface{}, error) {
+	payload, err := formatting.Encode(formatting.Hex, op.Payload)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]secp256k1fx.JSONOutputOwners, len(op.Outputs))
+	for i, out := range op.Outputs {
+		outputs[i], err = out.FormatOwners(formatAddr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &JSONMintOperation{
+		MintInput: op.MintInput,
+		GroupID:   op.GroupID,
+		Payload:   payload,
+		Outputs:   outputs,
+	}, nil
+}
+
+// JSONTransferOperation is the JSON representation of TransferOperation
+type JSONTransferOperation struct {
+	Input  secp256k1fx.Input   `json:"input"`
+	Output *JSONTransferOutput `json:"output"`
+}
+
+// JSON returns the JSON representation of [op], with the addresses formatted
+// by [formatAddr]
+func (op *TransferOperation) JSON(formatAddr func(ids.ShortID) (string, error)) (interface{}, error) {
+	output, err := op.Output.transferOutputJSON(formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONTransferOperation{
+		Input:  op.Input,
+		Output: output,
+	}, nil
+}
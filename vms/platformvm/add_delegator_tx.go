@@ -17,7 +17,6 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
-	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
@@ -26,7 +25,6 @@ var (
 	errDelegatorSubset = errors.New("delegator's time range must be a subset of the validator's time range")
 	errInvalidState    = errors.New("generated output isn't valid state")
 	errInvalidAmount   = errors.New("invalid amount")
-	errCapWeightBroken = errors.New("validator would surpass maximum weight")
 	errOverDelegated   = errors.New("validator would be over delegated")
 
 	_ UnsignedProposalTx = &UnsignedAddDelegatorTx{}
@@ -154,51 +152,14 @@ func (tx *UnsignedAddDelegatorTx) SemanticVerify(
 	}
 
 	// Ensure that the period this delegator delegates is a subset of the time
-	// the validator validates.
-	vdr, isValidator, err := vm.isValidator(db, constants.PrimaryNetworkID, tx.Validator.NodeID)
-	vdrWeight := uint64(0)
-	if err != nil {
-		return nil, nil, nil, nil, tempError{
-			fmt.Errorf("failed to find whether %s is a validator: %w", tx.Validator.NodeID, err),
-		}
-	}
-	if isValidator && !tx.Validator.BoundedBy(vdr.StartTime(), vdr.EndTime()) {
-		return nil, nil, nil, nil, permError{errDelegatorSubset}
-	}
-	if !isValidator {
-		// Ensure that the period this delegator delegates is a subset of the
-		// time the validator will validates.
-		vdr, willBeValidator, err := vm.willBeValidator(db, constants.PrimaryNetworkID, tx.Validator.NodeID)
-		if err != nil {
-			return nil, nil, nil, nil, tempError{
-				fmt.Errorf("failed to find whether %s will be a validator: %w", tx.Validator.NodeID, err),
-			}
-		}
-		if !willBeValidator || !tx.Validator.BoundedBy(vdr.StartTime(), vdr.EndTime()) {
-			return nil, nil, nil, nil, permError{errDelegatorSubset}
-		}
-		vdrWeight = vdr.Weight()
-	} else {
-		vdrWeight = vdr.Weight()
-	}
-
-	maxWeight, err := vm.maxStakeAmount(db, constants.PrimaryNetworkID, tx.Validator.NodeID, tx.StartTime(), tx.EndTime())
-	if err != nil {
+	// the validator validates and that the validator can take the stake
+	capacity, _, err := vm.delegationCapacity(db, tx.Validator.NodeID, tx.StartTime(), tx.EndTime())
+	switch {
+	case errors.Is(err, errDelegatorSubset), errors.Is(err, errStakeOverflow):
+		return nil, nil, nil, nil, permError{err}
+	case err != nil:
 		return nil, nil, nil, nil, tempError{err}
-	}
-	newWeight, err := safemath.Add64(maxWeight, tx.Validator.Wght)
-	if err != nil {
-		return nil, nil, nil, nil, permError{errStakeOverflow}
-	}
-	if newWeight > vm.maxValidatorStake {
-		return nil, nil, nil, nil, permError{errCapWeightBroken}
-	}
-
-	delegationRestrict, err := safemath.Mul64(5, vdrWeight)
-	if err != nil {
-		return nil, nil, nil, nil, permError{errStakeOverflow}
-	}
-	if newWeight > delegationRestrict {
+	case tx.Validator.Wght > capacity:
 		return nil, nil, nil, nil, permError{errOverDelegated}
 	}
 
@@ -268,6 +229,53 @@ func (tx *UnsignedAddDelegatorTx) InitiallyPrefersCommit(vm *VM) bool {
 	return tx.StartTime().After(vm.clock.Time())
 }
 
+// delegationCapacity returns the maximum amount of nAVAX that can be delegated
+// to the primary network validator [nodeID] from [startTime] to [endTime],
+// along with the tx that added the validator. The total stake of a validator
+// can't surpass the maximum validator stake, nor 5 times the validator's own
+// stake.
+func (vm *VM) delegationCapacity(
+	db database.Database,
+	nodeID ids.ShortID,
+	startTime time.Time,
+	endTime time.Time,
+) (uint64, *UnsignedAddValidatorTx, error) {
+	vdr, isValidator, err := vm.isValidator(db, constants.PrimaryNetworkID, nodeID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to find whether %s is a validator: %w", nodeID, err)
+	}
+	if !isValidator {
+		vdr, isValidator, err = vm.willBeValidator(db, constants.PrimaryNetworkID, nodeID)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to find whether %s will be a validator: %w", nodeID, err)
+		}
+	}
+	if !isValidator || startTime.Before(vdr.StartTime()) || endTime.After(vdr.EndTime()) {
+		return 0, nil, errDelegatorSubset
+	}
+	vdrTx, ok := vdr.(*UnsignedAddValidatorTx)
+	if !ok {
+		return 0, nil, fmt.Errorf("expected validator tx to be *UnsignedAddValidatorTx but got %T", vdr)
+	}
+
+	maxWeight, err := vm.maxStakeAmount(db, constants.PrimaryNetworkID, nodeID, startTime, endTime)
+	if err != nil {
+		return 0, nil, err
+	}
+	delegationRestrict, err := safemath.Mul64(5, vdrTx.Weight())
+	if err != nil {
+		return 0, nil, errStakeOverflow
+	}
+	limit := vm.maxValidatorStake
+	if delegationRestrict < limit {
+		limit = delegationRestrict
+	}
+	if maxWeight >= limit {
+		return 0, vdrTx, nil
+	}
+	return limit - maxWeight, vdrTx, nil
+}
+
 // Creates a new transaction
 func (vm *VM) newAddDelegatorTx(
 	stakeAmt, // Amount the delegator stakes
@@ -278,36 +286,15 @@ func (vm *VM) newAddDelegatorTx(
 	keys []*crypto.PrivateKeySECP256K1R, // Keys providing the staked tokens
 	changeAddr ids.ShortID, // Address to send change to, if there is any
 ) (*Tx, error) {
-	ins, unlockedOuts, lockedOuts, signers, err := vm.stake(vm.DB, keys, stakeAmt, 0, changeAddr)
+	kc, utxos, err := vm.keychainUTXOs(vm.DB, keys)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
-	// Create the tx
-	utx := &UnsignedAddDelegatorTx{
-		BaseTx: BaseTx{BaseTx: avax.BaseTx{
-			NetworkID:    vm.Ctx.NetworkID,
-			BlockchainID: vm.Ctx.ChainID,
-			Ins:          ins,
-			Outs:         unlockedOuts,
-		}},
-		Validator: Validator{
-			NodeID: nodeID,
-			Start:  startTime,
-			End:    endTime,
-			Wght:   stakeAmt,
-		},
-		Stake: lockedOuts,
-		RewardsOwner: &secp256k1fx.OutputOwners{
-			Locktime:  0,
-			Threshold: 1,
-			Addrs:     []ids.ShortID{rewardAddress},
-		},
-	}
-	tx := &Tx{UnsignedTx: utx}
-	if err := tx.Sign(vm.codec, signers); err != nil {
+	tx, err := vm.txBuilder().NewAddDelegatorTx(utxos, kc, stakeAmt, startTime, endTime, nodeID, rewardAddress, changeAddr)
+	if err != nil {
 		return nil, err
 	}
-	return tx, utx.Verify(
+	return tx, tx.UnsignedTx.(*UnsignedAddDelegatorTx).Verify(
 		vm.Ctx,
 		vm.codec,
 		vm.minDelegatorStake,
@@ -15,7 +15,6 @@ import (
 	"github.com/ava-labs/avalanchego/utils/codec"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
-	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 )
 
@@ -170,12 +169,11 @@ func (tx *UnsignedAddSubnetValidatorTx) SemanticVerify(
 	baseTxCreds := stx.Creds[:baseTxCredsLen]
 	subnetCred := stx.Creds[baseTxCredsLen]
 
-	subnet, timedErr := vm.getSubnet(db, tx.Validator.Subnet)
+	owner, timedErr := vm.getSubnetOwner(db, tx.Validator.Subnet)
 	if timedErr != nil {
 		return nil, nil, nil, nil, timedErr
 	}
-	unsignedSubnet := subnet.UnsignedTx.(*UnsignedCreateSubnetTx)
-	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, unsignedSubnet.Owner); err != nil {
+	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, owner); err != nil {
 		return nil, nil, nil, nil, permError{err}
 	}
 
@@ -230,41 +228,19 @@ func (vm *VM) newAddSubnetValidatorTx(
 	keys []*crypto.PrivateKeySECP256K1R, // Keys to use for adding the validator
 	changeAddr ids.ShortID, // Address to send change to, if there is any
 ) (*Tx, error) {
-	ins, outs, _, signers, err := vm.stake(vm.DB, keys, 0, vm.txFee, changeAddr)
+	kc, utxos, err := vm.keychainUTXOs(vm.DB, keys)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
-
-	subnetAuth, subnetSigners, err := vm.authorize(vm.DB, subnetID, keys)
+	subnetOwner, err := vm.subnetOwner(vm.DB, subnetID)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
 	}
-	signers = append(signers, subnetSigners)
-
-	// Create the tx
-	utx := &UnsignedAddSubnetValidatorTx{
-		BaseTx: BaseTx{BaseTx: avax.BaseTx{
-			NetworkID:    vm.Ctx.NetworkID,
-			BlockchainID: vm.Ctx.ChainID,
-			Ins:          ins,
-			Outs:         outs,
-		}},
-		Validator: SubnetValidator{
-			Validator: Validator{
-				NodeID: nodeID,
-				Start:  startTime,
-				End:    endTime,
-				Wght:   weight,
-			},
-			Subnet: subnetID,
-		},
-		SubnetAuth: subnetAuth,
-	}
-	tx := &Tx{UnsignedTx: utx}
-	if err := tx.Sign(vm.codec, signers); err != nil {
+	tx, err := vm.txBuilder().NewAddSubnetValidatorTx(utxos, kc, weight, startTime, endTime, nodeID, subnetID, subnetOwner, changeAddr)
+	if err != nil {
 		return nil, err
 	}
-	return tx, utx.Verify(
+	return tx, tx.UnsignedTx.(*UnsignedAddSubnetValidatorTx).Verify(
 		vm.Ctx,
 		vm.codec,
 		vm.txFee,
@@ -292,11 +292,41 @@ func (vm *VM) newAddValidatorTx(
 	keys []*crypto.PrivateKeySECP256K1R, // Keys providing the staked tokens
 	changeAddr ids.ShortID, // Address to send change to, if there is any
 ) (*Tx, error) {
-	ins, unlockedOuts, lockedOuts, signers, err := vm.stake(vm.DB, keys, stakeAmt, 0, changeAddr)
+	kc, utxos, err := vm.keychainUTXOs(vm.DB, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	tx, err := vm.txBuilder().NewAddValidatorTx(utxos, kc, stakeAmt, startTime, endTime, nodeID, rewardAddress, shares, changeAddr)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.UnsignedTx.(*UnsignedAddValidatorTx).Verify(
+		vm.Ctx,
+		vm.codec,
+		vm.minValidatorStake,
+		vm.maxValidatorStake,
+		vm.minStakeDuration,
+		vm.maxStakeDuration,
+		vm.minDelegationFee,
+	)
+}
+
+// newUnsignedAddValidatorTx returns a new AddValidatorTx, staking funds owned
+// by [fromAddrs], that doesn't contain any signatures yet
+func (vm *VM) newUnsignedAddValidatorTx(
+	stakeAmt, // Amount the validator stakes
+	startTime, // Unix time they start validating
+	endTime uint64, // Unix time they stop validating
+	nodeID ids.ShortID, // ID of the node that validates
+	rewardAddress ids.ShortID, // Address to send reward to, if applicable
+	shares uint32, // 10,000 times percentage of reward taken from delegators
+	fromAddrs ids.ShortSet, // Addresses providing the staked tokens
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, unlockedOuts, lockedOuts, _, err := vm.stakeWithAddresses(vm.DB, fromAddrs, stakeAmt, 0, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
-	// Create the tx
 	utx := &UnsignedAddValidatorTx{
 		BaseTx: BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    vm.Ctx.NetworkID,
@@ -318,8 +348,8 @@ func (vm *VM) newAddValidatorTx(
 		},
 		Shares: shares,
 	}
-	tx := &Tx{UnsignedTx: utx}
-	if err := tx.Sign(vm.codec, signers); err != nil {
+	tx, err := vm.newUnsignedTx(utx)
+	if err != nil {
 		return nil, err
 	}
 	return tx, utx.Verify(
@@ -93,6 +93,9 @@ func (ab *AtomicBlock) Verify() error {
 		return fmt.Errorf("failed to put status of tx %s: %w", tx.ID(), err)
 	}
 
+	// The tx no longer needs to be put into a block
+	ab.vm.mempool.RemoveTxs(&ab.Tx)
+
 	ab.vm.currentBlocks[ab.ID()] = ab
 	ab.parentBlock().addChild(ab)
 	return nil
@@ -110,6 +113,9 @@ func (ab *AtomicBlock) Accept() error {
 	if err := ab.CommonBlock.Accept(); err != nil {
 		return fmt.Errorf("failed to accept CommonBlock of %s: %w", ab.ID(), err)
 	}
+	if err := ab.vm.putAcceptedBlockID(ab.onAcceptDB, ab.Height(), ab.ID()); err != nil {
+		return fmt.Errorf("failed to index block %s by height: %w", ab.ID(), err)
+	}
 
 	// Update the state of the chain in the database
 	if err := ab.onAcceptDB.Commit(); err != nil {
@@ -0,0 +1,149 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const blockHeightDBPrefix = "blockHeight"
+
+var errBlockNotAccepted = errors.New("block isn't accepted")
+
+// putAcceptedBlockID stores in [db] that [blkID] is the accepted block at
+// [height]
+func (vm *VM) putAcceptedBlockID(db database.Database, height uint64, blkID ids.ID) error {
+	heightDB := prefixdb.NewNested([]byte(blockHeightDBPrefix), db)
+	errs := wrappers.Errs{}
+	errs.Add(
+		heightDB.Put(heightKey(height), blkID[:]),
+		heightDB.Close(),
+	)
+	return errs.Err
+}
+
+// getAcceptedBlockID returns the ID of the accepted block at [height]
+func (vm *VM) getAcceptedBlockID(db database.Database, height uint64) (ids.ID, error) {
+	heightDB := prefixdb.NewNested([]byte(blockHeightDBPrefix), db)
+	defer heightDB.Close()
+
+	blkIDBytes, err := heightDB.Get(heightKey(height))
+	if err != nil {
+		return ids.ID{}, err
+	}
+	return ids.ToID(blkIDBytes)
+}
+
+// initBlockIndex indexes the accepted blocks by height. Nodes that ran a
+// version without the index have the blocks they accepted before upgrading
+// indexed by walking back from the last accepted block until an indexed block
+// is found.
+func (vm *VM) initBlockIndex() error {
+	blkID := vm.LastAccepted()
+	for {
+		blk, err := vm.getBlock(blkID)
+		if err != nil {
+			return fmt.Errorf("couldn't get accepted block %s: %w", blkID, err)
+		}
+		height := blk.Height()
+		if _, err := vm.getAcceptedBlockID(vm.DB, height); err == nil {
+			break
+		} else if err != database.ErrNotFound {
+			return err
+		}
+		if err := vm.putAcceptedBlockID(vm.DB, height, blkID); err != nil {
+			return err
+		}
+		if height == 0 {
+			break
+		}
+		blkID = blk.Parent()
+	}
+	return vm.DB.Commit()
+}
+
+// getAcceptedBlock returns the accepted block at [height]
+func (vm *VM) getAcceptedBlock(height uint64) (Block, error) {
+	lastAcceptedHeight, err := vm.GetCurrentHeight()
+	if err != nil {
+		return nil, err
+	}
+	if height > lastAcceptedHeight {
+		return nil, fmt.Errorf("%w: %d > %d", errFutureHeight, height, lastAcceptedHeight)
+	}
+	blkID, err := vm.getAcceptedBlockID(vm.DB, height)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the ID of the block at height %d: %w", height, err)
+	}
+	return vm.getBlock(blkID)
+}
+
+// JSONBlock is the JSON representation of a block, with its txs decoded
+type JSONBlock struct {
+	ID ids.ID `json:"id"`
+	// Type of the block: "proposal", "commit", "abort", "standard" or "atomic"
+	Type     string         `json:"type"`
+	ParentID ids.ID         `json:"parentID"`
+	Height   uint64         `json:"height"`
+	Status   choices.Status `json:"status"`
+	// Unix time the block proposes to advance the chain's timestamp to. Only
+	// set for proposal blocks that contain an AdvanceTimeTx.
+	Timestamp *uint64 `json:"timestamp,omitempty"`
+	// Txs contained in the block. Commit and abort blocks don't contain txs.
+	Txs []*JSONTx `json:"txs"`
+}
+
+// blockJSON returns the JSON representation of [blk]. Blocks that aren't
+// accepted or processing aren't reported.
+func (vm *VM) blockJSON(blk Block) (*JSONBlock, error) {
+	blkJSON := &JSONBlock{
+		ID:       blk.ID(),
+		ParentID: blk.Parent(),
+		Height:   blk.Height(),
+		Status:   blk.Status(),
+		Txs:      []*JSONTx{},
+	}
+	if blkJSON.Status == choices.Rejected || blkJSON.Status == choices.Unknown {
+		return nil, fmt.Errorf("%w: %s is %s", errBlockNotAccepted, blkJSON.ID, blkJSON.Status)
+	}
+
+	var txs []*Tx
+	switch blk := blk.(type) {
+	case *ProposalBlock:
+		blkJSON.Type = "proposal"
+		txs = []*Tx{&blk.Tx}
+		if utx, ok := blk.Tx.UnsignedTx.(*UnsignedAdvanceTimeTx); ok {
+			timestamp := utx.Time
+			blkJSON.Timestamp = &timestamp
+		}
+	case *Commit:
+		blkJSON.Type = "commit"
+	case *Abort:
+		blkJSON.Type = "abort"
+	case *StandardBlock:
+		blkJSON.Type = "standard"
+		txs = blk.Txs
+	case *AtomicBlock:
+		blkJSON.Type = "atomic"
+		txs = []*Tx{&blk.Tx}
+	default:
+		return nil, fmt.Errorf("%w: %T", errInvalidBlockType, blk)
+	}
+
+	for _, tx := range txs {
+		txJSON, err := vm.txJSON(tx)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode tx %s: %w", tx.ID(), err)
+		}
+		blkJSON.Txs = append(blkJSON.Txs, txJSON)
+	}
+	return blkJSON, nil
+}
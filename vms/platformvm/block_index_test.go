@@ -0,0 +1,166 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/snow/choices"
+)
+
+// Ensure accepted blocks are indexed by height and decoded with their txs
+func TestGetBlockByHeight(t *testing.T) {
+	service := defaultService(t)
+	service.vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+	vm := service.vm
+
+	// The genesis block and the block that created testSubnet1 are indexed
+	reply := GetBlockReply{}
+	if err := service.GetBlockByHeight(nil, &GetBlockByHeightArgs{Height: 0}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply.Block.Type != "commit" || len(reply.Block.Txs) != 0 {
+		t.Fatalf("expected the genesis block to be an empty commit block but got a %s block with %d txs", reply.Block.Type, len(reply.Block.Txs))
+	}
+	genesisID := reply.Block.ID
+	if err := service.GetBlockByHeight(nil, &GetBlockByHeightArgs{Height: 1}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case reply.Block.Type != "standard":
+		t.Fatalf("expected a standard block but got a %s block", reply.Block.Type)
+	case reply.Block.ParentID != genesisID:
+		t.Fatal("wrong parent ID")
+	case reply.Block.Status != choices.Accepted:
+		t.Fatalf("expected the block to be accepted but is %s", reply.Block.Status)
+	case len(reply.Block.Txs) != 1:
+		t.Fatalf("expected 1 tx but got %d", len(reply.Block.Txs))
+	case reply.Block.Txs[0].ID != testSubnet1.ID():
+		t.Fatal("expected the block to contain the tx that created testSubnet1")
+	}
+
+	if err := service.GetBlockByHeight(nil, &GetBlockByHeightArgs{Height: 2}, &reply); !errors.Is(err, errFutureHeight) {
+		t.Fatalf("expected %s but got %v", errFutureHeight, err)
+	}
+
+	// Propose advancing the chain's timestamp and commit it
+	newTimestamp := defaultGenesisTime.Add(time.Second)
+	advanceTimeTx, err := vm.newAdvanceTimeTx(newTimestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	preferredHeight, err := vm.preferredHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk, err := vm.newProposalBlock(vm.Preferred(), preferredHeight+1, *advanceTimeTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.clock.Set(newTimestamp)
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	options, err := blk.Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := options[0].(*Commit)
+	if err := blk.Accept(); err != nil {
+		t.Fatal(err)
+	} else if err := vm.SaveBlock(blk); err != nil { // Normally done by the engine
+		t.Fatal(err)
+	} else if err := commit.Verify(); err != nil {
+		t.Fatal(err)
+	} else if err := commit.Accept(); err != nil {
+		t.Fatal(err)
+	} else if err := vm.SaveBlock(commit); err != nil { // Normally done by the engine
+		t.Fatal(err)
+	}
+
+	if err := service.GetBlockByHeight(nil, &GetBlockByHeightArgs{Height: 2}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case reply.Block.ID != blk.ID():
+		t.Fatal("wrong block at height 2")
+	case reply.Block.Type != "proposal":
+		t.Fatalf("expected a proposal block but got a %s block", reply.Block.Type)
+	case reply.Block.Timestamp == nil || *reply.Block.Timestamp != uint64(newTimestamp.Unix()):
+		t.Fatal("expected the proposal block to report the proposed timestamp")
+	case len(reply.Block.Txs) != 1:
+		t.Fatalf("expected 1 tx but got %d", len(reply.Block.Txs))
+	}
+
+	if err := service.GetBlockByHeight(nil, &GetBlockByHeightArgs{Height: 3}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply.Block.ID != commit.ID() || reply.Block.Type != "commit" {
+		t.Fatal("expected the commit block at height 3")
+	}
+
+	// Blocks can be looked up by ID as well
+	if err := service.GetBlock(nil, &GetBlockArgs{BlockID: blk.ID()}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply.Block.Height != 2 {
+		t.Fatalf("expected the block at height 2 but got %d", reply.Block.Height)
+	}
+}
+
+// Ensure blocks accepted before the index existed are indexed on startup
+func TestInitBlockIndex(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	lastAcceptedID := vm.LastAccepted()
+	lastAccepted, err := vm.getBlock(lastAcceptedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisID, err := vm.getAcceptedBlockID(vm.DB, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Drop the index, as if the blocks were accepted by an older version
+	heightDB := prefixdb.NewNested([]byte(blockHeightDBPrefix), vm.DB)
+	for height := uint64(0); height <= lastAccepted.Height(); height++ {
+		if err := heightDB.Delete(heightKey(height)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := heightDB.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.getAcceptedBlockID(vm.DB, 0); err == nil {
+		t.Fatal("should have dropped the index")
+	}
+
+	if err := vm.initBlockIndex(); err != nil {
+		t.Fatal(err)
+	}
+	if blkID, err := vm.getAcceptedBlockID(vm.DB, lastAccepted.Height()); err != nil {
+		t.Fatal(err)
+	} else if blkID != lastAcceptedID {
+		t.Fatal("wrong block indexed at the last accepted height")
+	}
+	if blkID, err := vm.getAcceptedBlockID(vm.DB, 0); err != nil {
+		t.Fatal(err)
+	} else if blkID != genesisID {
+		t.Fatal("wrong block indexed at the genesis height")
+	}
+}
@@ -0,0 +1,426 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// Builder builds and signs transactions that spend the UTXOs it is given with
+// the keys of a keychain. The UTXOs are selected, and the inputs and outputs
+// that spend and stake them are built, by the embedded wallet.Builder, whose
+// codec must be Codec. It doesn't read the state of the chain, so it can be
+// used to build transactions without a node. The transactions aren't verified
+// against the parameters of the chain.
+type Builder struct {
+	wallet.Builder
+
+	NetworkID uint32
+	ChainID   ids.ID
+
+	// Fee burned by AddSubnetValidatorTxs, ImportTxs and ExportTxs
+	TxFee uint64
+	// Fee burned by CreateSubnetTxs and CreateChainTxs
+	CreationTxFee uint64
+}
+
+// NewAddValidatorTx returns a signed AddValidatorTx that stakes [stakeAmt] out
+// of [utxos]
+func (b *Builder) NewAddValidatorTx(
+	utxos []*avax.UTXO, // UTXOs providing the staked tokens
+	kc *secp256k1fx.Keychain, // Keys providing the staked tokens
+	stakeAmt, // Amount the validator stakes
+	startTime, // Unix time they start validating
+	endTime uint64, // Unix time they stop validating
+	nodeID ids.ShortID, // ID of the node that validates
+	rewardAddress ids.ShortID, // Address to send reward to, if applicable
+	shares uint32, // 10,000 times percentage of reward taken from delegators
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, unlockedOuts, lockedOuts, signers, err := b.Stake(utxos, kc, stakeAmt, 0, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	tx := &Tx{UnsignedTx: &UnsignedAddValidatorTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.NetworkID,
+			BlockchainID: b.ChainID,
+			Ins:          ins,
+			Outs:         unlockedOuts,
+		}},
+		Validator: Validator{
+			NodeID: nodeID,
+			Start:  startTime,
+			End:    endTime,
+			Wght:   stakeAmt,
+		},
+		Stake: lockedOuts,
+		RewardsOwner: &secp256k1fx.OutputOwners{
+			Locktime:  0,
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardAddress},
+		},
+		Shares: shares,
+	}}
+	return tx, tx.Sign(b.Codec, signers)
+}
+
+// NewAddDelegatorTx returns a signed AddDelegatorTx that stakes [stakeAmt] out
+// of [utxos]
+func (b *Builder) NewAddDelegatorTx(
+	utxos []*avax.UTXO, // UTXOs providing the staked tokens
+	kc *secp256k1fx.Keychain, // Keys providing the staked tokens
+	stakeAmt, // Amount the delegator stakes
+	startTime, // Unix time they start delegating
+	endTime uint64, // Unix time they stop delegating
+	nodeID ids.ShortID, // ID of the node we are delegating to
+	rewardAddress ids.ShortID, // Address to send reward to, if applicable
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, unlockedOuts, lockedOuts, signers, err := b.Stake(utxos, kc, stakeAmt, 0, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	tx := &Tx{UnsignedTx: &UnsignedAddDelegatorTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.NetworkID,
+			BlockchainID: b.ChainID,
+			Ins:          ins,
+			Outs:         unlockedOuts,
+		}},
+		Validator: Validator{
+			NodeID: nodeID,
+			Start:  startTime,
+			End:    endTime,
+			Wght:   stakeAmt,
+		},
+		Stake: lockedOuts,
+		RewardsOwner: &secp256k1fx.OutputOwners{
+			Locktime:  0,
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardAddress},
+		},
+	}}
+	return tx, tx.Sign(b.Codec, signers)
+}
+
+// NewAddSubnetValidatorTx returns a signed AddSubnetValidatorTx. The fee is
+// paid out of [utxos] and the subnet, which is owned by [subnetOwner], is
+// authorized with the keys in [kc].
+func (b *Builder) NewAddSubnetValidatorTx(
+	utxos []*avax.UTXO, // UTXOs paying the fee
+	kc *secp256k1fx.Keychain, // Keys paying the fee and authorizing the subnet
+	weight, // Sampling weight of the new validator
+	startTime, // Unix time they start delegating
+	endTime uint64, // Unix time they top delegating
+	nodeID ids.ShortID, // ID of the node validating
+	subnetID ids.ID, // ID of the subnet the validator will validate
+	subnetOwner *secp256k1fx.OutputOwners, // Owner of the subnet
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := b.Stake(utxos, kc, 0, b.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(subnetOwner, kc)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	tx := &Tx{UnsignedTx: &UnsignedAddSubnetValidatorTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.NetworkID,
+			BlockchainID: b.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Validator: SubnetValidator{
+			Validator: Validator{
+				NodeID: nodeID,
+				Start:  startTime,
+				End:    endTime,
+				Wght:   weight,
+			},
+			Subnet: subnetID,
+		},
+		SubnetAuth: subnetAuth,
+	}}
+	return tx, tx.Sign(b.Codec, signers)
+}
+
+// NewCreateChainTx returns a signed CreateChainTx. The fee is paid out of
+// [utxos] and the subnet, which is owned by [subnetOwner], is authorized with
+// the keys in [kc].
+func (b *Builder) NewCreateChainTx(
+	utxos []*avax.UTXO, // UTXOs paying the fee
+	kc *secp256k1fx.Keychain, // Keys paying the fee and authorizing the subnet
+	subnetID ids.ID, // ID of the subnet that validates the new chain
+	subnetOwner *secp256k1fx.OutputOwners, // Owner of the subnet
+	genesisData []byte, // Byte repr. of genesis state of the new chain
+	vmID ids.ID, // VM this chain runs
+	fxIDs []ids.ID, // fxs this chain supports
+	chainName string, // Name of the chain
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := b.Stake(utxos, kc, 0, b.CreationTxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(subnetOwner, kc)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Sort the provided fxIDs
+	ids.SortIDs(fxIDs)
+
+	tx := &Tx{UnsignedTx: &UnsignedCreateChainTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.NetworkID,
+			BlockchainID: b.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		SubnetID:    subnetID,
+		ChainName:   chainName,
+		VMID:        vmID,
+		FxIDs:       fxIDs,
+		GenesisData: genesisData,
+		SubnetAuth:  subnetAuth,
+	}}
+	return tx, tx.Sign(b.Codec, signers)
+}
+
+// NewCreateSubnetTx returns a signed CreateSubnetTx that pays the fee out of
+// [utxos]
+func (b *Builder) NewCreateSubnetTx(
+	utxos []*avax.UTXO, // UTXOs paying the fee
+	kc *secp256k1fx.Keychain, // Keys paying the fee
+	threshold uint32, // [threshold] of [ownerAddrs] needed to manage this subnet
+	ownerAddrs []ids.ShortID, // control addresses for the new subnet
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := b.Stake(utxos, kc, 0, b.CreationTxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	// Sort control addresses
+	ids.SortShortIDs(ownerAddrs)
+
+	tx := &Tx{UnsignedTx: &UnsignedCreateSubnetTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.NetworkID,
+			BlockchainID: b.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Owner: &secp256k1fx.OutputOwners{
+			Threshold: threshold,
+			Addrs:     ownerAddrs,
+		},
+	}}
+	return tx, tx.Sign(b.Codec, signers)
+}
+
+// NewTransferSubnetOwnershipTx returns a signed TransferSubnetOwnershipTx that
+// makes [threshold] of [ownerAddrs] the owner of the subnet. The fee is paid
+// out of [utxos] and the subnet, which is owned by [subnetOwner], is
+// authorized with the keys in [kc].
+func (b *Builder) NewTransferSubnetOwnershipTx(
+	utxos []*avax.UTXO, // UTXOs paying the fee
+	kc *secp256k1fx.Keychain, // Keys paying the fee and authorizing the subnet
+	subnetID ids.ID, // ID of the subnet to transfer
+	subnetOwner *secp256k1fx.OutputOwners, // Current owner of the subnet
+	threshold uint32, // [threshold] of [ownerAddrs] needed to manage the subnet
+	ownerAddrs []ids.ShortID, // New control addresses of the subnet
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := b.Stake(utxos, kc, 0, b.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(subnetOwner, kc)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Sort control addresses
+	ids.SortShortIDs(ownerAddrs)
+
+	tx := &Tx{UnsignedTx: &UnsignedTransferSubnetOwnershipTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.NetworkID,
+			BlockchainID: b.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner: &secp256k1fx.OutputOwners{
+			Threshold: threshold,
+			Addrs:     ownerAddrs,
+		},
+	}}
+	return tx, tx.Sign(b.Codec, signers)
+}
+
+// NewRemoveSubnetValidatorTx returns a signed RemoveSubnetValidatorTx that
+// removes [nodeID] from the validators of the subnet at [removalTime]. The fee
+// is paid out of [utxos] and the subnet, which is owned by [subnetOwner], is
+// authorized with the keys in [kc].
+func (b *Builder) NewRemoveSubnetValidatorTx(
+	utxos []*avax.UTXO, // UTXOs paying the fee
+	kc *secp256k1fx.Keychain, // Keys paying the fee and authorizing the subnet
+	nodeID ids.ShortID, // ID of the node to remove
+	subnetID ids.ID, // ID of the subnet the node is removed from
+	removalTime uint64, // Unix time the node is removed at
+	subnetOwner *secp256k1fx.OutputOwners, // Owner of the subnet
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := b.Stake(utxos, kc, 0, b.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(subnetOwner, kc)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	tx := &Tx{UnsignedTx: &UnsignedRemoveSubnetValidatorTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.NetworkID,
+			BlockchainID: b.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		Time:       removalTime,
+		SubnetAuth: subnetAuth,
+	}}
+	return tx, tx.Sign(b.Codec, signers)
+}
+
+// NewExportTx returns a signed ExportTx that exports [amount] AVAX out of
+// [utxos] to [to] on [chainID]
+func (b *Builder) NewExportTx(
+	utxos []*avax.UTXO, // UTXOs providing the tokens and paying the fee
+	kc *secp256k1fx.Keychain, // Keys providing the tokens and paying the fee
+	amount uint64, // Amount of tokens to export
+	chainID ids.ID, // Chain to send the UTXOs to
+	to ids.ShortID, // Address of chain recipient
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	toBurn, err := safemath.Add64(amount, b.TxFee)
+	if err != nil {
+		return nil, errOverflowExport
+	}
+	ins, outs, _, signers, err := b.Stake(utxos, kc, 0, toBurn, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	tx := &Tx{UnsignedTx: &UnsignedExportTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.NetworkID,
+			BlockchainID: b.ChainID,
+			Ins:          ins,
+			Outs:         outs, // Non-exported outputs
+		}},
+		DestinationChain: chainID,
+		ExportedOutputs: []*avax.TransferableOutput{{ // Exported to X-Chain
+			Asset: avax.Asset{ID: b.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{to},
+				},
+			},
+		}},
+	}}
+	return tx, tx.Sign(b.Codec, signers)
+}
+
+// NewImportTx returns a signed ImportTx that imports every AVAX UTXO out of
+// [atomicUTXOs], which were exported from [chainID], that [kc] can spend, and
+// sends them to [to]. If the imported AVAX doesn't cover the fee, the rest of
+// the fee is paid out of [utxos].
+func (b *Builder) NewImportTx(
+	utxos []*avax.UTXO, // UTXOs paying the fee, if needed
+	atomicUTXOs []*avax.UTXO, // UTXOs exported from [chainID]
+	kc *secp256k1fx.Keychain, // Keys to import the funds
+	chainID ids.ID, // chain to import from
+	to ids.ShortID, // Address of recipient
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	avaxUTXOs := []*avax.UTXO{}
+	for _, utxo := range atomicUTXOs {
+		if utxo.AssetID() == b.AVAXAssetID {
+			avaxUTXOs = append(avaxUTXOs, utxo)
+		}
+	}
+	amountsImported, importedInputs, signers, err := b.SpendAll(avaxUTXOs, kc)
+	if err != nil {
+		return nil, err
+	}
+	importedAmount := amountsImported[b.AVAXAssetID]
+
+	if importedAmount == 0 {
+		return nil, errNoFunds // No imported UTXOs were spendable
+	}
+
+	ins := []*avax.TransferableInput{}
+	outs := []*avax.TransferableOutput{}
+	if importedAmount < b.TxFee { // imported amount goes toward paying tx fee
+		var baseSigners [][]*crypto.PrivateKeySECP256K1R
+		ins, outs, _, baseSigners, err = b.Stake(utxos, kc, 0, b.TxFee-importedAmount, changeAddr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
+		signers = append(baseSigners, signers...)
+	} else if importedAmount > b.TxFee {
+		outs = append(outs, &avax.TransferableOutput{
+			Asset: avax.Asset{ID: b.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: importedAmount - b.TxFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{to},
+				},
+			},
+		})
+	}
+
+	tx := &Tx{UnsignedTx: &UnsignedImportTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.NetworkID,
+			BlockchainID: b.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+		SourceChain:    chainID,
+		ImportedInputs: importedInputs,
+	}}
+	return tx, tx.Sign(b.Codec, signers)
+}
@@ -32,6 +32,24 @@ func (c *Client) GetHeight() (uint64, error) {
 	return uint64(res.Height), err
 }
 
+// GetBlock returns the decoded block with ID [blockID]
+func (c *Client) GetBlock(blockID ids.ID) (*JSONBlock, error) {
+	res := &GetBlockReply{}
+	err := c.requester.SendRequest("getBlock", &GetBlockArgs{
+		BlockID: blockID,
+	}, res)
+	return res.Block, err
+}
+
+// GetBlockByHeight returns the decoded accepted block at [height]
+func (c *Client) GetBlockByHeight(height uint64) (*JSONBlock, error) {
+	res := &GetBlockReply{}
+	err := c.requester.SendRequest("getBlockByHeight", &GetBlockByHeightArgs{
+		Height: cjson.Uint64(height),
+	}, res)
+	return res.Block, err
+}
+
 // ExportKey returns the private key corresponding to [address] from [user]'s account
 func (c *Client) ExportKey(user api.UserPass, address string) (string, error) {
 	res := &ExportKeyReply{}
@@ -151,6 +169,24 @@ func (c *Client) SampleValidators(subnetID ids.ID, sampleSize uint16) ([]string,
 	return res.Validators, err
 }
 
+// GetValidatorsAt returns the weights of the validators of the subnet with ID
+// [subnetID] once the P-Chain block at [height] was accepted, keyed by node ID
+func (c *Client) GetValidatorsAt(subnetID ids.ID, height uint64) (map[string]uint64, error) {
+	res := &GetValidatorsAtReply{}
+	err := c.requester.SendRequest("getValidatorsAt", &GetValidatorsAtArgs{
+		SubnetID: subnetID,
+		Height:   cjson.Uint64(height),
+	}, res)
+	if err != nil {
+		return nil, err
+	}
+	vdrs := make(map[string]uint64, len(res.Validators))
+	for nodeID, weight := range res.Validators {
+		vdrs[nodeID] = uint64(weight)
+	}
+	return vdrs, nil
+}
+
 // AddValidator issues a transaction to add a validator to the primary network and returns the txID
 func (c *Client) AddValidator(
 	user api.UserPass,
@@ -263,6 +299,54 @@ func (c *Client) CreateSubnet(
 	return res.TxID, err
 }
 
+// TransferSubnetOwnership issues a transaction that makes [threshold] of
+// [controlKeys] the owner of the subnet [subnetID] and returns the txID
+func (c *Client) TransferSubnetOwnership(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	subnetID ids.ID,
+	controlKeys []string,
+	threshold uint32,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("transferSubnetOwnership", &TransferSubnetOwnershipArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		SubnetID:    subnetID,
+		ControlKeys: controlKeys,
+		Threshold:   cjson.Uint32(threshold),
+	}, res)
+	return res.TxID, err
+}
+
+// RemoveSubnetValidator issues a transaction that removes [nodeID] from the
+// validators of the subnet [subnetID] at [removalTime] and returns the txID
+func (c *Client) RemoveSubnetValidator(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	nodeID string,
+	subnetID ids.ID,
+	removalTime uint64,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("removeSubnetValidator", &RemoveSubnetValidatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		NodeID:      nodeID,
+		SubnetID:    subnetID,
+		RemovalTime: cjson.Uint64(removalTime),
+	}, res)
+	return res.TxID, err
+}
+
 // ExportAVAX issues an ExportAVAX transaction and returns the txID
 func (c *Client) ExportAVAX(
 	user api.UserPass,
@@ -387,6 +471,14 @@ func (c *Client) IssueTx(txBytes []byte) (ids.ID, error) {
 	return res.TxID, err
 }
 
+// GetMempoolTxs returns the txs that are waiting to be put into a block and why
+// they're still waiting
+func (c *Client) GetMempoolTxs() ([]APIMempoolTx, error) {
+	res := &GetMempoolTxsReply{}
+	err := c.requester.SendRequest("getMempoolTxs", &struct{}{}, res)
+	return res.Txs, err
+}
+
 // GetTx returns the byte representation of the transaction corresponding to [txID]
 func (c *Client) GetTx(txID ids.ID) ([]byte, error) {
 	res := &api.FormattedTx{}
@@ -400,6 +492,21 @@ func (c *Client) GetTx(txID ids.ID) ([]byte, error) {
 	return formatting.Decode(res.Encoding, res.Tx)
 }
 
+// SimulateTx verifies the signed or unsigned transaction [txBytes] without
+// issuing it
+func (c *Client) SimulateTx(txBytes []byte) (*SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &SimulateTxReply{}
+	err = c.requester.SendRequest("simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res)
+	return res, err
+}
+
 // GetTxStatus returns the status of the transaction corresponding to [txID]
 func (c *Client) GetTxStatus(txID ids.ID, includeReason bool) (*GetTxStatusResponse, error) {
 	res := new(GetTxStatusResponse)
@@ -410,6 +517,38 @@ func (c *Client) GetTxStatus(txID ids.ID, includeReason bool) (*GetTxStatusRespo
 	return res, err
 }
 
+// GetStakingOutcome returns whether the staker that [txID] added was rewarded
+// once its staking period ended
+func (c *Client) GetStakingOutcome(txID ids.ID) (*GetStakingOutcomeReply, error) {
+	res := &GetStakingOutcomeReply{}
+	err := c.requester.SendRequest("getStakingOutcome", &api.JSONTxID{
+		TxID: txID,
+	}, res)
+	return res, err
+}
+
+// GetRewardUTXOs returns the byte representation of the UTXOs that the staker
+// that [txID] added received once its staking period ended
+func (c *Client) GetRewardUTXOs(txID ids.ID) ([][]byte, error) {
+	res := &GetRewardUTXOsReply{}
+	err := c.requester.SendRequest("getRewardUTXOs", &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: formatting.Hex,
+	}, res)
+	if err != nil {
+		return nil, err
+	}
+	utxos := make([][]byte, len(res.UTXOs))
+	for i, utxo := range res.UTXOs {
+		utxoBytes, err := formatting.Decode(res.Encoding, utxo)
+		if err != nil {
+			return nil, err
+		}
+		utxos[i] = utxoBytes
+	}
+	return utxos, nil
+}
+
 // GetStake returns the amount of nAVAX that [addresses] have cumulatively
 // staked on the Primary Network.
 func (c *Client) GetStake(addrs []string) (uint64, error) {
@@ -447,3 +586,122 @@ func (c *Client) GetMaxStakeAmount(subnetID ids.ID, nodeID string, startTime, en
 	}, res)
 	return uint64(res.Amount), err
 }
+
+// GetDelegationCapacity returns how much nAVAX can be delegated to [nodeID]
+// during the time period, its delegation fee and how the reward of delegating
+// [amount] would be split. If [amount] is 0, the reward of delegating the
+// whole capacity is projected.
+func (c *Client) GetDelegationCapacity(nodeID string, startTime, endTime, amount uint64) (*GetDelegationCapacityReply, error) {
+	res := new(GetDelegationCapacityReply)
+	err := c.requester.SendRequest("getDelegationCapacity", &GetDelegationCapacityArgs{
+		NodeID:    nodeID,
+		StartTime: cjson.Uint64(startTime),
+		EndTime:   cjson.Uint64(endTime),
+		Amount:    cjson.Uint64(amount),
+	}, res)
+	return res, err
+}
+
+// BuildUnsignedAddValidator returns a transaction, without any of its
+// signatures, that adds a validator to the primary network using the funds of
+// [from]
+func (c *Client) BuildUnsignedAddValidator(
+	from []string,
+	changeAddr string,
+	rewardAddress,
+	nodeID string,
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	delegationFeeRate float32,
+) (*PartialTxReply, error) {
+	res := &PartialTxReply{}
+	jsonStakeAmount := cjson.Uint64(stakeAmount)
+	err := c.requester.SendRequest("buildUnsignedAddValidator", &BuildUnsignedAddValidatorArgs{
+		JSONFromAddrs:  api.JSONFromAddrs{From: from},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		APIStaker: APIStaker{
+			NodeID:      nodeID,
+			StakeAmount: &jsonStakeAmount,
+			StartTime:   cjson.Uint64(startTime),
+			EndTime:     cjson.Uint64(endTime),
+		},
+		RewardAddress:     rewardAddress,
+		DelegationFeeRate: cjson.Float32(delegationFeeRate),
+		Encoding:          formatting.Hex,
+	}, res)
+	return res, err
+}
+
+// BuildUnsignedExportAVAX returns a transaction, without any of its
+// signatures, that exports [amount] AVAX of [from] to [to] on the X-Chain
+func (c *Client) BuildUnsignedExportAVAX(
+	from []string,
+	changeAddr string,
+	to string,
+	amount uint64,
+) (*PartialTxReply, error) {
+	res := &PartialTxReply{}
+	err := c.requester.SendRequest("buildUnsignedExportAVAX", &BuildUnsignedExportAVAXArgs{
+		JSONFromAddrs:  api.JSONFromAddrs{From: from},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		To:             to,
+		Amount:         cjson.Uint64(amount),
+		Encoding:       formatting.Hex,
+	}, res)
+	return res, err
+}
+
+// BuildUnsignedImportAVAX returns a transaction, without any of its
+// signatures, that imports the AVAX exported from [sourceChain] to [from] and
+// sends it to [to]
+func (c *Client) BuildUnsignedImportAVAX(
+	from []string,
+	changeAddr,
+	to,
+	sourceChain string,
+) (*PartialTxReply, error) {
+	res := &PartialTxReply{}
+	err := c.requester.SendRequest("buildUnsignedImportAVAX", &BuildUnsignedImportAVAXArgs{
+		JSONFromAddrs:  api.JSONFromAddrs{From: from},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		To:             to,
+		SourceChain:    sourceChain,
+		Encoding:       formatting.Hex,
+	}, res)
+	return res, err
+}
+
+// SignTx adds to the partially signed [txBytes] the missing signatures that
+// [user] or [privateKey] can provide. Either may be left empty.
+func (c *Client) SignTx(txBytes []byte, user api.UserPass, privateKey string) (*PartialTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &PartialTxReply{}
+	err = c.requester.SendRequest("signTx", &SignTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+		UserPass:   user,
+		PrivateKey: privateKey,
+	}, res)
+	return res, err
+}
+
+// GetTxSignatures returns which signatures the partially signed [txBytes]
+// contains and which are still missing
+func (c *Client) GetTxSignatures(txBytes []byte) (*PartialTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &PartialTxReply{}
+	err = c.requester.SendRequest("getTxSignatures", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res)
+	return res, err
+}
@@ -62,6 +62,9 @@ func init() {
 
 			c.RegisterType(&StakeableLockIn{}),
 			c.RegisterType(&StakeableLockOut{}),
+
+			c.RegisterType(&UnsignedTransferSubnetOwnershipTx{}),
+			c.RegisterType(&UnsignedRemoveSubnetValidatorTx{}),
 		)
 	}
 	errs.Add(
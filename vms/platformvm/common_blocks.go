@@ -211,6 +211,9 @@ func (sdb *SingleDecisionBlock) Accept() error {
 	if err := sdb.CommonBlock.Accept(); err != nil {
 		return fmt.Errorf("failed to accept CommonBlock: %w", err)
 	}
+	if err := sdb.vm.putAcceptedBlockID(sdb.onAcceptDB, sdb.Height(), sdb.ID()); err != nil {
+		return fmt.Errorf("failed to index block by height: %w", err)
+	}
 
 	// Update the state of the chain in the database
 	if err := sdb.onAcceptDB.Commit(); err != nil {
@@ -256,6 +259,20 @@ func (ddb *DoubleDecisionBlock) Accept() error {
 		return fmt.Errorf("failed to accept CommonBlock: %w", err)
 	}
 
+	// Record how the validator sets changed so they can be looked up at this
+	// height later. Only proposal blocks change the validator sets.
+	if err := ddb.vm.putValidatorDiffs(ddb.onAcceptDB, ddb.Height()); err != nil {
+		return fmt.Errorf("failed to store validator diffs: %w", err)
+	}
+
+	// Index the proposal block and this block by their heights
+	if err := ddb.vm.putAcceptedBlockID(ddb.onAcceptDB, parent.Height(), parent.ID()); err != nil {
+		return fmt.Errorf("failed to index parent block by height: %w", err)
+	}
+	if err := ddb.vm.putAcceptedBlockID(ddb.onAcceptDB, ddb.Height(), ddb.ID()); err != nil {
+		return fmt.Errorf("failed to index block by height: %w", err)
+	}
+
 	// Update the state of the chain in the database
 	if err := ddb.onAcceptDB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit onAcceptDB: %w", err)
@@ -173,16 +173,38 @@ func (vm *VM) newExportTx(
 		return nil, errWrongChainID
 	}
 
+	kc, utxos, err := vm.keychainUTXOs(vm.DB, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	tx, err := vm.txBuilder().NewExportTx(utxos, kc, amount, chainID, to, changeAddr)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.UnsignedTx.(*UnsignedExportTx).Verify(vm.Ctx.XChainID, vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID)
+}
+
+// newUnsignedExportTx returns a new ExportTx, exporting funds owned by
+// [fromAddrs], that doesn't contain any signatures yet
+func (vm *VM) newUnsignedExportTx(
+	amount uint64, // Amount of tokens to export
+	chainID ids.ID, // Chain to send the UTXOs to
+	to ids.ShortID, // Address of chain recipient
+	fromAddrs ids.ShortSet, // Addresses providing the tokens and paying the fee
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	if vm.Ctx.XChainID != chainID {
+		return nil, errWrongChainID
+	}
+
 	toBurn, err := safemath.Add64(amount, vm.txFee)
 	if err != nil {
 		return nil, errOverflowExport
 	}
-	ins, outs, _, signers, err := vm.stake(vm.DB, keys, 0, toBurn, changeAddr)
+	ins, outs, _, _, err := vm.stakeWithAddresses(vm.DB, fromAddrs, 0, toBurn, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
-
-	// Create the transaction
 	utx := &UnsignedExportTx{
 		BaseTx: BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    vm.Ctx.NetworkID,
@@ -203,8 +225,8 @@ func (vm *VM) newExportTx(
 			},
 		}},
 	}
-	tx := &Tx{UnsignedTx: utx}
-	if err := tx.Sign(vm.codec, signers); err != nil {
+	tx, err := vm.newUnsignedTx(utx)
+	if err != nil {
 		return nil, err
 	}
 	return tx, utx.Verify(vm.Ctx.XChainID, vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID)
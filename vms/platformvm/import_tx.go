@@ -12,7 +12,6 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/codec"
 	"github.com/ava-labs/avalanchego/utils/crypto"
-	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
@@ -178,46 +177,50 @@ func (vm *VM) newImportTx(
 		return nil, errWrongChainID
 	}
 
-	kc := secp256k1fx.NewKeychain()
-	for _, key := range keys {
-		kc.Add(key)
+	kc, utxos, err := vm.keychainUTXOs(vm.DB, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
-
 	atomicUTXOs, _, _, err := vm.GetAtomicUTXOs(chainID, kc.Addresses(), ids.ShortEmpty, ids.Empty, -1)
 	if err != nil {
 		return nil, fmt.Errorf("problem retrieving atomic UTXOs: %w", err)
 	}
+	tx, err := vm.txBuilder().NewImportTx(utxos, atomicUTXOs, kc, chainID, to, changeAddr)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.UnsignedTx.(*UnsignedImportTx).Verify(vm.Ctx.XChainID, vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID)
+}
 
-	importedInputs := []*avax.TransferableInput{}
-	signers := [][]*crypto.PrivateKeySECP256K1R{}
+// newUnsignedImportTx returns a new ImportTx, importing the funds exported
+// from [chainID] to [fromAddrs], that doesn't contain any signatures yet. If
+// the imported funds don't cover the fee, the rest of the fee is paid with
+// funds owned by [fromAddrs].
+func (vm *VM) newUnsignedImportTx(
+	chainID ids.ID, // chain to import from
+	to ids.ShortID, // Address of recipient
+	fromAddrs ids.ShortSet, // Addresses that own the funds to import
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	if vm.Ctx.XChainID != chainID {
+		return nil, errWrongChainID
+	}
 
-	importedAmount := uint64(0)
-	now := vm.clock.Unix()
+	atomicUTXOs, _, _, err := vm.GetAtomicUTXOs(chainID, fromAddrs, ids.ShortEmpty, ids.Empty, -1)
+	if err != nil {
+		return nil, fmt.Errorf("problem retrieving atomic UTXOs: %w", err)
+	}
+	avaxUTXOs := []*avax.UTXO{}
 	for _, utxo := range atomicUTXOs {
-		if utxo.AssetID() != vm.Ctx.AVAXAssetID {
-			continue
-		}
-		inputIntf, utxoSigners, err := kc.Spend(utxo.Out, now)
-		if err != nil {
-			continue
-		}
-		input, ok := inputIntf.(avax.TransferableIn)
-		if !ok {
-			continue
-		}
-		importedAmount, err = math.Add64(importedAmount, input.Amount())
-		if err != nil {
-			return nil, err
+		if utxo.AssetID() == vm.Ctx.AVAXAssetID {
+			avaxUTXOs = append(avaxUTXOs, utxo)
 		}
-		importedInputs = append(importedInputs, &avax.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  utxo.Asset,
-			In:     input,
-		})
-		signers = append(signers, utxoSigners)
 	}
-	avax.SortTransferableInputsWithSigners(importedInputs, signers)
-
+	amountsImported, importedInputs, err := vm.txBuilder().SpendAllWithAddresses(avaxUTXOs, fromAddrs)
+	if err != nil {
+		return nil, err
+	}
+	importedAmount := amountsImported[vm.Ctx.AVAXAssetID]
 	if importedAmount == 0 {
 		return nil, errNoFunds // No imported UTXOs were spendable
 	}
@@ -225,12 +228,10 @@ func (vm *VM) newImportTx(
 	ins := []*avax.TransferableInput{}
 	outs := []*avax.TransferableOutput{}
 	if importedAmount < vm.txFee { // imported amount goes toward paying tx fee
-		var baseSigners [][]*crypto.PrivateKeySECP256K1R
-		ins, outs, _, baseSigners, err = vm.stake(vm.DB, keys, 0, vm.txFee-importedAmount, changeAddr)
+		ins, outs, _, _, err = vm.stakeWithAddresses(vm.DB, fromAddrs, 0, vm.txFee-importedAmount, changeAddr)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 		}
-		signers = append(baseSigners, signers...)
 	} else if importedAmount > vm.txFee {
 		outs = append(outs, &avax.TransferableOutput{
 			Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
@@ -245,7 +246,6 @@ func (vm *VM) newImportTx(
 		})
 	}
 
-	// Create the transaction
 	utx := &UnsignedImportTx{
 		BaseTx: BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    vm.Ctx.NetworkID,
@@ -256,8 +256,8 @@ func (vm *VM) newImportTx(
 		SourceChain:    chainID,
 		ImportedInputs: importedInputs,
 	}
-	tx := &Tx{UnsignedTx: utx}
-	if err := tx.Sign(vm.codec, signers); err != nil {
+	tx, err := vm.newUnsignedTx(utx)
+	if err != nil {
 		return nil, err
 	}
 	return tx, utx.Verify(vm.Ctx.XChainID, vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID)
@@ -4,8 +4,11 @@
 package platformvm
 
 import (
+	"container/heap"
+	"container/list"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -29,14 +32,44 @@ const (
 	// Time difference between local time and current chain time
 	// at which to attempt to increase the chain timestamp
 	catchUpTime = 2 * time.Hour
+
+	// maxMempoolSize is the maximum number of bytes of txs the mempool holds.
+	// When it's full, the txs that were added the longest ago are evicted.
+	maxMempoolSize = 16 * 1024 * 1024 // 16 MiB
+
+	// maxMempoolTxSize is the size, in bytes, of the largest tx the mempool
+	// accepts
+	maxMempoolTxSize = 64 * 1024 // 64 KiB
+
+	// gossipedTxsPerPeer is the number of gossiped txs from a peer that are
+	// verified per [gossipBudgetPeriod]. Txs a peer gossips beyond that are
+	// dropped without being verified.
+	gossipedTxsPerPeer = 64
+
+	// gossipBudgetPeriod is how often the number of txs peers may gossip is
+	// reset
+	gossipBudgetPeriod = time.Minute
 )
 
 var (
 	errEndOfTime       = errors.New("program time is suspiciously far in the future. Either this codebase was way more successful than expected, or a critical error has occurred")
 	errNoPendingBlocks = errors.New("no pending blocks")
 	errUnknownTxType   = errors.New("unknown transaction type")
+	errTxTooLarge      = errors.New("transaction is too large")
+	errTxNotSigned     = errors.New("transaction isn't fully signed")
+	errTxDropped       = errors.New("transaction was recently dropped")
+	errTxDecided       = errors.New("transaction was already put into a block")
+	errGossipBudget    = errors.New("peer gossiped too many transactions")
 )
 
+// mempoolEntry is the bookkeeping of a tx in the mempool
+type mempoolEntry struct {
+	// Element of the tx's ID in the order txs were added to the mempool in
+	elem *list.Element
+	// Size of the tx in bytes
+	size int
+}
+
 // Mempool implements a simple mempool to convert txs into valid blocks
 type Mempool struct {
 	vm *VM
@@ -77,7 +110,22 @@ type Mempool struct {
 	unissuedProposalTxs *EventHeap
 	unissuedDecisionTxs []*Tx
 	unissuedAtomicTxs   []*Tx
-	unissuedTxIDs       ids.Set
+
+	// Key: ID of a tx that has not been put into a block yet
+	// Value: The tx's bookkeeping
+	unissuedTxs map[ids.ID]mempoolEntry
+	// Number of bytes of the txs that have not been put into blocks yet
+	unissuedTxsSize int
+	// IDs of the txs that have not been put into blocks yet, in the order they
+	// were added to the mempool in. The front is the oldest.
+	unissuedTxOrder *list.List
+
+	// Key: ID of a peer
+	// Value: Number of txs the peer gossiped that were verified since
+	//        [gossipBudgetReset]
+	gossipedTxs map[ids.ShortID]int
+	// Time [gossipedTxs] was last reset at
+	gossipBudgetReset time.Time
 }
 
 // Initialize this mempool.
@@ -89,6 +137,9 @@ func (m *Mempool) Initialize(vm *VM) {
 	// Transactions from clients that have not yet been put into blocks and
 	// added to consensus
 	m.unissuedProposalTxs = &EventHeap{SortByStartTime: true}
+	m.unissuedTxs = make(map[ids.ID]mempoolEntry)
+	m.unissuedTxOrder = list.New()
+	m.gossipedTxs = make(map[ids.ShortID]int)
 
 	m.timer = timer.NewTimer(func() {
 		m.vm.Ctx.Lock.Lock()
@@ -99,16 +150,107 @@ func (m *Mempool) Initialize(vm *VM) {
 	go m.vm.Ctx.Log.RecoverAndPanic(m.timer.Dispatch)
 }
 
-// IssueTx enqueues the [tx] to be put into a block
+// IssueTx enqueues the [tx] to be put into a block if it's valid on top of
+// the preferred state, and gossips it to the validators
 func (m *Mempool) IssueTx(tx *Tx) error {
+	// Initialize the transaction
+	if err := tx.Sign(m.vm.codec, nil); err != nil {
+		return err
+	}
+	if _, ok := m.unissuedTxs[tx.ID()]; ok {
+		return nil
+	}
+	if err := m.verifyTx(tx); err != nil {
+		return err
+	}
+	if err := m.add(tx); err != nil {
+		return err
+	}
+	if m.vm.appSender != nil {
+		m.vm.appSender.SendAppGossip(tx.Bytes())
+	}
+	m.ResetTimer()
+	return nil
+}
+
+// AddGossipedTx enqueues [tx], which was gossiped by [nodeID], to be put into
+// a block if it's valid on top of the preferred state. Unlike IssueTx, [tx]
+// isn't gossiped again. Once [nodeID] has used up its gossip budget, [tx] is
+// dropped without being verified.
+func (m *Mempool) AddGossipedTx(nodeID ids.ShortID, tx *Tx) error {
 	// Initialize the transaction
 	if err := tx.Sign(m.vm.codec, nil); err != nil {
 		return err
 	}
 	txID := tx.ID()
-	if m.unissuedTxIDs.Contains(txID) {
+	if _, ok := m.unissuedTxs[txID]; ok {
 		return nil
 	}
+	if _, ok := m.vm.droppedTxCache.Get(txID); ok {
+		return errTxDropped
+	}
+	if !m.spendGossipBudget(nodeID) {
+		return errGossipBudget
+	}
+	if err := m.verifyTx(tx); err != nil {
+		return err
+	}
+	if err := m.add(tx); err != nil {
+		return err
+	}
+	m.ResetTimer()
+	return nil
+}
+
+// spendGossipBudget returns true if [nodeID] may have another gossiped tx
+// verified, and counts it against [nodeID]'s budget
+func (m *Mempool) spendGossipBudget(nodeID ids.ShortID) bool {
+	if now := m.vm.clock.Time(); !now.Before(m.gossipBudgetReset.Add(gossipBudgetPeriod)) {
+		m.gossipedTxs = make(map[ids.ShortID]int)
+		m.gossipBudgetReset = now
+	}
+	if m.gossipedTxs[nodeID] >= gossipedTxsPerPeer {
+		return false
+	}
+	m.gossipedTxs[nodeID]++
+	return true
+}
+
+// verifyTx returns nil if [tx] is fully signed, wasn't put into a block yet,
+// and is valid on top of the preferred state
+func (m *Mempool) verifyTx(tx *Tx) error {
+	if _, err := m.vm.getStatus(m.vm.preferredState(), tx.ID()); err == nil {
+		return errTxDecided
+	}
+	sim, err := m.vm.simulateTx(tx)
+	switch {
+	case err != nil:
+		return err
+	case !sim.signed:
+		return errTxNotSigned
+	default:
+		return sim.err
+	}
+}
+
+// add [tx] to the mempool and persist it. If the mempool is full, the txs
+// that were added the longest ago are evicted to make room for [tx].
+func (m *Mempool) add(tx *Tx) error {
+	txID := tx.ID()
+	size := len(tx.Bytes())
+	if size > maxMempoolTxSize {
+		return fmt.Errorf("%w: %d bytes > %d bytes", errTxTooLarge, size, maxMempoolTxSize)
+	}
+	switch tx.UnsignedTx.(type) {
+	case TimedTx, UnsignedDecisionTx, UnsignedAtomicTx:
+	default:
+		return errUnknownTxType
+	}
+
+	for m.unissuedTxsSize+size > maxMempoolSize {
+		m.evictOldest()
+	}
+
 	switch tx.UnsignedTx.(type) {
 	case TimedTx:
 		m.unissuedProposalTxs.Add(tx)
@@ -116,14 +258,176 @@ func (m *Mempool) IssueTx(tx *Tx) error {
 		m.unissuedDecisionTxs = append(m.unissuedDecisionTxs, tx)
 	case UnsignedAtomicTx:
 		m.unissuedAtomicTxs = append(m.unissuedAtomicTxs, tx)
-	default:
-		return errUnknownTxType
 	}
-	m.unissuedTxIDs.Add(txID)
+	m.unissuedTxs[txID] = mempoolEntry{
+		elem: m.unissuedTxOrder.PushBack(txID),
+		size: size,
+	}
+	m.unissuedTxsSize += size
+
+	if err := m.vm.putMempoolTx(m.vm.DB, txID, tx.Bytes()); err != nil {
+		return err
+	}
+	return m.vm.DB.Commit()
+}
+
+// evictOldest drops the tx that was added to the mempool the longest ago
+func (m *Mempool) evictOldest() {
+	front := m.unissuedTxOrder.Front()
+	if front == nil {
+		return
+	}
+	oldestID := front.Value.(ids.ID)
+
+	m.removeTx(oldestID)
+	errMsg := "evicted from the mempool because it was full"
+	m.vm.droppedTxCache.Put(oldestID, errMsg) // cache tx as dropped
+	m.vm.Ctx.Log.Debug("dropping tx %s: %s", oldestID, errMsg)
+}
+
+// removeTx removes the tx with ID [txID] from the mempool, if it's there
+func (m *Mempool) removeTx(txID ids.ID) {
+	if _, ok := m.unissuedTxs[txID]; !ok {
+		return
+	}
+	for i, tx := range m.unissuedDecisionTxs {
+		if tx.ID() == txID {
+			m.unissuedDecisionTxs = append(m.unissuedDecisionTxs[:i], m.unissuedDecisionTxs[i+1:]...)
+			break
+		}
+	}
+	for i, tx := range m.unissuedAtomicTxs {
+		if tx.ID() == txID {
+			m.unissuedAtomicTxs = append(m.unissuedAtomicTxs[:i], m.unissuedAtomicTxs[i+1:]...)
+			break
+		}
+	}
+	for i, tx := range m.unissuedProposalTxs.Txs {
+		if tx.ID() == txID {
+			heap.Remove(m.unissuedProposalTxs, i)
+			break
+		}
+	}
+	m.forgetTx(txID)
+}
+
+// RemoveTxs removes [txs] from the mempool, if they're there. Called when
+// [txs] are put into a block that passed verification.
+func (m *Mempool) RemoveTxs(txs ...*Tx) {
+	for _, tx := range txs {
+		m.removeTx(tx.ID())
+	}
+}
+
+// forgetTx removes the bookkeeping of the tx with ID [txID], which was already
+// taken out of the unissued txs
+func (m *Mempool) forgetTx(txID ids.ID) {
+	entry, ok := m.unissuedTxs[txID]
+	if !ok {
+		return
+	}
+	delete(m.unissuedTxs, txID)
+	m.unissuedTxOrder.Remove(entry.elem)
+	m.unissuedTxsSize -= entry.size
+	if err := m.vm.deleteMempoolTx(m.vm.DB, txID); err != nil {
+		m.vm.Ctx.Log.Error("couldn't delete tx %s from the mempool's database: %s", txID, err)
+	}
+}
+
+// loadTxs enqueues the txs that were persisted in the mempool when the node
+// last ran. Txs that are no longer well-formed or were put into a block since
+// are dropped.
+func (m *Mempool) loadTxs() error {
+	txs, err := m.vm.getMempoolTxs(m.vm.DB)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		txID := tx.ID()
+		if err := m.vm.deleteMempoolTx(m.vm.DB, txID); err != nil {
+			return err
+		}
+		if err := m.vm.verifyUnsignedTx(tx.UnsignedTx); err != nil {
+			m.vm.Ctx.Log.Debug("dropping persisted tx %s: %s", txID, err)
+			continue
+		}
+		if _, err := m.vm.getStatus(m.vm.DB, txID); err == nil {
+			continue
+		}
+		if err := m.add(tx); err != nil {
+			m.vm.Ctx.Log.Debug("dropping persisted tx %s: %s", txID, err)
+		}
+	}
+	if err := m.vm.DB.Commit(); err != nil {
+		return err
+	}
 	m.ResetTimer()
 	return nil
 }
 
+// mempoolTxStatus is a tx in the mempool and the reason it hasn't been put into
+// a block yet
+type mempoolTxStatus struct {
+	tx     *Tx
+	reason string
+}
+
+// pendingTxs returns the txs in the mempool, in the order they're put into
+// blocks, and why each of them is still waiting
+func (m *Mempool) pendingTxs() []mempoolTxStatus {
+	numDecisionTxs := len(m.unissuedDecisionTxs)
+	numAtomicTxs := len(m.unissuedAtomicTxs)
+	pending := make([]mempoolTxStatus, 0, len(m.unissuedTxs))
+
+	for i, tx := range m.unissuedDecisionTxs {
+		reason := "waiting to be put into the next standard block"
+		if i >= BatchSize {
+			reason = fmt.Sprintf("waiting for %d decision txs ahead of it to be put into blocks", i-i%BatchSize)
+		}
+		pending = append(pending, mempoolTxStatus{
+			tx:     tx,
+			reason: reason,
+		})
+	}
+
+	for i, tx := range m.unissuedAtomicTxs {
+		reason := "waiting to be put into the next atomic block"
+		if numDecisionTxs > 0 || i > 0 {
+			reason = fmt.Sprintf("waiting for %d decision txs and %d atomic txs ahead of it to be put into blocks", numDecisionTxs, i)
+		}
+		pending = append(pending, mempoolTxStatus{
+			tx:     tx,
+			reason: reason,
+		})
+	}
+
+	proposalTxs := make([]*Tx, len(m.unissuedProposalTxs.Txs))
+	copy(proposalTxs, m.unissuedProposalTxs.Txs)
+	sort.SliceStable(proposalTxs, func(i, j int) bool {
+		return proposalTxs[i].UnsignedTx.(TimedTx).StartTime().Before(proposalTxs[j].UnsignedTx.(TimedTx).StartTime())
+	})
+	syncTime := m.vm.clock.Time().Add(syncBound)
+	for i, tx := range proposalTxs {
+		var reason string
+		startTime := tx.UnsignedTx.(TimedTx).StartTime()
+		switch {
+		case syncTime.After(startTime):
+			reason = fmt.Sprintf("will be dropped because the synchrony bound (%s) is later than its start time (%s)", syncTime, startTime)
+		case numDecisionTxs > 0 || numAtomicTxs > 0:
+			reason = fmt.Sprintf("waiting for %d decision txs and %d atomic txs to be put into blocks", numDecisionTxs, numAtomicTxs)
+		case i > 0:
+			reason = fmt.Sprintf("waiting for %d proposal txs with earlier start times to be put into blocks", i)
+		default:
+			reason = "waiting for the chain time to be advanced and stakers to be rewarded before it's proposed"
+		}
+		pending = append(pending, mempoolTxStatus{
+			tx:     tx,
+			reason: reason,
+		})
+	}
+	return pending
+}
+
 // BuildBlock builds a block to be added to consensus
 func (m *Mempool) BuildBlock() (snowman.Block, error) {
 	m.vm.Ctx.Log.Debug("in BuildBlock")
@@ -144,7 +448,7 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 		var txs []*Tx
 		txs, m.unissuedDecisionTxs = m.unissuedDecisionTxs[:numTxs], m.unissuedDecisionTxs[numTxs:]
 		for _, tx := range txs {
-			m.unissuedTxIDs.Remove(tx.ID())
+			m.forgetTx(tx.ID())
 		}
 		blk, err := m.vm.newStandardBlock(preferredID, preferredHeight+1, txs)
 		if err != nil {
@@ -166,7 +470,7 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 	if len(m.unissuedAtomicTxs) > 0 {
 		tx := m.unissuedAtomicTxs[0]
 		m.unissuedAtomicTxs = m.unissuedAtomicTxs[1:]
-		m.unissuedTxIDs.Remove(tx.ID())
+		m.forgetTx(tx.ID())
 		blk, err := m.vm.newAtomicBlock(preferredID, preferredHeight+1, *tx)
 		if err != nil {
 			return nil, err
@@ -276,12 +580,11 @@ func (m *Mempool) BuildBlock() (snowman.Block, error) {
 	syncTime := localTime.Add(syncBound)
 	for m.unissuedProposalTxs.Len() > 0 {
 		tx := m.unissuedProposalTxs.Remove()
-		m.unissuedTxIDs.Remove(tx.ID())
+		m.forgetTx(tx.ID())
 		utx := tx.UnsignedTx.(TimedTx)
 		startTime := utx.StartTime()
 		if syncTime.After(startTime) {
 			txID := tx.ID()
-			m.unissuedTxIDs.Remove(txID)
 			errMsg := fmt.Sprintf(
 				"synchrony bound (%s) is later than staker start time (%s)",
 				syncTime,
@@ -379,7 +682,7 @@ func (m *Mempool) ResetTimer() {
 		}
 		// If the tx doesn't meet the synchrony bound, drop it
 		txID := m.unissuedProposalTxs.Remove().ID()
-		m.unissuedTxIDs.Remove(txID)
+		m.forgetTx(txID)
 		errMsg := fmt.Sprintf(
 			"synchrony bound (%s) is later than staker start time (%s)",
 			syncTime,
@@ -0,0 +1,289 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/core"
+)
+
+// Ensure txs in the mempool are re-issued after a restart
+func TestMempoolPersisted(t *testing.T) {
+	_, genesisBytes := defaultGenesis()
+	db := memdb.New()
+
+	firstVM := &VM{
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		minStakeDuration:   defaultMinStakingDuration,
+		maxStakeDuration:   defaultMaxStakingDuration,
+		stakeMintingPeriod: defaultMaxStakingDuration,
+	}
+	firstVM.vdrMgr = validators.NewManager()
+	firstVM.clock.Set(defaultGenesisTime)
+	firstCtx := defaultContext()
+	firstCtx.Lock.Lock()
+
+	firstMsgChan := make(chan common.Message, 1)
+	if err := firstVM.Initialize(firstCtx, db, genesisBytes, firstMsgChan, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := firstVM.newCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := firstVM.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := firstVM.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	firstCtx.Lock.Unlock()
+
+	secondVM := &VM{
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		minStakeDuration:   defaultMinStakingDuration,
+		maxStakeDuration:   defaultMaxStakingDuration,
+		stakeMintingPeriod: defaultMaxStakingDuration,
+	}
+	secondVM.vdrMgr = validators.NewManager()
+	secondVM.clock.Set(defaultGenesisTime)
+	secondCtx := defaultContext()
+	secondCtx.Lock.Lock()
+	defer func() {
+		if err := secondVM.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		secondCtx.Lock.Unlock()
+	}()
+
+	secondMsgChan := make(chan common.Message, 1)
+	if err := secondVM.Initialize(secondCtx, db, genesisBytes, secondMsgChan, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	pending := secondVM.mempool.pendingTxs()
+	if len(pending) != 1 {
+		t.Fatalf("expected 1 tx in the mempool but got %d", len(pending))
+	} else if pending[0].tx.ID() != tx.ID() {
+		t.Fatalf("expected tx %s in the mempool but got %s", tx.ID(), pending[0].tx.ID())
+	} else if pending[0].reason == "" {
+		t.Fatal("should have reported why the tx is waiting")
+	}
+
+	// Once the tx is put into a block, it's no longer persisted
+	blk, err := secondVM.mempool.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	} else if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if txs, err := secondVM.getMempoolTxs(secondVM.DB); err != nil {
+		t.Fatal(err)
+	} else if len(txs) != 0 {
+		t.Fatalf("expected no persisted mempool txs but got %d", len(txs))
+	}
+}
+
+// Ensure txs issued to the node are gossiped and valid gossiped txs are added
+// to the mempool without being gossiped again
+func TestMempoolGossip(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	var gossiped [][]byte
+	sender := &common.SenderTest{T: t}
+	sender.SendAppGossipF = func(msg []byte) { gossiped = append(gossiped, msg) }
+	vm.SetAppSender(sender)
+
+	tx, err := vm.newCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	if len(gossiped) != 1 {
+		t.Fatalf("expected the tx to be gossiped once but was gossiped %d times", len(gossiped))
+	} else if !bytes.Equal(gossiped[0], tx.Bytes()) {
+		t.Fatal("gossiped the wrong bytes")
+	}
+
+	// Issuing the same tx again is a no-op
+	if err := vm.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	} else if len(gossiped) != 1 {
+		t.Fatal("shouldn't have gossiped a tx that's already in the mempool")
+	}
+
+	gossipedTx, err := vm.newCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[1].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unparsable messages are dropped
+	if err := vm.AppGossip(keys[2].PublicKey().Address(), []byte{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Txs without credentials are dropped
+	unsignedTx := &Tx{UnsignedTx: gossipedTx.UnsignedTx}
+	if err := unsignedTx.Sign(vm.codec, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.AddGossipedTx(keys[2].PublicKey().Address(), unsignedTx); err == nil {
+		t.Fatal("should have dropped the tx because it isn't signed")
+	}
+
+	// Txs that fail verification aren't gossiped
+	if err := vm.mempool.IssueTx(unsignedTx); err == nil {
+		t.Fatal("should have refused to issue the tx because it isn't signed")
+	} else if len(gossiped) != 1 {
+		t.Fatal("shouldn't have gossiped a tx that failed verification")
+	}
+
+	if err := vm.AppGossip(keys[2].PublicKey().Address(), gossipedTx.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := vm.mempool.unissuedTxs[gossipedTx.ID()]; !ok {
+		t.Fatal("should have added the gossiped tx to the mempool")
+	} else if len(gossiped) != 1 {
+		t.Fatal("shouldn't have gossiped a tx that was gossiped to the node")
+	}
+}
+
+// Ensure the txs that were added the longest ago are evicted first
+func TestMempoolEviction(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	txs := make([]*Tx, 2)
+	for i := range txs {
+		tx, err := vm.newCreateSubnetTx(
+			1, // threshold
+			[]ids.ShortID{keys[i].PublicKey().Address()},
+			[]*crypto.PrivateKeySECP256K1R{keys[i]},
+			ids.ShortEmpty, // change addr
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := vm.mempool.IssueTx(tx); err != nil {
+			t.Fatal(err)
+		}
+		txs[i] = tx
+	}
+	size := len(txs[0].Bytes()) + len(txs[1].Bytes())
+	if vm.mempool.unissuedTxsSize != size {
+		t.Fatalf("expected the mempool to hold %d bytes but holds %d", size, vm.mempool.unissuedTxsSize)
+	}
+
+	vm.mempool.evictOldest()
+	if _, ok := vm.mempool.unissuedTxs[txs[0].ID()]; ok {
+		t.Fatal("should have evicted the oldest tx")
+	} else if _, ok := vm.mempool.unissuedTxs[txs[1].ID()]; !ok {
+		t.Fatal("shouldn't have evicted the newest tx")
+	} else if len(vm.mempool.unissuedDecisionTxs) != 1 {
+		t.Fatalf("expected 1 unissued decision tx but got %d", len(vm.mempool.unissuedDecisionTxs))
+	} else if oldest := vm.mempool.unissuedTxOrder.Front().Value.(ids.ID); oldest != txs[1].ID() {
+		t.Fatalf("expected %s to be the oldest tx but got %s", txs[1].ID(), oldest)
+	} else if _, ok := vm.droppedTxCache.Get(txs[0].ID()); !ok {
+		t.Fatal("should have recorded why the tx was dropped")
+	}
+	if txs, err := vm.getMempoolTxs(vm.DB); err != nil {
+		t.Fatal(err)
+	} else if len(txs) != 1 {
+		t.Fatalf("expected 1 persisted mempool tx but got %d", len(txs))
+	}
+
+	// A gossiped tx that was dropped isn't added again
+	if err := vm.mempool.AddGossipedTx(keys[2].PublicKey().Address(), txs[0]); err == nil {
+		t.Fatal("should have refused a recently dropped tx")
+	}
+}
+
+// Ensure txs a peer gossips beyond its budget are dropped until the budget is
+// reset
+func TestMempoolGossipBudget(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	tx, err := vm.newCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodeID := keys[2].PublicKey().Address()
+	for i := 0; i < gossipedTxsPerPeer; i++ {
+		if !vm.mempool.spendGossipBudget(nodeID) {
+			t.Fatalf("should have allowed gossiped tx %d", i)
+		}
+	}
+	if err := vm.mempool.AddGossipedTx(nodeID, tx); err != errGossipBudget {
+		t.Fatalf("expected %s but got %v", errGossipBudget, err)
+	} else if _, ok := vm.mempool.unissuedTxs[tx.ID()]; ok {
+		t.Fatal("shouldn't have added a tx gossiped beyond the budget")
+	}
+
+	// Other peers have their own budget
+	if !vm.mempool.spendGossipBudget(keys[1].PublicKey().Address()) {
+		t.Fatal("should have allowed a tx gossiped by another peer")
+	}
+
+	vm.clock.Set(vm.clock.Time().Add(gossipBudgetPeriod))
+	if err := vm.mempool.AddGossipedTx(nodeID, tx); err != nil {
+		t.Fatal(err)
+	} else if _, ok := vm.mempool.unissuedTxs[tx.ID()]; !ok {
+		t.Fatal("should have added the gossiped tx once the budget was reset")
+	}
+}
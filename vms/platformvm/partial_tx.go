@@ -0,0 +1,220 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errPartialTxType      = errors.New("transaction type doesn't support partial signing")
+	errPartialInputType   = errors.New("only secp256k1fx inputs can be partially signed")
+	errPartialCredType    = errors.New("only secp256k1fx credentials can be partially signed")
+	errPartialOutputType  = errors.New("only secp256k1fx outputs can be partially signed")
+	errWrongNumberOfCreds = errors.New("transaction has the wrong number of credentials")
+)
+
+// credentialInputs returns the input that each credential of [utx] authorizes.
+// Transactions that are also authorized by a subnet's owners aren't
+// supported.
+func credentialInputs(utx UnsignedTx) ([]*avax.TransferableInput, error) {
+	switch utx := utx.(type) {
+	case *UnsignedAddValidatorTx:
+		return utx.Ins, nil
+	case *UnsignedAddDelegatorTx:
+		return utx.Ins, nil
+	case *UnsignedCreateSubnetTx:
+		return utx.Ins, nil
+	case *UnsignedExportTx:
+		return utx.Ins, nil
+	case *UnsignedImportTx:
+		ins := make([]*avax.TransferableInput, 0, len(utx.Ins)+len(utx.ImportedInputs))
+		ins = append(ins, utx.Ins...)
+		return append(ins, utx.ImportedInputs...), nil
+	default:
+		return nil, errPartialTxType
+	}
+}
+
+// secpInput returns the secp256k1fx input that [in] is spent with
+func secpInput(in *avax.TransferableInput) (*secp256k1fx.Input, error) {
+	inIntf := in.In
+	if lockedIn, ok := inIntf.(*StakeableLockIn); ok {
+		inIntf = lockedIn.TransferableIn
+	}
+	transferIn, ok := inIntf.(*secp256k1fx.TransferInput)
+	if !ok {
+		return nil, errPartialInputType
+	}
+	return &transferIn.Input, nil
+}
+
+// secpOwners returns the owners of [utxo]
+func secpOwners(utxo *avax.UTXO) (*secp256k1fx.OutputOwners, error) {
+	outIntf := utxo.Out
+	if lockedOut, ok := outIntf.(*StakeableLockOut); ok {
+		outIntf = lockedOut.TransferableOut
+	}
+	out, ok := outIntf.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return nil, errPartialOutputType
+	}
+	return &out.OutputOwners, nil
+}
+
+// newUnsignedTx returns [utx] with a credential for each of its inputs that
+// doesn't contain any signatures yet
+func (vm *VM) newUnsignedTx(utx UnsignedTx) (*Tx, error) {
+	ins, err := credentialInputs(utx)
+	if err != nil {
+		return nil, err
+	}
+	tx := &Tx{UnsignedTx: utx}
+	for _, in := range ins {
+		secpIn, err := secpInput(in)
+		if err != nil {
+			return nil, err
+		}
+		tx.Creds = append(tx.Creds, secp256k1fx.NewUnsignedCredential(secpIn))
+	}
+	// Signing without any signers sets the bytes of the transaction
+	return tx, tx.Sign(vm.codec, nil)
+}
+
+// spentUTXOs returns the UTXOs consumed by [ins] in [db]. The last
+// [numImported] inputs consume UTXOs that were exported from [sourceChain].
+// If a UTXO can't be fetched, the UTXOs fetched before it are returned with
+// the error.
+func (vm *VM) spentUTXOs(db database.Database, ins []*avax.TransferableInput, sourceChain ids.ID, numImported int) ([]*avax.UTXO, error) {
+	numLocal := len(ins) - numImported
+	utxos := make([]*avax.UTXO, 0, len(ins))
+	for _, in := range ins[:numLocal] {
+		utxoID := in.InputID()
+		utxo, err := vm.getUTXO(db, utxoID)
+		if err != nil {
+			return utxos, fmt.Errorf("problem fetching UTXO %s: %w", utxoID, err)
+		}
+		utxos = append(utxos, utxo)
+	}
+
+	if numImported > 0 {
+		utxoIDs := make([][]byte, numImported)
+		for i, in := range ins[numLocal:] {
+			utxoID := in.InputID()
+			utxoIDs[i] = utxoID[:]
+		}
+		allUTXOBytes, err := vm.Ctx.SharedMemory.Get(sourceChain, utxoIDs)
+		if err != nil {
+			return utxos, fmt.Errorf("problem fetching imported UTXOs: %w", err)
+		}
+		for _, utxoBytes := range allUTXOBytes {
+			utxo := &avax.UTXO{}
+			if _, err := vm.codec.Unmarshal(utxoBytes, utxo); err != nil {
+				return utxos, err
+			}
+			utxos = append(utxos, utxo)
+		}
+	}
+	return utxos, nil
+}
+
+// spentOwners returns the owners of the UTXOs consumed by [ins]. The last
+// [numImported] inputs consume UTXOs that were exported from [sourceChain].
+func (vm *VM) spentOwners(ins []*avax.TransferableInput, sourceChain ids.ID, numImported int) ([]*secp256k1fx.OutputOwners, error) {
+	utxos, err := vm.spentUTXOs(vm.DB, ins, sourceChain, numImported)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make([]*secp256k1fx.OutputOwners, len(utxos))
+	for i, utxo := range utxos {
+		utxoOwners, err := secpOwners(utxo)
+		if err != nil {
+			return nil, err
+		}
+		owners[i] = utxoOwners
+	}
+	return owners, nil
+}
+
+// partialTxSpends returns, for each credential of [tx], the input it
+// authorizes, the owners of the UTXO the input consumes, and the credential
+func (vm *VM) partialTxSpends(tx *Tx) ([]*secp256k1fx.Input, []*secp256k1fx.OutputOwners, []*secp256k1fx.Credential, error) {
+	ins, err := credentialInputs(tx.UnsignedTx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(ins) != len(tx.Creds) {
+		return nil, nil, nil, errWrongNumberOfCreds
+	}
+
+	sourceChain := ids.Empty
+	numImported := 0
+	if importTx, ok := tx.UnsignedTx.(*UnsignedImportTx); ok {
+		sourceChain = importTx.SourceChain
+		numImported = len(importTx.ImportedInputs)
+	}
+	owners, err := vm.spentOwners(ins, sourceChain, numImported)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	secpIns := make([]*secp256k1fx.Input, len(ins))
+	creds := make([]*secp256k1fx.Credential, len(ins))
+	for i, in := range ins {
+		secpIns[i], err = secpInput(in)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cred, ok := tx.Creds[i].(*secp256k1fx.Credential)
+		if !ok {
+			return nil, nil, nil, errPartialCredType
+		}
+		creds[i] = cred
+	}
+	return secpIns, owners, creds, nil
+}
+
+// sigSlots returns the signatures each credential of [tx] must contain
+func (vm *VM) sigSlots(tx *Tx) ([][]secp256k1fx.SigSlot, error) {
+	ins, owners, creds, err := vm.partialTxSpends(tx)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([][]secp256k1fx.SigSlot, len(ins))
+	for i, in := range ins {
+		slots[i], err = secp256k1fx.SigSlots(in, owners[i], creds[i])
+		if err != nil {
+			return nil, fmt.Errorf("credential %d: %w", i, err)
+		}
+	}
+	return slots, nil
+}
+
+// signPartialTx adds to [tx] every missing signature that [kc] can provide.
+// Returns the number of signatures that were added.
+func (vm *VM) signPartialTx(tx *Tx, kc *secp256k1fx.Keychain) (int, error) {
+	ins, owners, creds, err := vm.partialTxSpends(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	hash := hashing.ComputeHash256(tx.UnsignedBytes())
+	numSigned := 0
+	for i, in := range ins {
+		n, err := kc.SignCredential(hash, in, owners[i], creds[i])
+		if err != nil {
+			return 0, fmt.Errorf("credential %d: %w", i, err)
+		}
+		numSigned += n
+	}
+	return numSigned, tx.Sign(vm.codec, nil)
+}
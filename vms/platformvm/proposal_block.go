@@ -163,6 +163,9 @@ func (pb *ProposalBlock) Verify() error {
 		return fmt.Errorf("failed to put status of tx %s: %w", txID, err)
 	}
 
+	// The tx no longer needs to be put into a block
+	pb.vm.mempool.RemoveTxs(&pb.Tx)
+
 	pb.vm.currentBlocks[pb.ID()] = pb
 	parentIntf.addChild(pb)
 	return nil
@@ -0,0 +1,279 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	errRemovePrimaryNetworkValidator = errors.New("can't remove primary network validators")
+	errNotSubnetValidator            = errors.New("node isn't a validator of the subnet")
+	errRemovalOutsideValidation      = errors.New("removal time isn't within the validator's staking period")
+	errRemovalAlreadyPending         = errors.New("a removal of the validator is already pending")
+
+	_ UnsignedProposalTx = &UnsignedRemoveSubnetValidatorTx{}
+	_ TimedTx            = &UnsignedRemoveSubnetValidatorTx{}
+)
+
+// UnsignedRemoveSubnetValidatorTx is an unsigned transaction that removes a
+// validator from a subnet before its end time
+type UnsignedRemoveSubnetValidatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the node to remove
+	NodeID ids.ShortID `serialize:"true" json:"nodeID"`
+	// ID of the subnet the node is removed from
+	Subnet ids.ID `serialize:"true" json:"subnet"`
+	// Unix time the validator is removed at
+	Time uint64 `serialize:"true" json:"time"`
+	// Proves that the issuer has the right to remove the validator
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+// StartTime is the time the validator is removed at
+func (tx *UnsignedRemoveSubnetValidatorTx) StartTime() time.Time {
+	return time.Unix(int64(tx.Time), 0)
+}
+
+// EndTime is the time the validator is removed at
+func (tx *UnsignedRemoveSubnetValidatorTx) EndTime() time.Time {
+	return tx.StartTime()
+}
+
+// Weight is 0 as this tx doesn't add a staker
+func (tx *UnsignedRemoveSubnetValidatorTx) Weight() uint64 {
+	return 0
+}
+
+// Verify return nil iff [tx] is valid
+func (tx *UnsignedRemoveSubnetValidatorTx) Verify(
+	ctx *snow.Context,
+	c codec.Manager,
+	feeAmount uint64,
+	feeAssetID ids.ID,
+) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.syntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errRemovePrimaryNetworkValidator
+	}
+
+	if err := tx.BaseTx.Verify(ctx, c); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.syntacticallyVerified = true
+	return nil
+}
+
+// SemanticVerify this transaction is valid.
+func (tx *UnsignedRemoveSubnetValidatorTx) SemanticVerify(
+	vm *VM,
+	db database.Database,
+	stx *Tx,
+) (
+	*versiondb.Database,
+	*versiondb.Database,
+	func() error,
+	func() error,
+	TxError,
+) {
+	// Verify the tx is well-formed
+	if len(stx.Creds) == 0 {
+		return nil, nil, nil, nil, permError{errWrongNumberOfCredentials}
+	}
+	if err := tx.Verify(vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID); err != nil {
+		return nil, nil, nil, nil, permError{err}
+	}
+
+	// Ensure the removal happens after the current timestamp
+	removalTime := tx.StartTime()
+	if currentTimestamp, err := vm.getTimestamp(db); err != nil {
+		return nil, nil, nil, nil, tempError{fmt.Errorf("couldn't get current timestamp: %v", err)}
+	} else if !currentTimestamp.Before(removalTime) {
+		return nil, nil, nil, nil, permError{fmt.Errorf("removal time (%s) is at or before current chain timestamp (%s)",
+			removalTime,
+			currentTimestamp)}
+	} else if removalTime.After(currentTimestamp.Add(maxFutureStartTime)) {
+		return nil, nil, nil, nil, permError{fmt.Errorf("removal time (%s) more than two weeks after current chain timestamp (%s)", removalTime, currentTimestamp)}
+	}
+
+	// Ensure the node validates the subnet when it's removed
+	vdr, isValidator, err := vm.isValidator(db, tx.Subnet, tx.NodeID)
+	if err != nil {
+		return nil, nil, nil, nil, tempError{err}
+	}
+	if !isValidator {
+		vdr, isValidator, err = vm.willBeValidator(db, tx.Subnet, tx.NodeID)
+		if err != nil {
+			return nil, nil, nil, nil, tempError{err}
+		}
+	}
+	if !isValidator {
+		return nil, nil, nil, nil, permError{errNotSubnetValidator}
+	}
+	if removalTime.Before(vdr.StartTime()) || !removalTime.Before(vdr.EndTime()) {
+		return nil, nil, nil, nil, permError{errRemovalOutsideValidation}
+	}
+
+	removals, err := vm.pendingSubnetValidatorRemovals(db, tx.Subnet)
+	if err != nil {
+		return nil, nil, nil, nil, tempError{err}
+	}
+	if _, ok := removals[tx.NodeID.Key()]; ok {
+		return nil, nil, nil, nil, permError{errRemovalAlreadyPending}
+	}
+
+	baseTxCredsLen := len(stx.Creds) - 1
+	baseTxCreds := stx.Creds[:baseTxCredsLen]
+	subnetCred := stx.Creds[baseTxCredsLen]
+
+	owner, timedErr := vm.getSubnetOwner(db, tx.Subnet)
+	if timedErr != nil {
+		return nil, nil, nil, nil, timedErr
+	}
+	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, owner); err != nil {
+		return nil, nil, nil, nil, permError{err}
+	}
+
+	// Verify the flowcheck
+	if err := vm.semanticVerifySpend(db, tx, tx.Ins, tx.Outs, baseTxCreds, vm.txFee, vm.Ctx.AVAXAssetID); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	txID := tx.ID()
+
+	// Set up the DB if this tx is committed
+	onCommitDB := versiondb.New(db)
+	// Consume the UTXOS
+	if err := vm.consumeInputs(onCommitDB, tx.Ins); err != nil {
+		return nil, nil, nil, nil, tempError{err}
+	}
+	// Produce the UTXOS
+	if err := vm.produceOutputs(onCommitDB, txID, tx.Outs); err != nil {
+		return nil, nil, nil, nil, tempError{err}
+	}
+	// Schedule the removal of the validator
+	if err := vm.enqueueStaker(onCommitDB, tx.Subnet, stx); err != nil {
+		return nil, nil, nil, nil, tempError{err}
+	}
+
+	onAbortDB := versiondb.New(db)
+	// Consume the UTXOS
+	if err := vm.consumeInputs(onAbortDB, tx.Ins); err != nil {
+		return nil, nil, nil, nil, tempError{err}
+	}
+	// Produce the UTXOS
+	if err := vm.produceOutputs(onAbortDB, txID, tx.Outs); err != nil {
+		return nil, nil, nil, nil, tempError{err}
+	}
+
+	return onCommitDB, onAbortDB, nil, nil, nil
+}
+
+// InitiallyPrefersCommit returns true if the removal time is after the current
+// wall clock time
+func (tx *UnsignedRemoveSubnetValidatorTx) InitiallyPrefersCommit(vm *VM) bool {
+	return tx.StartTime().After(vm.clock.Time())
+}
+
+// pendingSubnetValidatorRemovals returns the time each validator of subnet
+// [subnetID] with a pending removal is removed at, keyed by node ID
+func (vm *VM) pendingSubnetValidatorRemovals(db database.Database, subnetID ids.ID) (map[[20]byte]time.Time, error) {
+	removals := make(map[[20]byte]time.Time)
+
+	startPrefix := []byte(fmt.Sprintf("%s%s", subnetID, startDBPrefix))
+	startDB := prefixdb.NewNested(startPrefix, db)
+	defer startDB.Close()
+
+	startIter := startDB.NewIterator()
+	defer startIter.Release()
+
+	for startIter.Next() { // Iterates in order of increasing start time
+		tx := Tx{}
+		if _, err := vm.codec.Unmarshal(startIter.Value(), &tx); err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal validator tx: %w", err)
+		}
+		if removal, ok := tx.UnsignedTx.(*UnsignedRemoveSubnetValidatorTx); ok {
+			removals[removal.NodeID.Key()] = removal.StartTime()
+		}
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		startIter.Error(),
+		startDB.Close(),
+	)
+	return removals, errs.Err
+}
+
+// currentSubnetValidator returns the tx that added [nodeID] to the current
+// validators of subnet [subnetID]
+func (vm *VM) currentSubnetValidator(db database.Database, subnetID ids.ID, nodeID ids.ShortID) (*rewardTx, bool, error) {
+	stopPrefix := []byte(fmt.Sprintf("%s%s", subnetID, stopDBPrefix))
+	stopDB := prefixdb.NewNested(stopPrefix, db)
+	defer stopDB.Close()
+
+	stopIter := stopDB.NewIterator()
+	defer stopIter.Release()
+
+	for stopIter.Next() {
+		tx := rewardTx{}
+		if _, err := vm.codec.Unmarshal(stopIter.Value(), &tx); err != nil {
+			return nil, false, fmt.Errorf("couldn't unmarshal validator tx: %w", err)
+		}
+		vdr, ok := tx.Tx.UnsignedTx.(*UnsignedAddSubnetValidatorTx)
+		if !ok || !vdr.Validator.NodeID.Equals(nodeID) {
+			continue
+		}
+		if err := tx.Tx.Sign(vm.codec, nil); err != nil {
+			return nil, false, err
+		}
+		return &tx, true, nil
+	}
+	return nil, false, stopIter.Error()
+}
+
+// Create a new transaction
+func (vm *VM) newRemoveSubnetValidatorTx(
+	nodeID ids.ShortID, // ID of the node to remove
+	subnetID ids.ID, // ID of the subnet the node is removed from
+	removalTime uint64, // Unix time the node is removed at
+	keys []*crypto.PrivateKeySECP256K1R, // Keys to use for removing the validator
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	kc, utxos, err := vm.keychainUTXOs(vm.DB, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	subnetOwner, err := vm.subnetOwner(vm.DB, subnetID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	tx, err := vm.txBuilder().NewRemoveSubnetValidatorTx(utxos, kc, nodeID, subnetID, removalTime, subnetOwner, changeAddr)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.UnsignedTx.(*UnsignedRemoveSubnetValidatorTx).Verify(vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID)
+}
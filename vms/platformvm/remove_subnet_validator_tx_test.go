@@ -0,0 +1,185 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+)
+
+func TestUnsignedRemoveSubnetValidatorTxVerify(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	tx, err := vm.newRemoveSubnetValidatorTx(
+		keys[0].PublicKey().Address(),
+		testSubnet1.ID(),
+		uint64(defaultGenesisTime.Add(time.Hour).Unix()),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	utx := tx.UnsignedTx.(*UnsignedRemoveSubnetValidatorTx)
+	utx.syntacticallyVerified = false
+	utx.Subnet = constants.PrimaryNetworkID
+	if err := utx.Verify(vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID); err == nil {
+		t.Fatal("should have failed because primary network validators can't be removed")
+	}
+}
+
+// Ensure a subnet validator is removed at the time of its removal, and only
+// once it's accepted
+func TestRemoveSubnetValidatorTx(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	// keys[0] is a genesis validator
+	nodeID := keys[0].PublicKey().Address()
+	subnetKeys := []*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]}
+	startTime := defaultGenesisTime.Add(time.Second)
+	removalTime := startTime.Add(time.Hour)
+
+	// Case: The node doesn't validate the subnet
+	if tx, err := vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		uint64(removalTime.Unix()),
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	); err != nil {
+		t.Fatal(err)
+	} else if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.DB, tx); err == nil {
+		t.Fatal("should have failed because the node doesn't validate the subnet")
+	}
+
+	addTx, err := vm.newAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(startTime.Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		nodeID,
+		testSubnet1.ID(),
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	onCommitDB, _, _, _, err := addTx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.DB, addTx)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := onCommitDB.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Case: The removal happens after the validator stops validating
+	if tx, err := vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		uint64(defaultValidateEndTime.Unix()),
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	); err != nil {
+		t.Fatal(err)
+	} else if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.DB, tx); err == nil {
+		t.Fatal("should have failed because the validator already stopped validating")
+	}
+
+	// Case: Valid removal of a pending validator
+	removeTx, err := vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		uint64(removalTime.Unix()),
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	onCommitDB, onAbortDB, _, _, err := removeTx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.DB, removeTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removals, err := vm.pendingSubnetValidatorRemovals(onAbortDB, testSubnet1.ID()); err != nil {
+		t.Fatal(err)
+	} else if len(removals) != 0 {
+		t.Fatal("the removal shouldn't be pending if the tx is aborted")
+	}
+	if err := onCommitDB.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if removals, err := vm.pendingSubnetValidatorRemovals(vm.DB, testSubnet1.ID()); err != nil {
+		t.Fatal(err)
+	} else if !removals[nodeID.Key()].Equal(removalTime) {
+		t.Fatalf("expected the validator to be removed at %s", removalTime)
+	}
+
+	// Case: The validator is already being removed
+	if tx, err := vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		uint64(removalTime.Unix())+1,
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	); err != nil {
+		t.Fatal(err)
+	} else if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.DB, tx); err == nil {
+		t.Fatal("should have failed because the validator's removal is already pending")
+	}
+
+	if nextChangeTime, err := vm.nextStakerChangeTime(vm.DB); err != nil {
+		t.Fatal(err)
+	} else if !nextChangeTime.Equal(startTime) {
+		t.Fatalf("expected the next staker change at %s but got %s", startTime, nextChangeTime)
+	}
+
+	// The validator starts validating the subnet
+	if err := vm.updateSubnetValidators(vm.DB, testSubnet1.ID(), startTime); err != nil {
+		t.Fatal(err)
+	}
+	if _, isValidator, err := vm.isValidator(vm.DB, testSubnet1.ID(), nodeID); err != nil {
+		t.Fatal(err)
+	} else if !isValidator {
+		t.Fatal("should be validating the subnet")
+	}
+
+	if nextChangeTime, err := vm.nextStakerChangeTime(vm.DB); err != nil {
+		t.Fatal(err)
+	} else if !nextChangeTime.Equal(removalTime) {
+		t.Fatalf("expected the next staker change at %s but got %s", removalTime, nextChangeTime)
+	}
+
+	// The validator is removed before its end time
+	if err := vm.updateSubnetValidators(vm.DB, testSubnet1.ID(), removalTime); err != nil {
+		t.Fatal(err)
+	}
+	if _, isValidator, err := vm.isValidator(vm.DB, testSubnet1.ID(), nodeID); err != nil {
+		t.Fatal(err)
+	} else if isValidator {
+		t.Fatal("should have been removed from the subnet")
+	}
+	if removals, err := vm.pendingSubnetValidatorRemovals(vm.DB, testSubnet1.ID()); err != nil {
+		t.Fatal(err)
+	} else if len(removals) != 0 {
+		t.Fatal("the removal shouldn't be pending once it happened")
+	}
+}
@@ -6,6 +6,8 @@ package platformvm
 import (
 	"math/big"
 	"time"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 var (
@@ -60,3 +62,16 @@ func Reward(
 
 	return reward.Uint64()
 }
+
+// splitReward returns how the [reward] of a delegator is split between the
+// delegator and the validator it delegates to, which takes [shares] out of
+// every PercentDenominator of the reward.
+func splitReward(reward uint64, shares uint32) (delegatorReward uint64, validatorReward uint64) {
+	delegatorShares := PercentDenominator - uint64(shares)            // shares <= PercentDenominator so no underflow
+	delegatorReward = delegatorShares * (reward / PercentDenominator) // delegatorShares <= PercentDenominator so no overflow
+	// Delay rounding as long as possible for small numbers
+	if optimisticReward, err := safemath.Mul64(delegatorShares, reward); err == nil {
+		delegatorReward = optimisticReward / PercentDenominator
+	}
+	return delegatorReward, reward - delegatorReward // delegatorReward <= reward so no underflow
+}
@@ -5,6 +5,7 @@ package platformvm
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -119,3 +120,30 @@ func TestRewards(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitReward(t *testing.T) {
+	tests := []struct {
+		reward                  uint64
+		shares                  uint32
+		expectedDelegatorReward uint64
+		expectedValidatorReward uint64
+	}{
+		{reward: 1000, shares: 0, expectedDelegatorReward: 1000, expectedValidatorReward: 0},
+		{reward: 1000, shares: PercentDenominator, expectedDelegatorReward: 0, expectedValidatorReward: 1000},
+		{reward: 1000, shares: PercentDenominator / 4, expectedDelegatorReward: 750, expectedValidatorReward: 250},
+		{reward: 3, shares: PercentDenominator / 2, expectedDelegatorReward: 1, expectedValidatorReward: 2},
+		{reward: math.MaxUint64, shares: 0, expectedDelegatorReward: math.MaxUint64 - math.MaxUint64%PercentDenominator, expectedValidatorReward: math.MaxUint64 % PercentDenominator},
+	}
+	for _, test := range tests {
+		name := fmt.Sprintf("splitReward(%d,%d)", test.reward, test.shares)
+		t.Run(name, func(t *testing.T) {
+			delegatorReward, validatorReward := splitReward(test.reward, test.shares)
+			if delegatorReward != test.expectedDelegatorReward {
+				t.Fatalf("expected delegator reward %d; got %d", test.expectedDelegatorReward, delegatorReward)
+			}
+			if validatorReward != test.expectedValidatorReward {
+				t.Fatalf("expected validator reward %d; got %d", test.expectedValidatorReward, validatorReward)
+			}
+		})
+	}
+}
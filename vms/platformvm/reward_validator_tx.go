@@ -120,6 +120,10 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 	var (
 		nodeID    ids.ShortID
 		startTime time.Time
+		// UTXOs created whether the staker is rewarded or not
+		stakeUTXOs []*avax.UTXO
+		// UTXOs created only if the staker is rewarded
+		rewardUTXOs []*avax.UTXO
 	)
 	switch uStakerTx := stakerTx.Tx.UnsignedTx.(type) {
 	case *UnsignedAddValidatorTx:
@@ -144,6 +148,7 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 					fmt.Errorf("failed to put UTXO: %w", err),
 				}
 			}
+			stakeUTXOs = append(stakeUTXOs, utxo)
 		}
 
 		// Provide the reward here
@@ -158,18 +163,20 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 			if !ok {
 				return nil, nil, nil, nil, permError{errInvalidState}
 			}
-			if err := vm.putUTXO(onCommitDB, &avax.UTXO{
+			utxo := &avax.UTXO{
 				UTXOID: avax.UTXOID{
 					TxID:        tx.TxID,
 					OutputIndex: uint32(len(uStakerTx.Outs) + len(uStakerTx.Stake)),
 				},
 				Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
 				Out:   out,
-			}); err != nil {
+			}
+			if err := vm.putUTXO(onCommitDB, utxo); err != nil {
 				return nil, nil, nil, nil, tempError{
 					fmt.Errorf("failed to create output: %w", err),
 				}
 			}
+			rewardUTXOs = append(rewardUTXOs, utxo)
 
 			currentSupply, err := vm.getCurrentSupply(onAbortDB)
 			if err != nil {
@@ -244,6 +251,7 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 					fmt.Errorf("failed to put UTXO: %w", err),
 				}
 			}
+			stakeUTXOs = append(stakeUTXOs, utxo)
 		}
 
 		currentSupply, err := vm.getCurrentSupply(onAbortDB)
@@ -264,13 +272,7 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 
 		// Calculate split of reward between delegator/delegatee
 		// The delegator gives stake to the validatee
-		delegatorShares := PercentDenominator - uint64(vdr.Shares)                  // parentTx.Shares <= NumberOfShares so no underflow
-		delegatorReward := delegatorShares * (stakerTx.Reward / PercentDenominator) // delegatorShares <= NumberOfShares so no overflow
-		// Delay rounding as long as possible for small numbers
-		if optimisticReward, err := safemath.Mul64(delegatorShares, stakerTx.Reward); err == nil {
-			delegatorReward = optimisticReward / PercentDenominator
-		}
-		delegateeReward := stakerTx.Reward - delegatorReward // delegatorReward <= reward so no underflow
+		delegatorReward, delegateeReward := splitReward(stakerTx.Reward, vdr.Shares)
 
 		offset := 0
 
@@ -286,18 +288,20 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 			if !ok {
 				return nil, nil, nil, nil, permError{errInvalidState}
 			}
-			if err := vm.putUTXO(onCommitDB, &avax.UTXO{
+			utxo := &avax.UTXO{
 				UTXOID: avax.UTXOID{
 					TxID:        tx.TxID,
 					OutputIndex: uint32(len(uStakerTx.Outs) + len(uStakerTx.Stake)),
 				},
 				Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
 				Out:   out,
-			}); err != nil {
+			}
+			if err := vm.putUTXO(onCommitDB, utxo); err != nil {
 				return nil, nil, nil, nil, tempError{
 					fmt.Errorf("failed to put UTXO: %w", err),
 				}
 			}
+			rewardUTXOs = append(rewardUTXOs, utxo)
 
 			offset++
 		}
@@ -314,18 +318,20 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 			if !ok {
 				return nil, nil, nil, nil, permError{errInvalidState}
 			}
-			if err := vm.putUTXO(onCommitDB, &avax.UTXO{
+			utxo := &avax.UTXO{
 				UTXOID: avax.UTXOID{
 					TxID:        tx.TxID,
 					OutputIndex: uint32(len(uStakerTx.Outs) + len(uStakerTx.Stake) + offset),
 				},
 				Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
 				Out:   out,
-			}); err != nil {
+			}
+			if err := vm.putUTXO(onCommitDB, utxo); err != nil {
 				return nil, nil, nil, nil, tempError{
 					fmt.Errorf("failed to put UTXO: %w", err),
 				}
 			}
+			rewardUTXOs = append(rewardUTXOs, utxo)
 		}
 		nodeID = uStakerTx.Validator.ID()
 		startTime = vdrTx.StartTime()
@@ -345,6 +351,29 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 		}
 	}
 
+	// Record what happens to the staker in each outcome, so it can be looked
+	// up once the staking period is over
+	onCommitOutcome := &stakingOutcome{
+		Rewarded: stakerTx.Reward > 0,
+		Reward:   stakerTx.Reward,
+		UTXOs:    append(stakeUTXOs, rewardUTXOs...),
+		Uptime:   uint64(uptime * PercentDenominator),
+	}
+	if err := vm.putStakingOutcome(onCommitDB, tx.TxID, onCommitOutcome); err != nil {
+		return nil, nil, nil, nil, tempError{
+			fmt.Errorf("failed to put staking outcome: %w", err),
+		}
+	}
+	onAbortOutcome := &stakingOutcome{
+		UTXOs:  stakeUTXOs,
+		Uptime: uint64(uptime * PercentDenominator),
+	}
+	if err := vm.putStakingOutcome(onAbortDB, tx.TxID, onAbortOutcome); err != nil {
+		return nil, nil, nil, nil, tempError{
+			fmt.Errorf("failed to put staking outcome: %w", err),
+		}
+	}
+
 	tx.shouldPreferCommit = uptime >= vm.uptimePercentage
 	return onCommitDB, onAbortDB, updateValidators, updateValidators, nil
 }
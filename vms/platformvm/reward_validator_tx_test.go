@@ -103,6 +103,53 @@ func TestUnsignedRewardValidatorTxSemanticVerify(t *testing.T) {
 	}
 }
 
+func TestRewardValidatorTxStakingOutcome(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	toRemoveIntf, err := vm.nextStakerStop(vm.DB, constants.PrimaryNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toRemove := toRemoveIntf.Tx.UnsignedTx.(*UnsignedAddValidatorTx)
+
+	if err := vm.putTimestamp(vm.DB, toRemove.EndTime()); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := vm.newRewardValidatorTx(toRemove.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	onCommitDB, onAbortDB, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.DB, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if outcome, err := vm.getStakingOutcome(onCommitDB, toRemove.ID()); err != nil {
+		t.Fatal(err)
+	} else if !outcome.Rewarded || outcome.Reward != 27 {
+		t.Fatalf("on commit, should have recorded a reward of 27")
+	} else if len(outcome.UTXOs) != len(toRemove.Stake)+1 {
+		t.Fatalf("on commit, should have recorded the stake and reward UTXOs")
+	}
+	if outcome, err := vm.getStakingOutcome(onAbortDB, toRemove.ID()); err != nil {
+		t.Fatal(err)
+	} else if outcome.Rewarded || outcome.Reward != 0 {
+		t.Fatalf("on abort, shouldn't have recorded a reward")
+	} else if len(outcome.UTXOs) != len(toRemove.Stake) {
+		t.Fatalf("on abort, should have recorded only the stake UTXOs")
+	}
+	if _, err := vm.getStakingOutcome(vm.DB, toRemove.ID()); err == nil {
+		t.Fatalf("shouldn't have recorded an outcome before the proposal is decided")
+	}
+}
+
 func TestRewardDelegatorTxSemanticVerify(t *testing.T) {
 	vm, _ := defaultVM(t)
 	vm.Ctx.Lock.Lock()
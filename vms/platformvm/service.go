@@ -43,6 +43,8 @@ var (
 	errInvalidDelegationRate = errors.New("argument 'delegationFeeRate' must be between 0 and 100, inclusive")
 	errNoAddresses           = errors.New("no addresses provided")
 	errNoKeys                = errors.New("user has no keys or funds")
+	errNoSigners             = errors.New("either a user or a private key must be provided")
+	errNoMissingSigs         = errors.New("provided keys can't add any missing signature")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -63,6 +65,46 @@ func (service *Service) GetHeight(r *http.Request, args *struct{}, response *Get
 	return nil
 }
 
+// GetBlockArgs are the arguments for calling GetBlock
+type GetBlockArgs struct {
+	BlockID ids.ID `json:"blockID"`
+}
+
+// GetBlockByHeightArgs are the arguments for calling GetBlockByHeight
+type GetBlockByHeightArgs struct {
+	Height json.Uint64 `json:"height"`
+}
+
+// GetBlockReply is the response from calling GetBlock or GetBlockByHeight
+type GetBlockReply struct {
+	Block *JSONBlock `json:"block"`
+}
+
+// GetBlock returns the decoded block with the given ID. Only accepted and
+// processing blocks are returned.
+func (service *Service) GetBlock(_ *http.Request, args *GetBlockArgs, reply *GetBlockReply) error {
+	service.vm.Ctx.Log.Info("Platform: GetBlock called with BlockID = %s", args.BlockID)
+
+	blk, err := service.vm.getBlock(args.BlockID)
+	if err != nil {
+		return fmt.Errorf("couldn't get block %s: %w", args.BlockID, err)
+	}
+	reply.Block, err = service.vm.blockJSON(blk)
+	return err
+}
+
+// GetBlockByHeight returns the decoded accepted block at the given height
+func (service *Service) GetBlockByHeight(_ *http.Request, args *GetBlockByHeightArgs, reply *GetBlockReply) error {
+	service.vm.Ctx.Log.Info("Platform: GetBlockByHeight called with Height = %d", args.Height)
+
+	blk, err := service.vm.getAcceptedBlock(uint64(args.Height))
+	if err != nil {
+		return err
+	}
+	reply.Block, err = service.vm.blockJSON(blk)
+	return err
+}
+
 // ExportKeyArgs are arguments for ExportKey
 type ExportKeyArgs struct {
 	api.UserPass
@@ -126,23 +168,10 @@ func (service *Service) ImportKey(r *http.Request, args *ImportKeyArgs, reply *a
 		return fmt.Errorf("keystore user has reached its limit of %d addresses", maxKeystoreAddresses)
 	}
 
-	if !strings.HasPrefix(args.PrivateKey, constants.SecretKeyPrefix) {
-		return fmt.Errorf("private key missing %s prefix", constants.SecretKeyPrefix)
-	}
-
-	trimmedPrivateKey := strings.TrimPrefix(args.PrivateKey, constants.SecretKeyPrefix)
-	privKeyBytes, err := formatting.Decode(formatting.CB58, trimmedPrivateKey)
-
-	if err != nil {
-		return fmt.Errorf("problem parsing private key: %w", err)
-	}
-
-	factory := crypto.FactorySECP256K1R{}
-	skIntf, err := factory.ToPrivateKey(privKeyBytes)
+	sk, err := parsePrivateKey(args.PrivateKey)
 	if err != nil {
-		return fmt.Errorf("problem parsing private key: %w", err)
+		return err
 	}
-	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
 
 	reply.Address, err = service.vm.FormatLocalAddress(sk.PublicKey().Address())
 	if err != nil {
@@ -155,6 +184,26 @@ func (service *Service) ImportKey(r *http.Request, args *ImportKeyArgs, reply *a
 	return db.Close()
 }
 
+// parsePrivateKey parses a private key in the format returned by ExportKey
+func parsePrivateKey(privateKey string) (*crypto.PrivateKeySECP256K1R, error) {
+	if !strings.HasPrefix(privateKey, constants.SecretKeyPrefix) {
+		return nil, fmt.Errorf("private key missing %s prefix", constants.SecretKeyPrefix)
+	}
+
+	trimmedPrivateKey := strings.TrimPrefix(privateKey, constants.SecretKeyPrefix)
+	privKeyBytes, err := formatting.Decode(formatting.CB58, trimmedPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing private key: %w", err)
+	}
+
+	factory := crypto.FactorySECP256K1R{}
+	skIntf, err := factory.ToPrivateKey(privKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing private key: %w", err)
+	}
+	return skIntf.(*crypto.PrivateKeySECP256K1R), nil
+}
+
 /*
  ******************************************************
  *************  Balances / Addresses ******************
@@ -508,8 +557,10 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 	if getAll {
 		response.Subnets = make([]APISubnet, len(subnets)+1)
 		for i, subnet := range subnets {
-			unsignedTx := subnet.UnsignedTx.(*UnsignedCreateSubnetTx)
-			owner := unsignedTx.Owner.(*secp256k1fx.OutputOwners)
+			owner, err := service.vm.subnetOwner(service.vm.DB, subnet.ID())
+			if err != nil {
+				return err
+			}
 			controlAddrs := []string{}
 			for _, controlKeyID := range owner.Addrs {
 				addr, err := service.vm.FormatLocalAddress(controlKeyID)
@@ -537,8 +588,10 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 	idsSet.Add(args.IDs...)
 	for _, subnet := range subnets {
 		if idsSet.Contains(subnet.ID()) {
-			unsignedTx := subnet.UnsignedTx.(*UnsignedCreateSubnetTx)
-			owner := unsignedTx.Owner.(*secp256k1fx.OutputOwners)
+			owner, err := service.vm.subnetOwner(service.vm.DB, subnet.ID())
+			if err != nil {
+				return err
+			}
 			controlAddrs := []string{}
 			for _, controlKeyID := range owner.Addrs {
 				addr, err := service.vm.FormatLocalAddress(controlKeyID)
@@ -619,6 +672,14 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 
 	// Validator's node ID as string --> Delegators to them
 	vdrTodelegators := map[string][]APIPrimaryDelegator{}
+	// Validator's node ID as string --> Shares of the delegators' rewards they
+	// receive
+	vdrToShares := map[string]uint32{}
+
+	removalTimes, err := service.subnetValidatorRemovalTimes(args.SubnetID)
+	if err != nil {
+		return err
+	}
 
 	stopPrefix := []byte(fmt.Sprintf("%s%s", args.SubnetID, stopDBPrefix))
 	stopDB := prefixdb.NewNested(stopPrefix, service.vm.DB)
@@ -684,6 +745,7 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 			uptime := json.Float32(rawUptime)
 
 			_, connected := service.vm.connections[nodeID.Key()]
+			vdrToShares[nodeID.PrefixedString(constants.NodeIDPrefix)] = staker.Shares
 
 			var rewardOwner *APIOwner
 			owner, ok := staker.RewardsOwner.(*secp256k1fx.OutputOwners)
@@ -717,12 +779,15 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 			})
 		case *UnsignedAddSubnetValidatorTx:
 			weight := json.Uint64(staker.Validator.Weight())
-			reply.Validators = append(reply.Validators, APIStaker{
-				TxID:      tx.Tx.ID(),
-				NodeID:    staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
-				StartTime: json.Uint64(staker.StartTime().Unix()),
-				EndTime:   json.Uint64(staker.EndTime().Unix()),
-				Weight:    &weight,
+			reply.Validators = append(reply.Validators, APISubnetValidator{
+				APIStaker: APIStaker{
+					TxID:      tx.Tx.ID(),
+					NodeID:    staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
+					StartTime: json.Uint64(staker.StartTime().Unix()),
+					EndTime:   json.Uint64(staker.EndTime().Unix()),
+					Weight:    &weight,
+				},
+				RemovalTime: removalTimes[staker.Validator.NodeID.Key()],
 			})
 		default:
 			return fmt.Errorf("expected validator but got %T", tx.Tx.UnsignedTx)
@@ -737,7 +802,24 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 		if !ok {
 			continue
 		}
-		if delegators, ok := vdrTodelegators[vdr.NodeID]; ok {
+		delegators := vdrTodelegators[vdr.NodeID]
+		if len(delegators) > 0 {
+			// The rewards of the delegators are split with the validator
+			shares := vdrToShares[vdr.NodeID]
+			delegationReward := uint64(0)
+			for j, delegator := range delegators {
+				delegatorReward, validatorReward := splitReward(uint64(*delegator.PotentialReward), shares)
+				potentialReward := json.Uint64(delegatorReward)
+				delegators[j].PotentialReward = &potentialReward
+
+				newDelegationReward, err := math.Add64(delegationReward, validatorReward)
+				if err != nil {
+					return err
+				}
+				delegationReward = newDelegationReward
+			}
+			potentialDelegationReward := json.Uint64(delegationReward)
+			vdr.PotentialDelegationReward = &potentialDelegationReward
 			vdr.Delegators = delegators
 		}
 		reply.Validators[i] = vdr
@@ -760,6 +842,21 @@ type GetPendingValidatorsReply struct {
 	Delegators []interface{} `json:"delegators"`
 }
 
+// subnetValidatorRemovalTimes returns the time each validator of [subnetID]
+// with a pending removal is removed at, keyed by node ID
+func (service *Service) subnetValidatorRemovalTimes(subnetID ids.ID) (map[[20]byte]*json.Uint64, error) {
+	removals, err := service.vm.pendingSubnetValidatorRemovals(service.vm.DB, subnetID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get pending validator removals: %w", err)
+	}
+	removalTimes := make(map[[20]byte]*json.Uint64, len(removals))
+	for key, removalTime := range removals {
+		removalTimeJSON := json.Uint64(removalTime.Unix())
+		removalTimes[key] = &removalTimeJSON
+	}
+	return removalTimes, nil
+}
+
 // GetPendingValidators returns the list of pending validators
 func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingValidatorsArgs, reply *GetPendingValidatorsReply) error {
 	service.vm.Ctx.Log.Info("Platform: GetPendingValidators called")
@@ -767,6 +864,11 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 	reply.Validators = []interface{}{}
 	reply.Delegators = []interface{}{}
 
+	removalTimes, err := service.subnetValidatorRemovalTimes(args.SubnetID)
+	if err != nil {
+		return err
+	}
+
 	startPrefix := []byte(fmt.Sprintf("%s%s", args.SubnetID, startDBPrefix))
 	startDB := prefixdb.NewNested(startPrefix, service.vm.DB)
 	defer startDB.Close()
@@ -814,13 +916,18 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 			})
 		case *UnsignedAddSubnetValidatorTx:
 			weight := json.Uint64(staker.Validator.Weight())
-			reply.Validators = append(reply.Validators, APIStaker{
-				TxID:      tx.ID(),
-				NodeID:    staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
-				StartTime: json.Uint64(staker.StartTime().Unix()),
-				EndTime:   json.Uint64(staker.EndTime().Unix()),
-				Weight:    &weight,
+			reply.Validators = append(reply.Validators, APISubnetValidator{
+				APIStaker: APIStaker{
+					TxID:      tx.ID(),
+					NodeID:    staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
+					StartTime: json.Uint64(staker.StartTime().Unix()),
+					EndTime:   json.Uint64(staker.EndTime().Unix()),
+					Weight:    &weight,
+				},
+				RemovalTime: removalTimes[staker.Validator.NodeID.Key()],
 			})
+		case *UnsignedRemoveSubnetValidatorTx:
+			// Reported as the removal time of the validator
 		default:
 			return fmt.Errorf("expected validator but got %T", tx.UnsignedTx)
 		}
@@ -887,6 +994,39 @@ func (service *Service) SampleValidators(_ *http.Request, args *SampleValidators
 	return nil
 }
 
+// GetValidatorsAtArgs are the arguments for calling GetValidatorsAt
+type GetValidatorsAtArgs struct {
+	// Height of the P-Chain block to get the validators at
+	Height json.Uint64 `json:"height"`
+
+	// ID of the subnet to get the validators of
+	// If omitted, defaults to the primary network
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// GetValidatorsAtReply are the results from calling GetValidatorsAt
+type GetValidatorsAtReply struct {
+	// Weight of each validator, keyed by node ID
+	Validators map[string]json.Uint64 `json:"validators"`
+}
+
+// GetValidatorsAt returns the weights of the validators of a subnet once the
+// block at the given height was accepted
+func (service *Service) GetValidatorsAt(_ *http.Request, args *GetValidatorsAtArgs, reply *GetValidatorsAtReply) error {
+	service.vm.Ctx.Log.Info("Platform: GetValidatorsAt called with Height = %d, SubnetID = %s", args.Height, args.SubnetID)
+
+	vdrs, err := service.vm.GetValidatorSet(uint64(args.Height), args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get validator set: %w", err)
+	}
+
+	reply.Validators = make(map[string]json.Uint64, vdrs.Len())
+	for _, vdr := range vdrs.List() {
+		reply.Validators[vdr.ID().PrefixedString(constants.NodeIDPrefix)] = json.Uint64(vdr.Weight())
+	}
+	return nil
+}
+
 /*
  ******************************************************
  ************ Add Validators to Subnets ***************
@@ -907,27 +1047,10 @@ type AddValidatorArgs struct {
 // validator to the primary network
 func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, reply *api.JSONTxIDChangeAddr) error {
 	service.vm.Ctx.Log.Info("Platform: AddValidator called")
-	switch {
-	case args.RewardAddress == "":
-		return errNoRewardAddress
-	case uint64(args.StartTime) < service.vm.clock.Unix():
-		return fmt.Errorf("start time must be in the future")
-	case uint64(args.StartTime) > service.vm.clock.Unix()+uint64(maxFutureStartTime.Seconds()):
-		return errStartTimeTooLate
-	case args.DelegationFeeRate < 0 || args.DelegationFeeRate > 100:
-		return errInvalidDelegationRate
-	}
 
-	// Parse the node ID
-	var nodeID ids.ShortID
-	if args.NodeID == "" {
-		nodeID = service.vm.Ctx.NodeID // If omitted, use this node's ID
-	} else {
-		nID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
-		if err != nil {
-			return err
-		}
-		nodeID = nID
+	nodeID, rewardAddress, err := service.parseValidator(&args.APIStaker, args.RewardAddress, args.DelegationFeeRate)
+	if err != nil {
+		return err
 	}
 
 	// Parse the from addresses
@@ -940,12 +1063,6 @@ func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, re
 		fromAddrs.Add(addr)
 	}
 
-	// Parse the reward address
-	rewardAddress, err := service.vm.ParseLocalAddress(args.RewardAddress)
-	if err != nil {
-		return fmt.Errorf("problem while parsing reward address: %w", err)
-	}
-
 	// Get the keys controlled by the user
 	db, err := service.vm.Ctx.Keystore.GetDatabase(args.Username, args.Password)
 	if err != nil {
@@ -1011,6 +1128,39 @@ func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, re
 	return errs.Err
 }
 
+// parseValidator verifies the arguments common to the methods that add a
+// validator to the primary network, and returns the ID of the node that will
+// validate and the address the staking reward will go to
+func (service *Service) parseValidator(staker *APIStaker, rewardAddr string, delegationFeeRate json.Float32) (ids.ShortID, ids.ShortID, error) {
+	switch {
+	case rewardAddr == "":
+		return ids.ShortID{}, ids.ShortID{}, errNoRewardAddress
+	case uint64(staker.StartTime) < service.vm.clock.Unix():
+		return ids.ShortID{}, ids.ShortID{}, fmt.Errorf("start time must be in the future")
+	case uint64(staker.StartTime) > service.vm.clock.Unix()+uint64(maxFutureStartTime.Seconds()):
+		return ids.ShortID{}, ids.ShortID{}, errStartTimeTooLate
+	case delegationFeeRate < 0 || delegationFeeRate > 100:
+		return ids.ShortID{}, ids.ShortID{}, errInvalidDelegationRate
+	}
+
+	// Parse the node ID
+	nodeID := service.vm.Ctx.NodeID // If omitted, use this node's ID
+	if staker.NodeID != "" {
+		nID, err := ids.ShortFromPrefixedString(staker.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return ids.ShortID{}, ids.ShortID{}, err
+		}
+		nodeID = nID
+	}
+
+	// Parse the reward address
+	rewardAddress, err := service.vm.ParseLocalAddress(rewardAddr)
+	if err != nil {
+		return ids.ShortID{}, ids.ShortID{}, fmt.Errorf("problem while parsing reward address: %w", err)
+	}
+	return nodeID, rewardAddress, nil
+}
+
 // AddDelegatorArgs are the arguments to AddDelegator
 type AddDelegatorArgs struct {
 	// User, password, from addrs, change addr
@@ -1328,35 +1478,38 @@ func (service *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, re
 	return errs.Err
 }
 
-// ExportAVAXArgs are the arguments to ExportAVAX
-type ExportAVAXArgs struct {
+// TransferSubnetOwnershipArgs are the arguments to TransferSubnetOwnership
+type TransferSubnetOwnershipArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
-
-	// Amount of AVAX to send
-	Amount json.Uint64 `json:"amount"`
-
-	// ID of the address that will receive the AVAX. This address includes the
-	// chainID, which is used to determine what the destination chain is.
-	To string `json:"to"`
+	// ID of the subnet to transfer
+	SubnetID ids.ID `json:"subnetID"`
+	// The new owner of the subnet
+	ControlKeys []string    `json:"controlKeys"`
+	Threshold   json.Uint32 `json:"threshold"`
 }
 
-// ExportAVAX exports AVAX from the P-Chain to the X-Chain
-// It must be imported on the X-Chain to complete the transfer
-func (service *Service) ExportAVAX(_ *http.Request, args *ExportAVAXArgs, response *api.JSONTxIDChangeAddr) error {
-	service.vm.Ctx.Log.Info("Platform: ExportAVAX called")
+// TransferSubnetOwnership creates and signs and issues a transaction to
+// replace the control keys and threshold of a subnet. The user must hold
+// enough of the subnet's current control keys to meet its threshold.
+func (service *Service) TransferSubnetOwnership(_ *http.Request, args *TransferSubnetOwnershipArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.Ctx.Log.Info("Platform: TransferSubnetOwnership called")
 
-	if args.Amount == 0 {
-		return errors.New("argument 'amount' must be > 0")
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return errTransferPrimaryNetwork
 	}
 
-	// Parse the to address
-	chainID, to, err := service.vm.ParseAddress(args.To)
-	if err != nil {
-		return err
+	// Parse the control keys
+	controlKeys := []ids.ShortID{}
+	for _, controlKey := range args.ControlKeys {
+		controlKeyID, err := service.vm.ParseLocalAddress(controlKey)
+		if err != nil {
+			return fmt.Errorf("problem parsing control key %q: %w", controlKey, err)
+		}
+		controlKeys = append(controlKeys, controlKeyID)
 	}
 
-	// Get this user's data
+	// Get the keys controlled by the user
 	db, err := service.vm.Ctx.Keystore.GetDatabase(args.Username, args.Password)
 	if err != nil {
 		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
@@ -1364,17 +1517,16 @@ func (service *Service) ExportAVAX(_ *http.Request, args *ExportAVAXArgs, respon
 	defer db.Close()
 
 	user := user{db: db}
-	privKeys, err := user.getKeys()
+	keys, err := user.getKeys()
 	if err != nil {
 		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
 	}
 
-	// Parse the change address. Assumes that if the user has no keys,
-	// this operation will fail so the change address can be anything.
-	if len(privKeys) == 0 {
+	// Parse the change address.
+	if len(keys) == 0 {
 		return errNoKeys
 	}
-	changeAddr := privKeys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := keys[0].PublicKey().Address() // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
 		if err != nil {
@@ -1395,9 +1547,9 @@ func (service *Service) ExportAVAX(_ *http.Request, args *ExportAVAXArgs, respon
 	// If fromAddrs given, only use those addrs to pay fee
 	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
 	if fromAddrs.Len() == 0 {
-		filteredPrivKeys = privKeys
+		filteredPrivKeys = keys
 	} else {
-		for _, key := range privKeys {
+		for _, key := range keys {
 			if fromAddrs.Contains(key.PublicKey().Address()) {
 				filteredPrivKeys = append(filteredPrivKeys, key)
 			}
@@ -1405,12 +1557,12 @@ func (service *Service) ExportAVAX(_ *http.Request, args *ExportAVAXArgs, respon
 	}
 
 	// Create the transaction
-	tx, err := service.vm.newExportTx(
-		uint64(args.Amount), // Amount
-		chainID,             // ID of the chain to send the funds to
-		to,                  // Address
-		filteredPrivKeys,    // Private keys
-		changeAddr,          // Change address
+	tx, err := service.vm.newTransferSubnetOwnershipTx(
+		args.SubnetID,          // Subnet ID
+		uint32(args.Threshold), // Threshold
+		controlKeys,            // Control Addresses
+		filteredPrivKeys,       // Private keys
+		changeAddr,             // Change address
 	)
 	if err != nil {
 		return fmt.Errorf("couldn't create tx: %w", err)
@@ -1428,54 +1580,56 @@ func (service *Service) ExportAVAX(_ *http.Request, args *ExportAVAXArgs, respon
 	return errs.Err
 }
 
-// ImportAVAXArgs are the arguments to ImportAVAX
-type ImportAVAXArgs struct {
+// RemoveSubnetValidatorArgs are the arguments to RemoveSubnetValidator
+type RemoveSubnetValidatorArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
-
-	// Chain the funds are coming from
-	SourceChain string `json:"sourceChain"`
-
-	// The address that will receive the imported funds
-	To string `json:"to"`
+	// ID of the node to remove
+	NodeID string `json:"nodeID"`
+	// ID of the subnet the node is removed from
+	SubnetID ids.ID `json:"subnetID"`
+	// Unix time the node is removed at
+	RemovalTime json.Uint64 `json:"removalTime"`
 }
 
-// ImportAVAX issues a transaction to import AVAX from the X-chain. The AVAX
-// must have already been exported from the X-Chain.
-func (service *Service) ImportAVAX(_ *http.Request, args *ImportAVAXArgs, response *api.JSONTxIDChangeAddr) error {
-	service.vm.Ctx.Log.Info("Platform: ImportAVAX called")
-
-	// Parse the sourceCHain
-	chainID, err := service.vm.Ctx.BCLookup.Lookup(args.SourceChain)
-	if err != nil {
-		return fmt.Errorf("problem parsing chainID %q: %w", args.SourceChain, err)
+// RemoveSubnetValidator creates and signs and issues a transaction to remove a
+// validator from a subnet before its end time. The user must hold enough of
+// the subnet's control keys to meet its threshold.
+func (service *Service) RemoveSubnetValidator(_ *http.Request, args *RemoveSubnetValidatorArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.Ctx.Log.Info("Platform: RemoveSubnetValidator called")
+	switch {
+	case args.SubnetID == constants.PrimaryNetworkID:
+		return errRemovePrimaryNetworkValidator
+	case uint64(args.RemovalTime) < service.vm.clock.Unix():
+		return fmt.Errorf("removal time must be in the future")
+	case uint64(args.RemovalTime) > service.vm.clock.Unix()+uint64(maxFutureStartTime.Seconds()):
+		return fmt.Errorf("removal time can be at most %s in the future", maxFutureStartTime)
 	}
 
-	// Parse the to address
-	to, err := service.vm.ParseLocalAddress(args.To)
-	if err != nil { // Parse address
-		return fmt.Errorf("couldn't parse argument 'to' to an address: %w", err)
+	// Parse the node ID
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return fmt.Errorf("error parsing nodeID: %q: %w", args.NodeID, err)
 	}
 
-	// Get the user's info
+	// Get the keys controlled by the user
 	db, err := service.vm.Ctx.Keystore.GetDatabase(args.Username, args.Password)
 	if err != nil {
-		return fmt.Errorf("couldn't get user %q: %w", args.Username, err)
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
 	}
 	defer db.Close()
 
 	user := user{db: db}
-	privKeys, err := user.getKeys()
-	if err != nil { // Get keys
-		return fmt.Errorf("couldn't get keys controlled by the user: %w", err)
+	keys, err := user.getKeys()
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
 	}
 
-	// Parse the change address. Assumes that if the user has no keys,
-	// this operation will fail so the change address can be anything.
-	if len(privKeys) == 0 {
+	// Parse the change address.
+	if len(keys) == 0 {
 		return errNoKeys
 	}
-	changeAddr := privKeys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := keys[0].PublicKey().Address() // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
 		if err != nil {
@@ -1496,18 +1650,25 @@ func (service *Service) ImportAVAX(_ *http.Request, args *ImportAVAXArgs, respon
 	// If fromAddrs given, only use those addrs to pay fee
 	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
 	if fromAddrs.Len() == 0 {
-		filteredPrivKeys = privKeys
+		filteredPrivKeys = keys
 	} else {
-		for _, key := range privKeys {
+		for _, key := range keys {
 			if fromAddrs.Contains(key.PublicKey().Address()) {
 				filteredPrivKeys = append(filteredPrivKeys, key)
 			}
 		}
 	}
 
-	tx, err := service.vm.newImportTx(chainID, to, filteredPrivKeys, changeAddr)
+	// Create the transaction
+	tx, err := service.vm.newRemoveSubnetValidatorTx(
+		nodeID,                   // Node ID
+		args.SubnetID,            // Subnet ID
+		uint64(args.RemovalTime), // Removal time
+		filteredPrivKeys,         // Keys
+		changeAddr,               // Change address
+	)
 	if err != nil {
-		return err
+		return fmt.Errorf("couldn't create tx: %w", err)
 	}
 
 	response.TxID = tx.ID()
@@ -1522,33 +1683,227 @@ func (service *Service) ImportAVAX(_ *http.Request, args *ImportAVAXArgs, respon
 	return errs.Err
 }
 
-/*
- ******************************************************
- ******** Create/get status of a blockchain ***********
- ******************************************************
- */
-
-// CreateBlockchainArgs is the arguments for calling CreateBlockchain
-type CreateBlockchainArgs struct {
+// ExportAVAXArgs are the arguments to ExportAVAX
+type ExportAVAXArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
-	// ID of Subnet that validates the new blockchain
-	SubnetID ids.ID `json:"subnetID"`
-	// ID of the VM the new blockchain is running
-	VMID string `json:"vmID"`
-	// IDs of the FXs the VM is running
-	FxIDs []string `json:"fxIDs"`
-	// Human-readable name for the new blockchain, not necessarily unique
-	Name string `json:"name"`
-	// Genesis state of the blockchain being created
-	GenesisData string `json:"genesisData"`
-	// Encoding format to use for genesis data
-	Encoding formatting.Encoding `json:"encoding"`
-}
 
-// CreateBlockchain issues a transaction to create a new blockchain
-func (service *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchainArgs, response *api.JSONTxIDChangeAddr) error {
-	service.vm.Ctx.Log.Info("Platform: CreateBlockchain called")
+	// Amount of AVAX to send
+	Amount json.Uint64 `json:"amount"`
+
+	// ID of the address that will receive the AVAX. This address includes the
+	// chainID, which is used to determine what the destination chain is.
+	To string `json:"to"`
+}
+
+// ExportAVAX exports AVAX from the P-Chain to the X-Chain
+// It must be imported on the X-Chain to complete the transfer
+func (service *Service) ExportAVAX(_ *http.Request, args *ExportAVAXArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.Ctx.Log.Info("Platform: ExportAVAX called")
+
+	if args.Amount == 0 {
+		return errors.New("argument 'amount' must be > 0")
+	}
+
+	// Parse the to address
+	chainID, to, err := service.vm.ParseAddress(args.To)
+	if err != nil {
+		return err
+	}
+
+	// Get this user's data
+	db, err := service.vm.Ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := user{db: db}
+	privKeys, err := user.getKeys()
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(privKeys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := privKeys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// If fromAddrs given, only use those addrs to pay fee
+	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
+	if fromAddrs.Len() == 0 {
+		filteredPrivKeys = privKeys
+	} else {
+		for _, key := range privKeys {
+			if fromAddrs.Contains(key.PublicKey().Address()) {
+				filteredPrivKeys = append(filteredPrivKeys, key)
+			}
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newExportTx(
+		uint64(args.Amount), // Amount
+		chainID,             // ID of the chain to send the funds to
+		to,                  // Address
+		filteredPrivKeys,    // Private keys
+		changeAddr,          // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.mempool.IssueTx(tx),
+		db.Close(),
+	)
+	return errs.Err
+}
+
+// ImportAVAXArgs are the arguments to ImportAVAX
+type ImportAVAXArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+
+	// Chain the funds are coming from
+	SourceChain string `json:"sourceChain"`
+
+	// The address that will receive the imported funds
+	To string `json:"to"`
+}
+
+// ImportAVAX issues a transaction to import AVAX from the X-chain. The AVAX
+// must have already been exported from the X-Chain.
+func (service *Service) ImportAVAX(_ *http.Request, args *ImportAVAXArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.Ctx.Log.Info("Platform: ImportAVAX called")
+
+	// Parse the sourceCHain
+	chainID, err := service.vm.Ctx.BCLookup.Lookup(args.SourceChain)
+	if err != nil {
+		return fmt.Errorf("problem parsing chainID %q: %w", args.SourceChain, err)
+	}
+
+	// Parse the to address
+	to, err := service.vm.ParseLocalAddress(args.To)
+	if err != nil { // Parse address
+		return fmt.Errorf("couldn't parse argument 'to' to an address: %w", err)
+	}
+
+	// Get the user's info
+	db, err := service.vm.Ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("couldn't get user %q: %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := user{db: db}
+	privKeys, err := user.getKeys()
+	if err != nil { // Get keys
+		return fmt.Errorf("couldn't get keys controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(privKeys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := privKeys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// If fromAddrs given, only use those addrs to pay fee
+	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
+	if fromAddrs.Len() == 0 {
+		filteredPrivKeys = privKeys
+	} else {
+		for _, key := range privKeys {
+			if fromAddrs.Contains(key.PublicKey().Address()) {
+				filteredPrivKeys = append(filteredPrivKeys, key)
+			}
+		}
+	}
+
+	tx, err := service.vm.newImportTx(chainID, to, filteredPrivKeys, changeAddr)
+	if err != nil {
+		return err
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.mempool.IssueTx(tx),
+		db.Close(),
+	)
+	return errs.Err
+}
+
+/*
+ ******************************************************
+ ******** Create/get status of a blockchain ***********
+ ******************************************************
+ */
+
+// CreateBlockchainArgs is the arguments for calling CreateBlockchain
+type CreateBlockchainArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// ID of Subnet that validates the new blockchain
+	SubnetID ids.ID `json:"subnetID"`
+	// ID of the VM the new blockchain is running
+	VMID string `json:"vmID"`
+	// IDs of the FXs the VM is running
+	FxIDs []string `json:"fxIDs"`
+	// Human-readable name for the new blockchain, not necessarily unique
+	Name string `json:"name"`
+	// Genesis state of the blockchain being created
+	GenesisData string `json:"genesisData"`
+	// Encoding format to use for genesis data
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// CreateBlockchain issues a transaction to create a new blockchain
+func (service *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchainArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.Ctx.Log.Info("Platform: CreateBlockchain called")
 	switch {
 	case args.Name == "":
 		return errors.New("argument 'name' not given")
@@ -1842,39 +2197,185 @@ func (service *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response
 	return nil
 }
 
-// GetTx gets a tx
-func (service *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.FormattedTx) error {
+// APIMempoolTx is a tx that is waiting to be put into a block
+type APIMempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Why the tx hasn't been put into a block yet
+	Reason string `json:"reason"`
+}
+
+// GetMempoolTxsReply is the response from GetMempoolTxs
+type GetMempoolTxsReply struct {
+	Txs []APIMempoolTx `json:"txs"`
+}
+
+// GetMempoolTxs returns the txs in the mempool, in the order they're put into
+// blocks, and why each of them is still waiting
+func (service *Service) GetMempoolTxs(_ *http.Request, _ *struct{}, reply *GetMempoolTxsReply) error {
+	service.vm.Ctx.Log.Info("Platform: GetMempoolTxs called")
+
+	pending := service.vm.mempool.pendingTxs()
+	reply.Txs = make([]APIMempoolTx, len(pending))
+	for i, p := range pending {
+		reply.Txs[i] = APIMempoolTx{
+			TxID:   p.tx.ID(),
+			Reason: p.reason,
+		}
+	}
+	return nil
+}
+
+// GetTx gets a tx. If the JSON encoding is requested, the tx is returned
+// decoded.
+func (service *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	service.vm.Ctx.Log.Info("Platform: GetTx called")
 
 	txBytes, err := service.vm.getTx(service.vm.DB, args.TxID)
 	if err != nil {
 		return fmt.Errorf("couldn't get tx: %w", err)
 	}
+	response.Encoding = args.Encoding
+
+	if args.Encoding == formatting.JSON {
+		tx := &Tx{}
+		if _, err := service.vm.codec.Unmarshal(txBytes, tx); err != nil {
+			return fmt.Errorf("couldn't parse tx: %w", err)
+		}
+		if err := tx.Sign(service.vm.codec, nil); err != nil {
+			return err
+		}
+		txJSON, err := service.vm.txJSON(tx)
+		if err != nil {
+			return fmt.Errorf("couldn't decode tx: %w", err)
+		}
+		response.Tx = txJSON
+		return nil
+	}
 
-	response.Tx, err = formatting.Encode(args.Encoding, txBytes)
+	txStr, err := formatting.Encode(args.Encoding, txBytes)
 	if err != nil {
 		return fmt.Errorf("couldn't encode tx as a string: %s", err)
 	}
-	response.Encoding = args.Encoding
+	response.Tx = txStr
 	return nil
 }
 
-// GetTxStatusArgs ...
-type GetTxStatusArgs struct {
+// SimulatedUTXO is a UTXO consumed or produced by a simulated transaction
+type SimulatedUTXO struct {
+	UTXOID avax.UTXOID `json:"utxoID"`
+	Amount json.Uint64 `json:"amount"`
+	// The UTXO, encoded with the requested encoding
+	UTXO string `json:"utxo"`
+}
+
+// SimulateTxReply defines the SimulateTx replies returned from the API
+type SimulateTxReply struct {
 	TxID ids.ID `json:"txID"`
-	// If IncludeReason is false returns a response that looks like:
-	// {
-	// 	"jsonrpc": "2.0",
-	// 	"result": "Dropped",
-	// 	"id": 1
-	// }
-	// If IncludeReason is true returns a response that looks like this:
-	// {
-	// 	"jsonrpc": "2.0",
-	// 	"result": {
-	//     "status":"[Status]",
-	//     "reason":"[Reason tx was dropped, if applicable]"
-	//  },
+	// True if the transaction was fully signed and its credentials were
+	// verified
+	Signed bool `json:"signed"`
+	// Amount of AVAX the transaction burns
+	Fee json.Uint64 `json:"fee"`
+	// Amount of AVAX a transaction of this type must burn
+	RequiredFee json.Uint64     `json:"requiredFee"`
+	Consumed    []SimulatedUTXO `json:"consumed"`
+	// UTXOs the transaction adds to the UTXO set of the P-Chain
+	Produced []SimulatedUTXO `json:"produced"`
+	// UTXOs the transaction locks for its staking period, which are added to
+	// the UTXO set once the staking period ends
+	Staked []SimulatedUTXO `json:"staked"`
+	// UTXOs the transaction exports to another chain
+	Exported []SimulatedUTXO `json:"exported"`
+	// True if the transaction would be valid if it were issued now
+	Valid bool `json:"valid"`
+	// Reason the transaction is invalid
+	Error    string              `json:"error,omitempty"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// SimulateTx verifies a transaction on top of the preferred state without
+// issuing it. The transaction may be signed or unsigned. The credentials of a
+// transaction are only verified if it is fully signed.
+func (service *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, reply *SimulateTxReply) error {
+	service.vm.Ctx.Log.Info("Platform: SimulateTx called")
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := service.vm.parseSimulatedTx(txBytes)
+	if err != nil {
+		return err
+	}
+	sim, err := service.vm.simulateTx(tx)
+	if err != nil {
+		return err
+	}
+
+	reply.TxID = tx.ID()
+	reply.Signed = sim.signed
+	reply.Fee = json.Uint64(sim.fee)
+	reply.RequiredFee = json.Uint64(sim.requiredFee)
+	if reply.Consumed, err = service.simulatedUTXOs(sim.consumed, args.Encoding); err != nil {
+		return err
+	}
+	if reply.Produced, err = service.simulatedUTXOs(sim.produced, args.Encoding); err != nil {
+		return err
+	}
+	if reply.Staked, err = service.simulatedUTXOs(sim.staked, args.Encoding); err != nil {
+		return err
+	}
+	if reply.Exported, err = service.simulatedUTXOs(sim.exported, args.Encoding); err != nil {
+		return err
+	}
+	reply.Valid = sim.err == nil
+	if sim.err != nil {
+		reply.Error = sim.err.Error()
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+func (service *Service) simulatedUTXOs(utxos []*avax.UTXO, encoding formatting.Encoding) ([]SimulatedUTXO, error) {
+	simulated := make([]SimulatedUTXO, len(utxos))
+	for i, utxo := range utxos {
+		utxoBytes, err := service.vm.codec.Marshal(codecVersion, utxo)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't serialize UTXO %s: %w", utxo.InputID(), err)
+		}
+		utxoStr, err := formatting.Encode(encoding, utxoBytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't encode UTXO %s: %w", utxo.InputID(), err)
+		}
+		amount := uint64(0)
+		if out, ok := utxo.Out.(avax.TransferableOut); ok {
+			amount = out.Amount()
+		}
+		simulated[i] = SimulatedUTXO{
+			UTXOID: utxo.UTXOID,
+			Amount: json.Uint64(amount),
+			UTXO:   utxoStr,
+		}
+	}
+	return simulated, nil
+}
+
+// GetTxStatusArgs ...
+type GetTxStatusArgs struct {
+	TxID ids.ID `json:"txID"`
+	// If IncludeReason is false returns a response that looks like:
+	// {
+	// 	"jsonrpc": "2.0",
+	// 	"result": "Dropped",
+	// 	"id": 1
+	// }
+	// If IncludeReason is true returns a response that looks like this:
+	// {
+	// 	"jsonrpc": "2.0",
+	// 	"result": {
+	//     "status":"[Status]",
+	//     "reason":"[Reason tx was dropped, if applicable]"
+	//  },
 	// 	"id": 1
 	// }
 	// In the latter, "reason" is only present if the status is dropped
@@ -1929,6 +2430,76 @@ func (service *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, resp
 	return nil
 }
 
+// GetStakingOutcomeReply is the response from calling GetStakingOutcome
+type GetStakingOutcomeReply struct {
+	// True if the staker was rewarded
+	Rewarded bool `json:"rewarded"`
+	// Reward of the staker, including the part that went to the validator if
+	// the staker is a delegator
+	Reward json.Uint64 `json:"reward"`
+	// UTXOs that returned the stake and paid the reward
+	UTXOIDs []avax.UTXOID `json:"utxoIDs"`
+	// Uptime of the staker observed by this node when the outcome was decided
+	Uptime json.Float32 `json:"uptime"`
+}
+
+// GetStakingOutcome returns whether the staker that the given tx added was
+// rewarded once its staking period ended, and the UTXOs that it received
+func (service *Service) GetStakingOutcome(_ *http.Request, args *api.JSONTxID, reply *GetStakingOutcomeReply) error {
+	service.vm.Ctx.Log.Info("Platform: GetStakingOutcome called with TxID = %s", args.TxID)
+
+	outcome, err := service.vm.getStakingOutcome(service.vm.DB, args.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the staking outcome of %s. Has its staking period ended? %w", args.TxID, err)
+	}
+
+	reply.Rewarded = outcome.Rewarded
+	reply.Reward = json.Uint64(outcome.Reward)
+	reply.UTXOIDs = make([]avax.UTXOID, len(outcome.UTXOs))
+	for i, utxo := range outcome.UTXOs {
+		reply.UTXOIDs[i] = utxo.UTXOID
+	}
+	reply.Uptime = json.Float32(float64(outcome.Uptime) / PercentDenominator)
+	return nil
+}
+
+// GetRewardUTXOsReply is the response from calling GetRewardUTXOs
+type GetRewardUTXOsReply struct {
+	// Number of UTXOs returned
+	NumFetched json.Uint64 `json:"numFetched"`
+	// The UTXOs
+	UTXOs []string `json:"utxos"`
+	// Encoding specifies the format the UTXOs are returned in
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetRewardUTXOs returns the UTXOs that returned the stake and paid the reward
+// of the staker that the given tx added once its staking period ended. The
+// UTXOs are returned even if they were spent since.
+func (service *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *GetRewardUTXOsReply) error {
+	service.vm.Ctx.Log.Info("Platform: GetRewardUTXOs called with TxID = %s", args.TxID)
+
+	outcome, err := service.vm.getStakingOutcome(service.vm.DB, args.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the staking outcome of %s. Has its staking period ended? %w", args.TxID, err)
+	}
+
+	reply.NumFetched = json.Uint64(len(outcome.UTXOs))
+	reply.UTXOs = make([]string, len(outcome.UTXOs))
+	for i, utxo := range outcome.UTXOs {
+		utxoBytes, err := service.vm.codec.Marshal(codecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("couldn't serialize UTXO %s: %w", utxo.InputID(), err)
+		}
+		reply.UTXOs[i], err = formatting.Encode(args.Encoding, utxoBytes)
+		if err != nil {
+			return fmt.Errorf("couldn't encode UTXO %s as string: %w", utxo.InputID(), err)
+		}
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
 // GetStakeReply is the response from calling GetStake.
 type GetStakeReply struct {
 	Staked json.Uint64 `json:"staked"`
@@ -2130,3 +2701,405 @@ func (service *Service) GetMaxStakeAmount(_ *http.Request, args *GetMaxStakeAmou
 	reply.Amount = json.Uint64(amount)
 	return err
 }
+
+// GetDelegationCapacityArgs are the arguments for calling GetDelegationCapacity
+type GetDelegationCapacityArgs struct {
+	// Node ID of the primary network validator to delegate to
+	NodeID    string      `json:"nodeID"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	// Amount of nAVAX to project the reward of. If omitted, the reward of
+	// delegating the whole available capacity is projected.
+	Amount json.Uint64 `json:"amount"`
+}
+
+// GetDelegationCapacityReply is the response from calling
+// GetDelegationCapacity
+type GetDelegationCapacityReply struct {
+	// Maximum amount of nAVAX that can be delegated to the validator over the
+	// whole period
+	Capacity json.Uint64 `json:"capacity"`
+	// Minimum amount of nAVAX that can be delegated
+	MinDelegatorStake json.Uint64 `json:"minDelegatorStake"`
+	// Percent fee the validator charges on the delegators' rewards
+	DelegationFee json.Float32 `json:"delegationFee"`
+	// Amount of nAVAX the reward is projected for
+	Amount json.Uint64 `json:"amount"`
+	// Reward of delegating [Amount] over the period, given the current supply
+	PotentialReward json.Uint64 `json:"potentialReward"`
+	// Part of the reward the delegator receives
+	DelegatorReward json.Uint64 `json:"delegatorReward"`
+	// Part of the reward the validator receives as its delegation fee
+	ValidatorReward json.Uint64 `json:"validatorReward"`
+}
+
+// GetDelegationCapacity returns how much nAVAX can be delegated to a primary
+// network validator during the time period, the validator's delegation fee
+// and how the reward of delegating would be split between the delegator and
+// the validator.
+func (service *Service) GetDelegationCapacity(_ *http.Request, args *GetDelegationCapacityArgs, reply *GetDelegationCapacityReply) error {
+	service.vm.Ctx.Log.Info("Platform: GetDelegationCapacity called")
+
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to parse nodeID %q due to: %w", args.NodeID, err)
+	}
+	startTime := time.Unix(int64(args.StartTime), 0)
+	endTime := time.Unix(int64(args.EndTime), 0)
+	if !startTime.Before(endTime) {
+		return errStartAfterEndTime
+	}
+
+	capacity, vdrTx, err := service.vm.delegationCapacity(service.vm.DB, nodeID, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("couldn't get the delegation capacity of %s: %w", args.NodeID, err)
+	}
+	currentSupply, err := service.vm.getCurrentSupply(service.vm.DB)
+	if err != nil {
+		return fmt.Errorf("couldn't get the current supply: %w", err)
+	}
+
+	amount := uint64(args.Amount)
+	if amount == 0 {
+		amount = capacity
+	}
+	reward := Reward(endTime.Sub(startTime), amount, currentSupply, service.vm.stakeMintingPeriod)
+	delegatorReward, validatorReward := splitReward(reward, vdrTx.Shares)
+
+	reply.Capacity = json.Uint64(capacity)
+	reply.MinDelegatorStake = json.Uint64(service.vm.minDelegatorStake)
+	reply.DelegationFee = json.Float32(100 * float32(vdrTx.Shares) / float32(PercentDenominator))
+	reply.Amount = json.Uint64(amount)
+	reply.PotentialReward = json.Uint64(reward)
+	reply.DelegatorReward = json.Uint64(delegatorReward)
+	reply.ValidatorReward = json.Uint64(validatorReward)
+	return nil
+}
+
+/*
+ ******************************************************
+ ********** Partially signed transactions *************
+ ******************************************************
+ */
+
+// SignatureSlot is a signature that a transaction must contain
+type SignatureSlot struct {
+	// Index of the credential the signature belongs in
+	Credential json.Uint32 `json:"credential"`
+
+	// Address whose signature belongs in this slot
+	Address string `json:"address"`
+
+	// True if the signature has been provided
+	Signed bool `json:"signed"`
+}
+
+// PartialTxReply is a transaction that may be missing some of its signatures
+type PartialTxReply struct {
+	api.FormattedTx
+
+	// Every signature the transaction must contain
+	Signatures []SignatureSlot `json:"signatures"`
+
+	// True if every signature has been provided, in which case the
+	// transaction can be issued with IssueTx
+	Complete bool `json:"complete"`
+}
+
+// partialTxReply sets [reply] to describe [tx]
+func (service *Service) partialTxReply(tx *Tx, encoding formatting.Encoding, reply *PartialTxReply) error {
+	slots, err := service.vm.sigSlots(tx)
+	if err != nil {
+		return fmt.Errorf("problem inspecting signatures: %w", err)
+	}
+
+	reply.Tx, err = formatting.Encode(encoding, tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx as string: %w", err)
+	}
+	reply.Encoding = encoding
+	reply.Signatures = []SignatureSlot{}
+	reply.Complete = true
+	for i, credSlots := range slots {
+		for _, slot := range credSlots {
+			addr, err := service.vm.FormatLocalAddress(slot.Address)
+			if err != nil {
+				return fmt.Errorf("problem formatting address: %w", err)
+			}
+			reply.Signatures = append(reply.Signatures, SignatureSlot{
+				Credential: json.Uint32(i),
+				Address:    addr,
+				Signed:     slot.Signed,
+			})
+			reply.Complete = reply.Complete && slot.Signed
+		}
+	}
+	return nil
+}
+
+// parsePartialTx decodes a transaction that may be missing some of its
+// signatures
+func (service *Service) parsePartialTx(args *api.FormattedTx) (*Tx, error) {
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx := &Tx{}
+	if _, err := service.vm.codec.Unmarshal(txBytes, tx); err != nil {
+		return nil, fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	// Signing without any signers sets the bytes of the transaction
+	return tx, tx.Sign(service.vm.codec, nil)
+}
+
+// parseUnsignedSpenders parses the addresses whose funds an unsigned
+// transaction spends, and the address its change is sent to, which defaults to
+// the first of the from addresses
+func (service *Service) parseUnsignedSpenders(from *api.JSONFromAddrs, change *api.JSONChangeAddr) (ids.ShortSet, ids.ShortID, error) {
+	if len(from.From) == 0 {
+		return nil, ids.ShortID{}, errNoAddresses
+	}
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range from.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return nil, ids.ShortID{}, fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	changeAddrStr := change.ChangeAddr
+	if changeAddrStr == "" {
+		changeAddrStr = from.From[0]
+	}
+	changeAddr, err := service.vm.ParseLocalAddress(changeAddrStr)
+	if err != nil {
+		return nil, ids.ShortID{}, fmt.Errorf("couldn't parse changeAddr: %w", err)
+	}
+	return fromAddrs, changeAddr, nil
+}
+
+// BuildUnsignedAddValidatorArgs are the arguments to BuildUnsignedAddValidator
+type BuildUnsignedAddValidatorArgs struct {
+	// Addresses whose funds are staked. Their keys aren't needed to build the
+	// transaction.
+	api.JSONFromAddrs
+	// Address change is sent to. Defaults to the first of the from addresses.
+	api.JSONChangeAddr
+	APIStaker
+	// The address the staking reward, if applicable, will go to
+	RewardAddress     string       `json:"rewardAddress"`
+	DelegationFeeRate json.Float32 `json:"delegationFeeRate"`
+	// Encoding of the returned transaction
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// BuildUnsignedAddValidator returns a transaction that adds a validator to
+// the primary network, staking funds of the given addresses, without any of
+// its signatures
+func (service *Service) BuildUnsignedAddValidator(_ *http.Request, args *BuildUnsignedAddValidatorArgs, reply *PartialTxReply) error {
+	service.vm.Ctx.Log.Info("Platform: BuildUnsignedAddValidator called")
+
+	nodeID, rewardAddress, err := service.parseValidator(&args.APIStaker, args.RewardAddress, args.DelegationFeeRate)
+	if err != nil {
+		return err
+	}
+
+	fromAddrs, changeAddr, err := service.parseUnsignedSpenders(&args.JSONFromAddrs, &args.JSONChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	tx, err := service.vm.newUnsignedAddValidatorTx(
+		args.weight(),                        // Stake amount
+		uint64(args.StartTime),               // Start time
+		uint64(args.EndTime),                 // End time
+		nodeID,                               // Node ID
+		rewardAddress,                        // Reward Address
+		uint32(10000*args.DelegationFeeRate), // Shares
+		fromAddrs,                            // Addresses providing the stake
+		changeAddr,                           // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+	return service.partialTxReply(tx, args.Encoding, reply)
+}
+
+// BuildUnsignedExportAVAXArgs are the arguments to BuildUnsignedExportAVAX
+type BuildUnsignedExportAVAXArgs struct {
+	// Addresses whose funds are exported and pay the fee. Their keys aren't
+	// needed to build the transaction.
+	api.JSONFromAddrs
+	// Address change is sent to. Defaults to the first of the from addresses.
+	api.JSONChangeAddr
+
+	// Amount of AVAX to send
+	Amount json.Uint64 `json:"amount"`
+
+	// ID of the address that will receive the AVAX. This address includes the
+	// chainID, which is used to determine what the destination chain is.
+	To string `json:"to"`
+
+	// Encoding of the returned transaction
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// BuildUnsignedExportAVAX returns a transaction that exports AVAX of the given
+// addresses from the P-Chain to the X-Chain, without any of its signatures
+func (service *Service) BuildUnsignedExportAVAX(_ *http.Request, args *BuildUnsignedExportAVAXArgs, reply *PartialTxReply) error {
+	service.vm.Ctx.Log.Info("Platform: BuildUnsignedExportAVAX called")
+
+	if args.Amount == 0 {
+		return errors.New("argument 'amount' must be > 0")
+	}
+
+	// Parse the to address
+	chainID, to, err := service.vm.ParseAddress(args.To)
+	if err != nil {
+		return err
+	}
+
+	fromAddrs, changeAddr, err := service.parseUnsignedSpenders(&args.JSONFromAddrs, &args.JSONChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	tx, err := service.vm.newUnsignedExportTx(
+		uint64(args.Amount), // Amount
+		chainID,             // ID of the chain to send the funds to
+		to,                  // Address
+		fromAddrs,           // Addresses providing the funds
+		changeAddr,          // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+	return service.partialTxReply(tx, args.Encoding, reply)
+}
+
+// BuildUnsignedImportAVAXArgs are the arguments to BuildUnsignedImportAVAX
+type BuildUnsignedImportAVAXArgs struct {
+	// Addresses whose exported funds are imported and, if the imported funds
+	// don't cover it, pay the fee. Their keys aren't needed to build the
+	// transaction.
+	api.JSONFromAddrs
+	// Address change is sent to. Defaults to the first of the from addresses.
+	api.JSONChangeAddr
+
+	// Chain the funds are coming from
+	SourceChain string `json:"sourceChain"`
+
+	// The address that will receive the imported funds
+	To string `json:"to"`
+
+	// Encoding of the returned transaction
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// BuildUnsignedImportAVAX returns a transaction that imports the AVAX that
+// was exported from the X-Chain to the given addresses, without any of its
+// signatures
+func (service *Service) BuildUnsignedImportAVAX(_ *http.Request, args *BuildUnsignedImportAVAXArgs, reply *PartialTxReply) error {
+	service.vm.Ctx.Log.Info("Platform: BuildUnsignedImportAVAX called")
+
+	// Parse the source chain
+	chainID, err := service.vm.Ctx.BCLookup.Lookup(args.SourceChain)
+	if err != nil {
+		return fmt.Errorf("problem parsing chainID %q: %w", args.SourceChain, err)
+	}
+
+	// Parse the to address
+	to, err := service.vm.ParseLocalAddress(args.To)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'to' to an address: %w", err)
+	}
+
+	fromAddrs, changeAddr, err := service.parseUnsignedSpenders(&args.JSONFromAddrs, &args.JSONChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	tx, err := service.vm.newUnsignedImportTx(chainID, to, fromAddrs, changeAddr)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+	return service.partialTxReply(tx, args.Encoding, reply)
+}
+
+// SignTxArgs are the arguments to SignTx
+type SignTxArgs struct {
+	// The partially signed transaction
+	api.FormattedTx
+
+	// User whose keys sign the transaction, if any
+	api.UserPass
+
+	// Key that signs the transaction, if any, in the format returned by
+	// ExportKey
+	PrivateKey string `json:"privateKey"`
+}
+
+// SignTx adds to a partially signed transaction the missing signatures that
+// the given user or key can provide. The returned transaction is encoded the
+// same way as the provided one.
+func (service *Service) SignTx(_ *http.Request, args *SignTxArgs, reply *PartialTxReply) error {
+	service.vm.Ctx.Log.Info("Platform: SignTx called")
+
+	if args.Username == "" && args.PrivateKey == "" {
+		return errNoSigners
+	}
+	tx, err := service.parsePartialTx(&args.FormattedTx)
+	if err != nil {
+		return err
+	}
+
+	kc := secp256k1fx.NewKeychain()
+	if args.PrivateKey != "" {
+		sk, err := parsePrivateKey(args.PrivateKey)
+		if err != nil {
+			return err
+		}
+		kc.Add(sk)
+	}
+	if args.Username != "" {
+		db, err := service.vm.Ctx.Keystore.GetDatabase(args.Username, args.Password)
+		if err != nil {
+			return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+		}
+		defer db.Close()
+
+		user := user{db: db}
+		privKeys, err := user.getKeys()
+		if err != nil {
+			return fmt.Errorf("couldn't get keys controlled by the user: %w", err)
+		}
+		for _, key := range privKeys {
+			kc.Add(key)
+		}
+		if err := db.Close(); err != nil {
+			return err
+		}
+	}
+
+	numSigned, err := service.vm.signPartialTx(tx, kc)
+	if err != nil {
+		return err
+	}
+	if numSigned == 0 {
+		return errNoMissingSigs
+	}
+	return service.partialTxReply(tx, args.Encoding, reply)
+}
+
+// GetTxSignatures returns which signatures a partially signed transaction
+// contains and which are still missing
+func (service *Service) GetTxSignatures(_ *http.Request, args *api.FormattedTx, reply *PartialTxReply) error {
+	service.vm.Ctx.Log.Info("Platform: GetTxSignatures called")
+
+	tx, err := service.parsePartialTx(args)
+	if err != nil {
+		return err
+	}
+	return service.partialTxReply(tx, args.Encoding, reply)
+}
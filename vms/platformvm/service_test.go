@@ -7,16 +7,22 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http/httptest"
+	"reflect"
 
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -347,7 +353,7 @@ func TestGetTx(t *testing.T) {
 			TxID:     tx.ID(),
 			Encoding: formatting.CB58,
 		}
-		var response api.FormattedTx
+		var response api.GetTxReply
 		if err := service.GetTx(nil, arg, &response); err == nil {
 			t.Fatalf("failed test '%s': haven't issued tx yet so shouldn't be able to get it", test.description)
 		} else if err := service.vm.mempool.IssueTx(tx); err != nil {
@@ -371,13 +377,29 @@ func TestGetTx(t *testing.T) {
 		} else if err := service.GetTx(nil, arg, &response); err != nil {
 			t.Fatalf("failed test '%s': %s", test.description, err)
 		} else {
-			responseTxBytes, err := formatting.Decode(response.Encoding, response.Tx)
+			responseTxBytes, err := formatting.Decode(response.Encoding, response.Tx.(string))
 			if err != nil {
 				t.Fatalf("failed test '%s': %s", test.description, err)
 			}
 			if !bytes.Equal(responseTxBytes, tx.Bytes()) {
 				t.Fatalf("failed test '%s': byte representation of tx in response is incorrect", test.description)
 			}
+
+			var jsonResponse api.GetTxReply
+			arg.Encoding = formatting.JSON
+			if err := service.GetTx(nil, arg, &jsonResponse); err != nil {
+				t.Fatalf("failed test '%s': %s", test.description, err)
+			}
+			txJSON, ok := jsonResponse.Tx.(*JSONTx)
+			if !ok {
+				t.Fatalf("failed test '%s': expected *JSONTx but got %T", test.description, jsonResponse.Tx)
+			}
+			if txJSON.ID != tx.ID() {
+				t.Fatalf("failed test '%s': decoded tx has the wrong ID", test.description)
+			}
+			if _, err := json.Marshal(jsonResponse); err != nil {
+				t.Fatalf("failed test '%s': %s", test.description, err)
+			}
 		}
 	}
 }
@@ -653,8 +675,9 @@ func TestGetCurrentValidators(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	delegatorReward := uint64(1000)
 	if err := service.vm.addStaker(service.vm.DB, constants.PrimaryNetworkID, &rewardTx{
-		Reward: 0,
+		Reward: delegatorReward,
 		Tx:     *tx,
 	}); err != nil {
 		t.Fatal(err)
@@ -691,9 +714,587 @@ func TestGetCurrentValidators(t *testing.T) {
 			t.Fatal("wrong end time")
 		case delegator.weight() != stakeAmt:
 			t.Fatalf("wrong weight")
+		case delegator.PotentialReward == nil || vdr.PotentialDelegationReward == nil:
+			t.Fatal("missing potential rewards")
+		case uint64(*delegator.PotentialReward)+uint64(*vdr.PotentialDelegationReward) != delegatorReward:
+			t.Fatalf("the delegator's reward should be split between the delegator and the validator")
+		case vdr.Uptime == nil || *vdr.Uptime < 0 || *vdr.Uptime > 1:
+			t.Fatalf("uptime should be between 0 and 1")
 		}
 	}
 	if !found {
 		t.Fatalf("didnt find delegator")
 	}
 }
+
+func TestPartiallySignedAddValidator(t *testing.T) {
+	service := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+
+	fromAddr, err := service.vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stakeAmount := cjson.Uint64(service.vm.minValidatorStake)
+	startTime := defaultGenesisTime.Add(time.Second)
+	built := PartialTxReply{}
+	if err := service.BuildUnsignedAddValidator(nil, &BuildUnsignedAddValidatorArgs{
+		JSONFromAddrs: api.JSONFromAddrs{From: []string{fromAddr}},
+		APIStaker: APIStaker{
+			NodeID:      ids.GenerateTestShortID().PrefixedString(constants.NodeIDPrefix),
+			StakeAmount: &stakeAmount,
+			StartTime:   cjson.Uint64(startTime.Unix()),
+			EndTime:     cjson.Uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+		},
+		RewardAddress: fromAddr,
+		Encoding:      formatting.Hex,
+	}, &built); err != nil {
+		t.Fatal(err)
+	}
+	if built.Complete {
+		t.Fatal("unsigned tx shouldn't be complete")
+	}
+	if len(built.Signatures) == 0 {
+		t.Fatal("unsigned tx should require signatures")
+	}
+	for _, slot := range built.Signatures {
+		if slot.Signed {
+			t.Fatal("unsigned tx shouldn't contain signatures")
+		}
+		if slot.Address != fromAddr {
+			t.Fatalf("expected signature from %s but got %s", fromAddr, slot.Address)
+		}
+	}
+
+	signed := PartialTxReply{}
+	if err := service.SignTx(nil, &SignTxArgs{
+		FormattedTx: built.FormattedTx,
+		UserPass: api.UserPass{
+			Username: testUsername,
+			Password: testPassword,
+		},
+	}, &signed); err != nil {
+		t.Fatal(err)
+	}
+	if !signed.Complete {
+		t.Fatal("signed tx should be complete")
+	}
+
+	// Signing again has nothing left to add
+	if err := service.SignTx(nil, &SignTxArgs{
+		FormattedTx: signed.FormattedTx,
+		UserPass: api.UserPass{
+			Username: testUsername,
+			Password: testPassword,
+		},
+	}, &PartialTxReply{}); err != errNoMissingSigs {
+		t.Fatalf("expected %s but got %v", errNoMissingSigs, err)
+	}
+
+	inspected := PartialTxReply{}
+	if err := service.GetTxSignatures(nil, &signed.FormattedTx, &inspected); err != nil {
+		t.Fatal(err)
+	}
+	if !inspected.Complete || len(inspected.Signatures) != len(built.Signatures) {
+		t.Fatalf("unexpected signatures %+v", inspected.Signatures)
+	}
+
+	if err := service.IssueTx(nil, &signed.FormattedTx, &api.JSONTxID{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPartiallySignedExportAndImport(t *testing.T) {
+	service := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+
+	// signWithKey signs [partial] with [key], passed in the format returned by
+	// ExportKey, and issues it
+	signWithKey := func(partial PartialTxReply, key *crypto.PrivateKeySECP256K1R) {
+		keyStr, err := formatting.Encode(formatting.CB58, key.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		signed := PartialTxReply{}
+		if err := service.SignTx(nil, &SignTxArgs{
+			FormattedTx: partial.FormattedTx,
+			PrivateKey:  constants.SecretKeyPrefix + keyStr,
+		}, &signed); err != nil {
+			t.Fatal(err)
+		}
+		if !signed.Complete {
+			t.Fatalf("signed tx should be complete but has signatures %+v", signed.Signatures)
+		}
+		if err := service.IssueTx(nil, &signed.FormattedTx, &api.JSONTxID{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// assertUnsigned checks that every signature of [partial] must be
+	// provided by [addr]
+	assertUnsigned := func(partial PartialTxReply, addr string) {
+		if partial.Complete || len(partial.Signatures) == 0 {
+			t.Fatalf("unsigned tx should be missing signatures but has %+v", partial.Signatures)
+		}
+		for _, slot := range partial.Signatures {
+			if slot.Signed {
+				t.Fatal("unsigned tx shouldn't contain signatures")
+			}
+			if slot.Address != addr {
+				t.Fatalf("expected signature from %s but got %s", addr, slot.Address)
+			}
+		}
+	}
+
+	fromAddr, err := service.vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipientKey := keys[1]
+	recipientAddr := recipientKey.PublicKey().Address()
+	xChainAddr, err := service.vm.FormatAddress(service.vm.Ctx.XChainID, recipientAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exportTx := PartialTxReply{}
+	if err := service.BuildUnsignedExportAVAX(nil, &BuildUnsignedExportAVAXArgs{
+		JSONFromAddrs: api.JSONFromAddrs{From: []string{fromAddr}},
+		Amount:        100,
+		To:            xChainAddr,
+		Encoding:      formatting.Hex,
+	}, &exportTx); err != nil {
+		t.Fatal(err)
+	}
+	assertUnsigned(exportTx, fromAddr)
+	signWithKey(exportTx, keys[0])
+
+	// Provide the UTXO the recipient imports
+	m := &atomic.Memory{}
+	if err := m.Initialize(logging.NoLog{}, memdb.New()); err != nil {
+		t.Fatal(err)
+	}
+	service.vm.Ctx.SharedMemory = m.NewSharedMemory(service.vm.Ctx.ChainID)
+	peerSharedMemory := m.NewSharedMemory(service.vm.Ctx.XChainID)
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: avaxAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 50000,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{recipientAddr},
+			},
+		},
+	}
+	utxoBytes, err := service.vm.codec.Marshal(codecVersion, utxo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputID := utxo.InputID()
+	if err := peerSharedMemory.Put(service.vm.Ctx.ChainID, []*atomic.Element{{
+		Key:    inputID[:],
+		Value:  utxoBytes,
+		Traits: [][]byte{recipientAddr.Bytes()},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	recipientLocalAddr, err := service.vm.FormatLocalAddress(recipientAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	importTx := PartialTxReply{}
+	if err := service.BuildUnsignedImportAVAX(nil, &BuildUnsignedImportAVAXArgs{
+		JSONFromAddrs: api.JSONFromAddrs{From: []string{recipientLocalAddr}},
+		SourceChain:   "X",
+		To:            recipientLocalAddr,
+		Encoding:      formatting.Hex,
+	}, &importTx); err != nil {
+		t.Fatal(err)
+	}
+	assertUnsigned(importTx, recipientLocalAddr)
+	signWithKey(importTx, recipientKey)
+}
+func TestSimulateTx(t *testing.T) {
+	service := defaultService(t)
+	service.vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+
+	tx, err := service.vm.newExportTx(
+		100,
+		service.vm.Ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	simulate := func(txBytes []byte) *SimulateTxReply {
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reply := &SimulateTxReply{}
+		if err := service.SimulateTx(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply); err != nil {
+			t.Fatal(err)
+		}
+		if reply.TxID != tx.ID() {
+			t.Fatalf("expected tx %s but got %s", tx.ID(), reply.TxID)
+		}
+		return reply
+	}
+
+	reply := simulate(tx.Bytes())
+	switch {
+	case !reply.Valid:
+		t.Fatalf("signed tx should be valid but got %q", reply.Error)
+	case !reply.Signed:
+		t.Fatal("signed tx should be verified as signed")
+	case uint64(reply.Fee) != service.vm.txFee:
+		t.Fatalf("expected fee %d but got %d", service.vm.txFee, reply.Fee)
+	case uint64(reply.RequiredFee) != service.vm.txFee:
+		t.Fatalf("expected required fee %d but got %d", service.vm.txFee, reply.RequiredFee)
+	case len(reply.Consumed) == 0:
+		t.Fatal("tx should consume UTXOs")
+	}
+	switch {
+	case len(reply.Exported) != 1:
+		t.Fatalf("expected 1 exported UTXO but got %d", len(reply.Exported))
+	case reply.Exported[0].Amount != 100:
+		t.Fatalf("expected the exported UTXO to hold 100 but got %d", reply.Exported[0].Amount)
+	case len(reply.Produced) != 1:
+		t.Fatalf("expected only the change to be produced but got %d UTXOs", len(reply.Produced))
+	case reply.Exported[0].UTXOID.OutputIndex != 1:
+		t.Fatalf("expected the exported UTXO to follow the change but has index %d", reply.Exported[0].UTXOID.OutputIndex)
+	}
+
+	// Simulating the tx mustn't issue it
+	if status, err := service.vm.getStatus(service.vm.DB, tx.ID()); err == nil && status != Unknown {
+		t.Fatalf("simulated tx shouldn't have status %s", status)
+	}
+
+	reply = simulate(tx.UnsignedBytes())
+	switch {
+	case !reply.Valid:
+		t.Fatalf("unsigned tx should be valid but got %q", reply.Error)
+	case reply.Signed:
+		t.Fatal("unsigned tx shouldn't be verified as signed")
+	}
+
+	// Burning AVAX doesn't make up for producing another asset that isn't
+	// consumed
+	utx := *tx.UnsignedTx.(*UnsignedExportTx)
+	change := *utx.Outs[0]
+	changeOut := *change.Out.(*secp256k1fx.TransferOutput)
+	changeOut.Amt -= 5
+	change.Out = &changeOut
+	utx.Outs = []*avax.TransferableOutput{
+		&change,
+		{
+			Asset: avax.Asset{ID: ids.GenerateTestID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          5,
+				OutputOwners: changeOut.OutputOwners,
+			},
+		},
+	}
+	avax.SortTransferableOutputs(utx.Outs, service.vm.codec)
+	var utxIntf UnsignedTx = &utx
+	utxBytes, err := service.vm.codec.Marshal(codecVersion, &utxIntf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	utxStr, err := formatting.Encode(formatting.Hex, utxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply = &SimulateTxReply{}
+	if err := service.SimulateTx(nil, &api.FormattedTx{
+		Tx:       utxStr,
+		Encoding: formatting.Hex,
+	}, reply); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case reply.Valid:
+		t.Fatal("tx that produces an asset it doesn't consume shouldn't be valid")
+	case uint64(reply.Fee) != service.vm.txFee+5:
+		t.Fatalf("expected fee %d but got %d", service.vm.txFee+5, reply.Fee)
+	}
+
+	tx.Creds[0].(*secp256k1fx.Credential).Sigs[0][0] ^= 1
+	txBytes, err := service.vm.codec.Marshal(codecVersion, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply = simulate(txBytes)
+	switch {
+	case reply.Valid:
+		t.Fatal("tx with an invalid signature shouldn't be valid")
+	case reply.Error == "":
+		t.Fatal("invalid tx should report its error")
+	}
+}
+
+// Ensure txs are simulated on top of the preferred option of a preferred
+// proposal block
+func TestSimulateTxPreferredProposalBlock(t *testing.T) {
+	service := defaultService(t)
+	service.vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+
+	startTime := defaultGenesisTime.Add(syncBound).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	nodeID := ids.GenerateTestShortID()
+	newAddValidatorTx := func(key *crypto.PrivateKeySECP256K1R) *Tx {
+		tx, err := service.vm.newAddValidatorTx(
+			service.vm.minValidatorStake,
+			uint64(startTime.Unix()),
+			uint64(endTime.Unix()),
+			nodeID,
+			nodeID,
+			PercentDenominator,
+			[]*crypto.PrivateKeySECP256K1R{key},
+			ids.ShortEmpty, // change addr
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	if err := service.vm.mempool.IssueTx(newAddValidatorTx(keys[0])); err != nil {
+		t.Fatal(err)
+	}
+	blk, err := service.vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	} else if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	service.vm.SetPreference(blk.ID())
+
+	// The preferred option commits the first tx, which makes the node a
+	// pending validator
+	sim, err := service.vm.simulateTx(newAddValidatorTx(keys[1]))
+	if err != nil {
+		t.Fatal(err)
+	} else if sim.err == nil {
+		t.Fatal("should have failed to add a validator that's already pending in the preferred option")
+	}
+}
+
+func TestGetDelegationCapacity(t *testing.T) {
+	service := defaultService(t)
+	service.vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+
+	nodeID := keys[1].PublicKey().Address().PrefixedString(constants.NodeIDPrefix)
+	startTime := uint64(defaultValidateStartTime.Add(time.Second).Unix())
+	endTime := uint64(defaultValidateStartTime.Add(defaultMinStakingDuration).Unix())
+
+	// Case: The node doesn't validate the primary network
+	args := GetDelegationCapacityArgs{
+		NodeID:    ids.GenerateTestShortID().PrefixedString(constants.NodeIDPrefix),
+		StartTime: cjson.Uint64(startTime),
+		EndTime:   cjson.Uint64(endTime),
+	}
+	reply := GetDelegationCapacityReply{}
+	if err := service.GetDelegationCapacity(nil, &args, &reply); err == nil {
+		t.Fatal("should have failed because the node isn't a validator")
+	}
+
+	// Case: The period ends after the validator stops validating
+	args = GetDelegationCapacityArgs{
+		NodeID:    nodeID,
+		StartTime: cjson.Uint64(startTime),
+		EndTime:   cjson.Uint64(defaultValidateEndTime.Add(time.Second).Unix()),
+	}
+	if err := service.GetDelegationCapacity(nil, &args, &reply); err == nil {
+		t.Fatal("should have failed because the period isn't within the validation period")
+	}
+
+	// Case: The validator can take 4 times its own stake
+	args = GetDelegationCapacityArgs{
+		NodeID:    nodeID,
+		StartTime: cjson.Uint64(startTime),
+		EndTime:   cjson.Uint64(endTime),
+	}
+	if err := service.GetDelegationCapacity(nil, &args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case uint64(reply.Capacity) != 4*defaultWeight:
+		t.Fatalf("expected a capacity of %d but got %d", 4*defaultWeight, reply.Capacity)
+	case reply.Amount != reply.Capacity:
+		t.Fatal("should have projected the reward of delegating the whole capacity")
+	case reply.DelegationFee != 100:
+		t.Fatalf("expected a delegation fee of 100%% but got %f%%", reply.DelegationFee)
+	case uint64(reply.DelegatorReward)+uint64(reply.ValidatorReward) != uint64(reply.PotentialReward):
+		t.Fatal("the reward should be split between the delegator and the validator")
+	}
+
+	// Case: Delegations use up the capacity over the period they overlap
+	service.vm.minDelegatorStake = defaultWeight
+	newDelegatorTx := func(weight uint64) *Tx {
+		tx, err := service.vm.newAddDelegatorTx(
+			weight,
+			startTime,
+			endTime,
+			keys[1].PublicKey().Address(),
+			ids.GenerateTestShortID(),
+			[]*crypto.PrivateKeySECP256K1R{keys[0]},
+			keys[0].PublicKey().Address(), // change addr
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+	tx := newDelegatorTx(defaultWeight)
+	if err := service.vm.addStaker(service.vm.DB, constants.PrimaryNetworkID, &rewardTx{Tx: *tx}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.GetDelegationCapacity(nil, &args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if uint64(reply.Capacity) != 3*defaultWeight {
+		t.Fatalf("expected a capacity of %d but got %d", 3*defaultWeight, reply.Capacity)
+	}
+
+	// A delegation is accepted only if it fits in the remaining capacity
+	tx = newDelegatorTx(3*defaultWeight + 1)
+	if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(service.vm, service.vm.DB, tx); err == nil {
+		t.Fatal("should have failed because the delegation exceeds the capacity")
+	}
+	tx = newDelegatorTx(3 * defaultWeight)
+	if _, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(service.vm, service.vm.DB, tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.vm.addStaker(service.vm.DB, constants.PrimaryNetworkID, &rewardTx{Tx: *tx}); err != nil {
+		t.Fatal(err)
+	}
+
+	args.Amount = cjson.Uint64(defaultWeight)
+	if err := service.GetDelegationCapacity(nil, &args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case reply.Capacity != 0:
+		t.Fatalf("expected no capacity but got %d", reply.Capacity)
+	case uint64(reply.Amount) != defaultWeight:
+		t.Fatal("should have projected the reward of the requested amount")
+	}
+}
+
+func TestGetMempoolTxs(t *testing.T) {
+	service := defaultService(t)
+	service.vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+
+	// The proposal tx is issued first but is put into a block after the
+	// decision txs
+	startTime := defaultGenesisTime.Add(syncBound).Add(time.Second)
+	proposalTx, err := service.vm.newAddValidatorTx(
+		service.vm.minValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+		ids.GenerateTestShortID(),
+		ids.GenerateTestShortID(),
+		PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := service.vm.mempool.IssueTx(proposalTx); err != nil {
+		t.Fatal(err)
+	}
+	decisionTxs := make([]*Tx, 2)
+	for i := range decisionTxs {
+		tx, err := service.vm.newCreateSubnetTx(
+			1, // threshold
+			[]ids.ShortID{keys[i+1].PublicKey().Address()},
+			[]*crypto.PrivateKeySECP256K1R{keys[i+1]},
+			ids.ShortEmpty, // change addr
+		)
+		if err != nil {
+			t.Fatal(err)
+		} else if err := service.vm.mempool.IssueTx(tx); err != nil {
+			t.Fatal(err)
+		}
+		decisionTxs[i] = tx
+	}
+
+	expected := []APIMempoolTx{
+		{
+			TxID:   decisionTxs[0].ID(),
+			Reason: "waiting to be put into the next standard block",
+		},
+		{
+			TxID:   decisionTxs[1].ID(),
+			Reason: "waiting to be put into the next standard block",
+		},
+		{
+			TxID:   proposalTx.ID(),
+			Reason: "waiting for 2 decision txs and 0 atomic txs to be put into blocks",
+		},
+	}
+
+	reply := GetMempoolTxsReply{}
+	if err := service.GetMempoolTxs(nil, nil, &reply); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(expected, reply.Txs) {
+		t.Fatalf("expected %v but got %v", expected, reply.Txs)
+	}
+
+	// The client gets the same txs over the API
+	server := httptest.NewServer(service.vm.CreateHandlers()[""].Handler)
+	defer server.Close()
+	txs, err := NewClient(server.URL, time.Second).GetMempoolTxs()
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(expected, txs) {
+		t.Fatalf("expected %v but got %v", expected, txs)
+	}
+}
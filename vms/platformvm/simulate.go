@@ -0,0 +1,351 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errMissingUTXO     = errors.New("input spends a UTXO that doesn't exist")
+	errWrongAmount     = errors.New("input amount doesn't match the UTXO")
+	errInsufficientFee = errors.New("transaction burns less than the required fee")
+)
+
+// simulation is the result of verifying a transaction without issuing it
+type simulation struct {
+	// True if the credentials of the transaction were verified
+	signed bool
+	// UTXOs the transaction consumes, as far as they could be found
+	consumed []*avax.UTXO
+	// UTXOs the transaction adds to the UTXO set of the P-Chain
+	produced []*avax.UTXO
+	// UTXOs the transaction locks for its staking period. They're added to
+	// the UTXO set once the staking period ends.
+	staked []*avax.UTXO
+	// UTXOs the transaction exports to another chain
+	exported []*avax.UTXO
+	// Amount of AVAX the transaction burns
+	fee uint64
+	// Amount of AVAX the transaction must burn
+	requiredFee uint64
+	// Reason the transaction is invalid, or nil if it is valid
+	err error
+}
+
+// parseSimulatedTx parses [txBytes], which may be a signed transaction or only
+// an unsigned transaction. An unsigned transaction is returned with
+// credentials that don't contain any signatures, if its type supports partial
+// signing, and without credentials otherwise.
+func (vm *VM) parseSimulatedTx(txBytes []byte) (*Tx, error) {
+	tx := &Tx{}
+	_, err := vm.codec.Unmarshal(txBytes, tx)
+	if err == nil {
+		// Signing without any signers sets the bytes of the transaction
+		return tx, tx.Sign(vm.codec, nil)
+	}
+
+	var utx UnsignedTx
+	if _, unsignedErr := vm.codec.Unmarshal(txBytes, &utx); unsignedErr != nil {
+		return nil, fmt.Errorf("couldn't parse bytes as a signed transaction (%s) or as an unsigned transaction (%s)", err, unsignedErr)
+	}
+	if tx, err := vm.newUnsignedTx(utx); err == nil {
+		return tx, nil
+	}
+	tx = &Tx{UnsignedTx: utx}
+	return tx, tx.Sign(vm.codec, nil)
+}
+
+// isFullySigned returns true if [tx] has credentials and none of them is
+// missing signatures
+func isFullySigned(tx *Tx) bool {
+	if len(tx.Creds) == 0 {
+		return false
+	}
+	for _, credIntf := range tx.Creds {
+		cred, ok := credIntf.(*secp256k1fx.Credential)
+		if !ok {
+			continue
+		}
+		for _, sig := range cred.Sigs {
+			if sig == [crypto.SECP256K1RSigLen]byte{} {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// flow is the inputs and outputs of a transaction
+type flow struct {
+	// Inputs of the transaction, with the [numImported] inputs it imports from
+	// [sourceChain] last
+	ins         []*avax.TransferableInput
+	sourceChain ids.ID
+	numImported int
+
+	// Outputs that are added to the UTXO set
+	outs []*avax.TransferableOutput
+	// Outputs that are locked for the staking period
+	stake []*avax.TransferableOutput
+	// Outputs that are exported to another chain
+	exported []*avax.TransferableOutput
+}
+
+// txFlow returns the inputs and outputs of [utx]
+func txFlow(utx UnsignedTx) *flow {
+	switch utx := utx.(type) {
+	case *UnsignedAddValidatorTx:
+		return &flow{ins: utx.Ins, outs: utx.Outs, stake: utx.Stake}
+	case *UnsignedAddDelegatorTx:
+		return &flow{ins: utx.Ins, outs: utx.Outs, stake: utx.Stake}
+	case *UnsignedAddSubnetValidatorTx:
+		return &flow{ins: utx.Ins, outs: utx.Outs}
+	case *UnsignedCreateChainTx:
+		return &flow{ins: utx.Ins, outs: utx.Outs}
+	case *UnsignedCreateSubnetTx:
+		return &flow{ins: utx.Ins, outs: utx.Outs}
+	case *UnsignedTransferSubnetOwnershipTx:
+		return &flow{ins: utx.Ins, outs: utx.Outs}
+	case *UnsignedRemoveSubnetValidatorTx:
+		return &flow{ins: utx.Ins, outs: utx.Outs}
+	case *UnsignedImportTx:
+		return &flow{
+			ins:         append(append([]*avax.TransferableInput(nil), utx.Ins...), utx.ImportedInputs...),
+			sourceChain: utx.SourceChain,
+			numImported: len(utx.ImportedInputs),
+			outs:        utx.Outs,
+		}
+	case *UnsignedExportTx:
+		return &flow{ins: utx.Ins, outs: utx.Outs, exported: utx.ExportedOutputs}
+	default:
+		return &flow{}
+	}
+}
+
+// flowUTXOs returns the UTXOs that [outs] of the transaction [txID] become,
+// where the first of [outs] has output index [offset]
+func flowUTXOs(txID ids.ID, offset int, outs []*avax.TransferableOutput) []*avax.UTXO {
+	utxos := make([]*avax.UTXO, len(outs))
+	for i, out := range outs {
+		utxos[i] = &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        txID,
+				OutputIndex: uint32(offset + i),
+			},
+			Asset: avax.Asset{ID: out.AssetID()},
+			Out:   out.Out,
+		}
+	}
+	return utxos
+}
+
+// requiredFee returns the amount of AVAX that [utx] must burn
+func (vm *VM) requiredFee(utx UnsignedTx) uint64 {
+	switch utx.(type) {
+	case *UnsignedAddSubnetValidatorTx, *UnsignedImportTx, *UnsignedExportTx, *UnsignedTransferSubnetOwnershipTx, *UnsignedRemoveSubnetValidatorTx:
+		return vm.txFee
+	case *UnsignedCreateChainTx, *UnsignedCreateSubnetTx:
+		return vm.creationTxFee
+	default:
+		return 0
+	}
+}
+
+// preferredState returns the state of the chain if the preferred block were
+// accepted. If the preferred block is a proposal block, it's the state if its
+// preferred option were accepted.
+func (vm *VM) preferredState() database.Database {
+	blk, err := vm.getBlock(vm.Preferred())
+	if err != nil {
+		return vm.DB
+	}
+	switch blk := blk.(type) {
+	case decision:
+		return blk.onAccept()
+	case *ProposalBlock:
+		tx, ok := blk.Tx.UnsignedTx.(UnsignedProposalTx)
+		if !ok {
+			return vm.DB
+		}
+		// The options are ordered the same way as by Options
+		var db *versiondb.Database
+		if tx.InitiallyPrefersCommit(vm) {
+			db, _ = blk.onCommit()
+		} else {
+			db, _ = blk.onAbort()
+		}
+		if db == nil {
+			// The block hasn't been verified yet
+			return vm.DB
+		}
+		return db
+	default:
+		return vm.DB
+	}
+}
+
+// simulateTx verifies [tx] on top of the preferred state without issuing it.
+// The credentials of [tx] are only verified if it is fully signed. Otherwise,
+// only the well-formedness of [tx] and the UTXOs it consumes are checked.
+func (vm *VM) simulateTx(tx *Tx) (*simulation, error) {
+	f := txFlow(tx.UnsignedTx)
+	txID := tx.ID()
+	sim := &simulation{
+		signed:      isFullySigned(tx),
+		produced:    flowUTXOs(txID, 0, f.outs),
+		staked:      flowUTXOs(txID, len(f.outs), f.stake),
+		exported:    flowUTXOs(txID, len(f.outs), f.exported),
+		requiredFee: vm.requiredFee(tx.UnsignedTx),
+	}
+
+	// Each asset must be consumed at least as much as it's produced, and the
+	// required fee must be burned on top of the AVAX that's produced
+	fc := avax.NewFlowChecker()
+	fc.Produce(vm.Ctx.AVAXAssetID, sim.requiredFee)
+	avaxConsumed, avaxProduced := uint64(0), uint64(0)
+	for _, in := range f.ins {
+		amount := in.In.Amount()
+		fc.Consume(in.AssetID(), amount)
+		if in.AssetID() != vm.Ctx.AVAXAssetID {
+			continue
+		}
+		var err error
+		avaxConsumed, err = safemath.Add64(avaxConsumed, amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, outs := range [][]*avax.TransferableOutput{f.outs, f.stake, f.exported} {
+		for _, out := range outs {
+			amount := out.Out.Amount()
+			fc.Produce(out.AssetID(), amount)
+			if out.AssetID() != vm.Ctx.AVAXAssetID {
+				continue
+			}
+			var err error
+			avaxProduced, err = safemath.Add64(avaxProduced, amount)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if avaxConsumed > avaxProduced {
+		sim.fee = avaxConsumed - avaxProduced
+	}
+
+	db := versiondb.New(vm.preferredState())
+	defer db.Abort()
+
+	// The consumed UTXOs are reported even if some of them are missing
+	var err error
+	sim.consumed, err = vm.spentUTXOs(db, f.ins, f.sourceChain, f.numImported)
+
+	if sim.signed {
+		switch utx := tx.UnsignedTx.(type) {
+		case UnsignedDecisionTx:
+			_, txErr := utx.SemanticVerify(vm, db, tx)
+			sim.err = txErr
+		case UnsignedProposalTx:
+			_, _, _, _, txErr := utx.SemanticVerify(vm, db, tx)
+			sim.err = txErr
+		case UnsignedAtomicTx:
+			sim.err = utx.SemanticVerify(vm, db, tx)
+		default:
+			sim.err = errUnknownTxType
+		}
+		return sim, nil
+	}
+
+	sim.err = vm.verifyUnsignedTx(tx.UnsignedTx)
+	flowErr := fc.Verify()
+	switch {
+	case sim.err != nil:
+	case err != nil:
+		sim.err = fmt.Errorf("%w: %s", errMissingUTXO, err)
+	case sim.fee < sim.requiredFee:
+		sim.err = fmt.Errorf("%w: burns %d but must burn %d", errInsufficientFee, sim.fee, sim.requiredFee)
+	case flowErr != nil:
+		sim.err = fmt.Errorf("transaction produces more than it consumes: %w", flowErr)
+	default:
+		sim.err = verifyUnsignedInputs(f.ins, sim.consumed)
+	}
+	return sim, nil
+}
+
+// verifyUnsignedTx verifies that [utx] is well-formed
+func (vm *VM) verifyUnsignedTx(utx UnsignedTx) error {
+	switch utx := utx.(type) {
+	case *UnsignedAddValidatorTx:
+		return utx.Verify(
+			vm.Ctx,
+			vm.codec,
+			vm.minValidatorStake,
+			vm.maxValidatorStake,
+			vm.minStakeDuration,
+			vm.maxStakeDuration,
+			vm.minDelegationFee,
+		)
+	case *UnsignedAddDelegatorTx:
+		return utx.Verify(
+			vm.Ctx,
+			vm.codec,
+			vm.minDelegatorStake,
+			vm.minStakeDuration,
+			vm.maxStakeDuration,
+		)
+	case *UnsignedAddSubnetValidatorTx:
+		return utx.Verify(
+			vm.Ctx,
+			vm.codec,
+			vm.txFee,
+			vm.Ctx.AVAXAssetID,
+			vm.minStakeDuration,
+			vm.maxStakeDuration,
+		)
+	case *UnsignedCreateChainTx:
+		return utx.Verify(vm.Ctx, vm.codec, vm.creationTxFee, vm.Ctx.AVAXAssetID)
+	case *UnsignedCreateSubnetTx:
+		return utx.Verify(vm.Ctx, vm.codec, vm.creationTxFee, vm.Ctx.AVAXAssetID)
+	case *UnsignedTransferSubnetOwnershipTx:
+		return utx.Verify(vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID)
+	case *UnsignedRemoveSubnetValidatorTx:
+		return utx.Verify(vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID)
+	case *UnsignedImportTx:
+		return utx.Verify(vm.Ctx.XChainID, vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID)
+	case *UnsignedExportTx:
+		return utx.Verify(vm.Ctx.XChainID, vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID)
+	default:
+		return errUnknownTxType
+	}
+}
+
+// verifyUnsignedInputs verifies that [utxos[i]] is the UTXO consumed by
+// [ins[i]], except for the credentials that authorize spending it
+func verifyUnsignedInputs(ins []*avax.TransferableInput, utxos []*avax.UTXO) error {
+	if len(ins) != len(utxos) {
+		return errMissingUTXO
+	}
+	for i, in := range ins {
+		utxo := utxos[i]
+		if utxo.AssetID() != in.AssetID() {
+			return errAssetIDMismatch
+		}
+		out, ok := utxo.Out.(avax.TransferableOut)
+		if !ok || out.Amount() != in.In.Amount() {
+			return fmt.Errorf("%w: input %s", errWrongAmount, in.InputID())
+		}
+	}
+	return nil
+}
@@ -19,26 +19,30 @@ var (
 	errLockedFundsNotMarkedAsLocked = errors.New("locked funds not marked as locked")
 	errWrongLocktime                = errors.New("wrong locktime reported")
 	errUnknownOwners                = errors.New("unknown owners")
-	errCantSign                     = errors.New("can't sign")
 )
 
-// stake the provided amount while deducting the provided fee.
-// Arguments:
-// - [db] is the database that is used to attempt to fetch the funds from.
-// - [keys] are the owners of the funds
-// - [amount] is the amount of funds that are trying to be staked
-// - [fee] is the amount of AVAX that should be burned
-// - [changeAddr] is the address that change, if there is any, is sent to
-// Returns:
-// - [inputs] the inputs that should be consumed to fund the outputs
-// - [returnedOutputs] the outputs that should be immediately returned to the
-//                     UTXO set
-// - [stakedOutputs] the outputs that should be locked for the duration of the
-//                   staking period
-// - [signers] the proof of ownership of the funds being moved
-func (vm *VM) stake(
+// keychainUTXOs returns a keychain that holds [keys] and the UTXOs in [db]
+// that are owned by the addresses of [keys]
+func (vm *VM) keychainUTXOs(
 	db database.Database,
 	keys []*crypto.PrivateKeySECP256K1R,
+) (*secp256k1fx.Keychain, []*avax.UTXO, error) {
+	kc := secp256k1fx.NewKeychain()
+	for _, key := range keys {
+		kc.Add(key)
+	}
+	utxos, _, _, err := vm.GetUTXOs(db, kc.Addrs, ids.ShortEmpty, ids.Empty, -1, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
+	}
+	return kc, utxos, nil
+}
+
+// stakeWithAddresses is like stake, but only needs the addresses that will
+// sign the inputs rather than their keys. The returned signers are empty.
+func (vm *VM) stakeWithAddresses(
+	db database.Database,
+	addrs ids.ShortSet,
 	amount uint64,
 	fee uint64,
 	changeAddr ids.ShortID,
@@ -49,274 +53,28 @@ func (vm *VM) stake(
 	[][]*crypto.PrivateKeySECP256K1R, // signers
 	error,
 ) {
-	addrs := ids.ShortSet{} // The addresses controlled by [keys]
-	for _, key := range keys {
-		addrs.Add(key.PublicKey().Address())
-	}
-	utxos, _, _, err := vm.GetUTXOs(db, addrs, ids.ShortEmpty, ids.Empty, -1, false) // The UTXOs controlled by [keys]
+	utxos, _, _, err := vm.GetUTXOs(db, addrs, ids.ShortEmpty, ids.Empty, -1, false) // The UTXOs controlled by [addrs]
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
 	}
-
-	kc := secp256k1fx.NewKeychain() // Keychain consumes UTXOs and creates new ones
-	for _, key := range keys {
-		kc.Add(key)
-	}
-
-	// Minimum time this transaction will be issued at
-	now := uint64(vm.clock.Time().Unix())
-
-	ins := []*avax.TransferableInput{}
-	returnedOuts := []*avax.TransferableOutput{}
-	stakedOuts := []*avax.TransferableOutput{}
-	signers := [][]*crypto.PrivateKeySECP256K1R{}
-
-	// Amount of AVAX that has been staked
-	amountStaked := uint64(0)
-
-	// Consume locked UTXOs
-	for _, utxo := range utxos {
-		// If we have consumed more AVAX than we are trying to stake, then we
-		// have no need to consume more locked AVAX
-		if amountStaked >= amount {
-			break
-		}
-
-		if assetID := utxo.AssetID(); assetID != vm.Ctx.AVAXAssetID {
-			continue // We only care about staking AVAX, so ignore other assets
-		}
-
-		out, ok := utxo.Out.(*StakeableLockOut)
-		if !ok {
-			// This output isn't locked, so it will be handled during the next
-			// iteration of the UTXO set
-			continue
-		}
-		if out.Locktime <= now {
-			// This output is no longer locked, so it will be handled during the
-			// next iteration of the UTXO set
-			continue
-		}
-
-		inner, ok := out.TransferableOut.(*secp256k1fx.TransferOutput)
-		if !ok {
-			// We only know how to clone secp256k1 outputs for now
-			continue
-		}
-
-		inIntf, inSigners, err := kc.Spend(out.TransferableOut, now)
-		if err != nil {
-			// We couldn't spend the output, so move on to the next one
-			continue
-		}
-		in, ok := inIntf.(avax.TransferableIn)
-		if !ok { // should never happen
-			vm.Ctx.Log.Warn("expected input to be avax.TransferableIn but is %T", inIntf)
-			continue
-		}
-
-		// The remaining value is initially the full value of the input
-		remainingValue := in.Amount()
-
-		// Stake any value that should be staked
-		amountToStake := safemath.Min64(
-			amount-amountStaked, // Amount we still need to stake
-			remainingValue,      // Amount available to stake
-		)
-		amountStaked += amountToStake
-		remainingValue -= amountToStake
-
-		// Add the input to the consumed inputs
-		ins = append(ins, &avax.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  avax.Asset{ID: vm.Ctx.AVAXAssetID},
-			In: &StakeableLockIn{
-				Locktime:       out.Locktime,
-				TransferableIn: in,
-			},
-		})
-
-		// Add the output to the staked outputs
-		stakedOuts = append(stakedOuts, &avax.TransferableOutput{
-			Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
-			Out: &StakeableLockOut{
-				Locktime: out.Locktime,
-				TransferableOut: &secp256k1fx.TransferOutput{
-					Amt:          amountToStake,
-					OutputOwners: inner.OutputOwners,
-				},
-			},
-		})
-
-		if remainingValue > 0 {
-			// This input provided more value than was needed to be locked.
-			// Some of it must be returned
-			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
-				Out: &StakeableLockOut{
-					Locktime: out.Locktime,
-					TransferableOut: &secp256k1fx.TransferOutput{
-						Amt:          remainingValue,
-						OutputOwners: inner.OutputOwners,
-					},
-				},
-			})
-		}
-
-		// Add the signers needed for this input to the set of signers
-		signers = append(signers, inSigners)
-	}
-
-	// Amount of AVAX that has been burned
-	amountBurned := uint64(0)
-
-	for _, utxo := range utxos {
-		// If we have consumed more AVAX than we are trying to stake, and we
-		// have burned more AVAX then we need to, then we have no need to
-		// consume more AVAX
-		if amountBurned >= fee && amountStaked >= amount {
-			break
-		}
-
-		if assetID := utxo.AssetID(); assetID != vm.Ctx.AVAXAssetID {
-			continue // We only care about burning AVAX, so ignore other assets
-		}
-
-		out := utxo.Out
-		inner, ok := out.(*StakeableLockOut)
-		if ok {
-			if inner.Locktime > now {
-				// This output is currently locked, so this output can't be
-				// burned. Additionally, it may have already been consumed
-				// above. Regardless, we skip to the next UTXO
-				continue
-			}
-			out = inner.TransferableOut
-		}
-
-		inIntf, inSigners, err := kc.Spend(out, now)
-		if err != nil {
-			// We couldn't spend this UTXO, so we skip to the next one
-			continue
-		}
-		in, ok := inIntf.(avax.TransferableIn)
-		if !ok {
-			// Because we only use the secp Fx right now, this should never
-			// happen
-			continue
-		}
-
-		// The remaining value is initially the full value of the input
-		remainingValue := in.Amount()
-
-		// Burn any value that should be burned
-		amountToBurn := safemath.Min64(
-			fee-amountBurned, // Amount we still need to burn
-			remainingValue,   // Amount available to burn
-		)
-		amountBurned += amountToBurn
-		remainingValue -= amountToBurn
-
-		// Stake any value that should be staked
-		amountToStake := safemath.Min64(
-			amount-amountStaked, // Amount we still need to stake
-			remainingValue,      // Amount available to stake
-		)
-		amountStaked += amountToStake
-		remainingValue -= amountToStake
-
-		// Add the input to the consumed inputs
-		ins = append(ins, &avax.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  avax.Asset{ID: vm.Ctx.AVAXAssetID},
-			In:     in,
-		})
-
-		if amountToStake > 0 {
-			// Some of this input was put for staking
-			stakedOuts = append(stakedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: amountToStake,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{changeAddr},
-					},
-				},
-			})
-		}
-
-		if remainingValue > 0 {
-			// This input had extra value, so some of it must be returned
-			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: remainingValue,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{changeAddr},
-					},
-				},
-			})
-		}
-
-		// Add the signers needed for this input to the set of signers
-		signers = append(signers, inSigners)
-	}
-
-	if amountBurned < fee || amountStaked < amount {
-		return nil, nil, nil, nil, fmt.Errorf(
-			"provided keys have balance (unlocked, locked) (%d, %d) but need (%d, %d)",
-			amountBurned, amountStaked, fee, amount)
+	spend := func(out verify.Verifiable, time uint64) (verify.Verifiable, []*crypto.PrivateKeySECP256K1R, error) {
+		in, err := secp256k1fx.SpendWithAddresses(out, addrs, time)
+		return in, nil, err
 	}
-
-	avax.SortTransferableInputsWithSigners(ins, signers) // sort inputs and keys
-	avax.SortTransferableOutputs(returnedOuts, vm.codec) // sort outputs
-	avax.SortTransferableOutputs(stakedOuts, vm.codec)   // sort outputs
-
-	return ins, returnedOuts, stakedOuts, signers, nil
+	return vm.txBuilder().StakeWith(utxos, spend, amount, fee, changeAddr)
 }
 
-// authorize an operation on behalf of the named subnet with the provided keys.
-func (vm *VM) authorize(
-	db database.Database,
-	subnetID ids.ID,
-	keys []*crypto.PrivateKeySECP256K1R,
-) (
-	verify.Verifiable, // Input that names owners
-	[]*crypto.PrivateKeySECP256K1R, // Keys that prove ownership
-	error,
-) {
-	// Get information about the subnet we're authorizing the operation for
-	subnet, err := vm.getSubnet(db, subnetID)
+// subnetOwner returns the owner of the subnet [subnetID]
+func (vm *VM) subnetOwner(db database.Database, subnetID ids.ID) (*secp256k1fx.OutputOwners, error) {
+	ownerIntf, err := vm.getSubnetOwner(db, subnetID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("subnet %s doesn't exist", subnetID)
+		return nil, fmt.Errorf("subnet %s doesn't exist", subnetID)
 	}
-
-	// Make sure the owners of the subnet match the provided keys
-	owner, ok := subnet.UnsignedTx.(*UnsignedCreateSubnetTx).Owner.(*secp256k1fx.OutputOwners)
+	owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
 	if !ok {
-		return nil, nil, errUnknownOwners
-	}
-
-	// Add the keys to a keychain
-	kc := secp256k1fx.NewKeychain()
-	for _, key := range keys {
-		kc.Add(key)
+		return nil, errUnknownOwners
 	}
-
-	// Make sure that the operation is valid after a minimum time
-	now := uint64(vm.clock.Time().Unix())
-
-	// Attempt to prove ownership of the subnet
-	indices, signers, matches := kc.Match(owner, now)
-	if !matches {
-		return nil, nil, errCantSign
-	}
-
-	return &secp256k1fx.Input{SigIndices: indices}, signers, nil
+	return owner, nil
 }
 
 // Verify that [tx] is semantically valid.
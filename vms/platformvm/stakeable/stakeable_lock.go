@@ -0,0 +1,99 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package stakeable defines the outputs and inputs of the P-Chain that hold
+// AVAX that is locked until a given time, but that can be staked before then.
+// They are defined apart from the P-Chain so that transactions that spend them
+// can be built without a node.
+package stakeable
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+var (
+	errInvalidLocktime = errors.New("invalid locktime")
+)
+
+// LockOut is an output that can't be spent until [Locktime]
+type LockOut struct {
+	Locktime             uint64 `serialize:"true" json:"locktime"`
+	avax.TransferableOut `serialize:"true"`
+}
+
+// Addresses ...
+func (s *LockOut) Addresses() [][]byte {
+	if addressable, ok := s.TransferableOut.(avax.Addressable); ok {
+		return addressable.Addresses()
+	}
+	return nil
+}
+
+// Verify ...
+func (s *LockOut) Verify() error {
+	if s.Locktime == 0 {
+		return errInvalidLocktime
+	}
+	if _, nested := s.TransferableOut.(*LockOut); nested {
+		return errors.New("shouldn't nest stakeable locks")
+	}
+	return s.TransferableOut.Verify()
+}
+
+// JSONLockOut is the JSON representation of LockOut
+type JSONLockOut struct {
+	Locktime uint64 `json:"locktime"`
+	avax.JSONOutput
+}
+
+// JSON returns the JSON representation of [s], with the addresses formatted by
+// [formatAddr]
+func (s *LockOut) JSON(formatAddr func(ids.ShortID) (string, error)) (interface{}, error) {
+	outJSON, err := avax.OutputJSON(s.TransferableOut, formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLockOut{
+		Locktime:   s.Locktime,
+		JSONOutput: outJSON,
+	}, nil
+}
+
+// LockIn is an input that spends a LockOut
+type LockIn struct {
+	Locktime            uint64 `serialize:"true" json:"locktime"`
+	avax.TransferableIn `serialize:"true"`
+}
+
+// Verify ...
+func (s *LockIn) Verify() error {
+	if s.Locktime == 0 {
+		return errInvalidLocktime
+	}
+	if _, nested := s.TransferableIn.(*LockIn); nested {
+		return errors.New("shouldn't nest stakeable locks")
+	}
+	return s.TransferableIn.Verify()
+}
+
+// JSONLockIn is the JSON representation of LockIn
+type JSONLockIn struct {
+	Locktime uint64 `json:"locktime"`
+	avax.JSONInput
+}
+
+// JSON returns the JSON representation of [s], with the addresses formatted by
+// [formatAddr]
+func (s *LockIn) JSON(formatAddr func(ids.ShortID) (string, error)) (interface{}, error) {
+	inJSON, err := avax.InputJSON(s.TransferableIn, formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLockIn{
+		Locktime:  s.Locktime,
+		JSONInput: inJSON,
+	}, nil
+}
@@ -1,53 +1,15 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
 package platformvm
 
 import (
-	"errors"
-
-	"github.com/ava-labs/avalanchego/vms/components/avax"
-)
-
-var (
-	errInvalidLocktime = errors.New("invalid locktime")
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 )
 
-// StakeableLockOut ...
-type StakeableLockOut struct {
-	Locktime             uint64 `serialize:"true" json:"locktime"`
-	avax.TransferableOut `serialize:"true"`
-}
-
-// Addresses ...
-func (s *StakeableLockOut) Addresses() [][]byte {
-	if addressable, ok := s.TransferableOut.(avax.Addressable); ok {
-		return addressable.Addresses()
-	}
-	return nil
-}
-
-// Verify ...
-func (s *StakeableLockOut) Verify() error {
-	if s.Locktime == 0 {
-		return errInvalidLocktime
-	}
-	if _, nested := s.TransferableOut.(*StakeableLockOut); nested {
-		return errors.New("shouldn't nest stakeable locks")
-	}
-	return s.TransferableOut.Verify()
-}
-
-// StakeableLockIn ...
-type StakeableLockIn struct {
-	Locktime            uint64 `serialize:"true" json:"locktime"`
-	avax.TransferableIn `serialize:"true"`
-}
+// StakeableLockOut is an output that can't be spent until its locktime, but
+// can be staked before then
+type StakeableLockOut = stakeable.LockOut
 
-// Verify ...
-func (s *StakeableLockIn) Verify() error {
-	if s.Locktime == 0 {
-		return errInvalidLocktime
-	}
-	if _, nested := s.TransferableIn.(*StakeableLockIn); nested {
-		return errors.New("shouldn't nest stakeable locks")
-	}
-	return s.TransferableIn.Verify()
-}
+// StakeableLockIn is an input that spends a StakeableLockOut
+type StakeableLockIn = stakeable.LockIn
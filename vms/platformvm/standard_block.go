@@ -99,6 +99,9 @@ func (sb *StandardBlock) Verify() error {
 		}
 	}
 
+	// The txs no longer need to be put into a block
+	sb.vm.mempool.RemoveTxs(sb.Txs...)
+
 	sb.vm.currentBlocks[sb.ID()] = sb
 	sb.parentBlock().addChild(sb)
 	return nil
@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/state"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
@@ -30,6 +31,10 @@ const (
 	startDBPrefix  = "start"
 	stopDBPrefix   = "stop"
 	uptimeDBPrefix = "uptime"
+
+	stakingOutcomeDBPrefix = "stakingOutcome"
+	subnetOwnerDBPrefix    = "subnetOwner"
+	mempoolDBPrefix        = "mempool"
 )
 
 var (
@@ -71,7 +76,7 @@ func (vm *VM) getStatus(db database.Database, id ids.ID) (Status, error) {
 }
 
 // Add a staker to subnet [subnetID]'s pending validator queue. A staker may be
-// a validator or a delegator
+// a validator, a delegator or the removal of a subnet validator
 func (vm *VM) enqueueStaker(db database.Database, subnetID ids.ID, stakerTx *Tx) error {
 	var (
 		staker   TimedTx
@@ -87,6 +92,11 @@ func (vm *VM) enqueueStaker(db database.Database, subnetID ids.ID, stakerTx *Tx)
 	case *UnsignedAddValidatorTx:
 		staker = unsignedTx
 		priority = 2
+	case *UnsignedRemoveSubnetValidatorTx:
+		// Removals happen after the validators starting at the same time
+		// are added
+		staker = unsignedTx
+		priority = 3
 	default:
 		return fmt.Errorf("staker is unexpected type %T", stakerTx)
 	}
@@ -118,7 +128,7 @@ func (vm *VM) enqueueStaker(db database.Database, subnetID ids.ID, stakerTx *Tx)
 }
 
 // Remove a staker from subnet [subnetID]'s pending validator queue. A staker
-// may be a validator or a delegator
+// may be a validator, a delegator or the removal of a subnet validator
 func (vm *VM) dequeueStaker(db database.Database, subnetID ids.ID, stakerTx *Tx) error {
 	var (
 		staker   TimedTx
@@ -134,6 +144,11 @@ func (vm *VM) dequeueStaker(db database.Database, subnetID ids.ID, stakerTx *Tx)
 	case *UnsignedAddValidatorTx:
 		staker = unsignedTx
 		priority = 2
+	case *UnsignedRemoveSubnetValidatorTx:
+		// Removals happen after the validators starting at the same time
+		// are added
+		staker = unsignedTx
+		priority = 3
 	default:
 		return fmt.Errorf("staker is unexpected type %T", stakerTx)
 	}
@@ -169,17 +184,21 @@ func (vm *VM) addStaker(db database.Database, subnetID ids.ID, tx *rewardTx) err
 	var (
 		staker   TimedTx
 		priority byte
+		vdr      Validator
 	)
 	switch unsignedTx := tx.Tx.UnsignedTx.(type) {
 	case *UnsignedAddDelegatorTx:
 		staker = unsignedTx
 		priority = 0
+		vdr = unsignedTx.Validator
 	case *UnsignedAddSubnetValidatorTx:
 		staker = unsignedTx
 		priority = 1
+		vdr = unsignedTx.Validator.Validator
 	case *UnsignedAddValidatorTx:
 		staker = unsignedTx
 		priority = 2
+		vdr = unsignedTx.Validator
 	default:
 		return fmt.Errorf("staker is unexpected type %T", tx.Tx.UnsignedTx)
 	}
@@ -211,6 +230,11 @@ func (vm *VM) addStaker(db database.Database, subnetID ids.ID, tx *rewardTx) err
 	errs.Add(
 		prefixStopDB.Put(stopKey, txBytes),
 		prefixStopDB.Close(),
+		vm.addPendingValidatorDiff(db, subnetID, validatorDiff{
+			NodeID:   vdr.NodeID,
+			Weight:   vdr.Weight(),
+			Decrease: false,
+		}),
 	)
 	return errs.Err
 }
@@ -221,17 +245,21 @@ func (vm *VM) removeStaker(db database.Database, subnetID ids.ID, tx *rewardTx)
 	var (
 		staker   TimedTx
 		priority byte
+		vdr      Validator
 	)
 	switch unsignedTx := tx.Tx.UnsignedTx.(type) {
 	case *UnsignedAddDelegatorTx:
 		staker = unsignedTx
 		priority = 0
+		vdr = unsignedTx.Validator
 	case *UnsignedAddSubnetValidatorTx:
 		staker = unsignedTx
 		priority = 1
+		vdr = unsignedTx.Validator.Validator
 	case *UnsignedAddValidatorTx:
 		staker = unsignedTx
 		priority = 2
+		vdr = unsignedTx.Validator
 	default:
 		return fmt.Errorf("staker is unexpected type %T", tx.Tx.UnsignedTx)
 	}
@@ -258,6 +286,11 @@ func (vm *VM) removeStaker(db database.Database, subnetID ids.ID, tx *rewardTx)
 	errs.Add(
 		prefixStopDB.Delete(stopKey),
 		prefixStopDB.Close(),
+		vm.addPendingValidatorDiff(db, subnetID, validatorDiff{
+			NodeID:   vdr.NodeID,
+			Weight:   vdr.Weight(),
+			Decrease: true,
+		}),
 	)
 	return errs.Err
 }
@@ -708,6 +741,98 @@ func (vm *VM) getSubnet(db database.Database, id ids.ID) (*Tx, TxError) {
 	return nil, permError{fmt.Errorf("couldn't find subnet with ID %s", id)}
 }
 
+// get the owner of the subnet with the specified ID. The owner is the one the
+// subnet was created with, unless its ownership was transferred since.
+func (vm *VM) getSubnetOwner(db database.Database, id ids.ID) (verify.Verifiable, TxError) {
+	subnet, txErr := vm.getSubnet(db, id)
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	ownerDB := prefixdb.NewNested([]byte(subnetOwnerDBPrefix), db)
+	defer ownerDB.Close()
+
+	ownerBytes, err := ownerDB.Get(id[:])
+	switch {
+	case err == database.ErrNotFound:
+		return subnet.UnsignedTx.(*UnsignedCreateSubnetTx).Owner, nil
+	case err != nil:
+		return nil, tempError{err}
+	}
+
+	var owner verify.Verifiable
+	if _, err := Codec.Unmarshal(ownerBytes, &owner); err != nil {
+		return nil, tempError{err}
+	}
+	return owner, nil
+}
+
+// put the owner of the subnet with the specified ID
+func (vm *VM) putSubnetOwner(db database.Database, id ids.ID, owner verify.Verifiable) error {
+	ownerBytes, err := Codec.Marshal(codecVersion, &owner)
+	if err != nil {
+		return err
+	}
+
+	ownerDB := prefixdb.NewNested([]byte(subnetOwnerDBPrefix), db)
+	errs := wrappers.Errs{}
+	errs.Add(
+		ownerDB.Put(id[:], ownerBytes),
+		ownerDB.Close(),
+	)
+	return errs.Err
+}
+
+// persist [txBytes], the tx with ID [txID], as being in the mempool
+func (vm *VM) putMempoolTx(db database.Database, txID ids.ID, txBytes []byte) error {
+	mempoolDB := prefixdb.NewNested([]byte(mempoolDBPrefix), db)
+	errs := wrappers.Errs{}
+	errs.Add(
+		mempoolDB.Put(txID[:], txBytes),
+		mempoolDB.Close(),
+	)
+	return errs.Err
+}
+
+// delete the tx with ID [txID] from the persisted mempool
+func (vm *VM) deleteMempoolTx(db database.Database, txID ids.ID) error {
+	mempoolDB := prefixdb.NewNested([]byte(mempoolDBPrefix), db)
+	errs := wrappers.Errs{}
+	errs.Add(
+		mempoolDB.Delete(txID[:]),
+		mempoolDB.Close(),
+	)
+	return errs.Err
+}
+
+// get the txs that were persisted as being in the mempool
+func (vm *VM) getMempoolTxs(db database.Database) ([]*Tx, error) {
+	mempoolDB := prefixdb.NewNested([]byte(mempoolDBPrefix), db)
+	defer mempoolDB.Close()
+
+	iter := mempoolDB.NewIterator()
+	defer iter.Release()
+
+	txs := []*Tx(nil)
+	for iter.Next() {
+		tx := &Tx{}
+		if _, err := vm.codec.Unmarshal(iter.Value(), tx); err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal mempool tx: %w", err)
+		}
+		if err := tx.Sign(vm.codec, nil); err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		iter.Error(),
+		mempoolDB.Close(),
+	)
+	return txs, errs.Err
+}
+
 // Returns the height of the preferred block
 func (vm *VM) preferredHeight() (uint64, error) {
 	preferred, err := vm.getBlock(vm.Preferred())
@@ -914,6 +1039,53 @@ func (vm *VM) deleteUptime(db database.Database, nodeID ids.ShortID) error {
 	return errs.Err
 }
 
+// stakingOutcome is what happened to a staker once its staking period ended
+type stakingOutcome struct {
+	// True if the staker was rewarded
+	Rewarded bool `serialize:"true"`
+	// Reward of the staker, including the part that went to the validator if
+	// the staker is a delegator. 0 if the staker wasn't rewarded.
+	Reward uint64 `serialize:"true"`
+	// UTXOs that returned the stake and paid the reward
+	UTXOs []*avax.UTXO `serialize:"true"`
+	// Uptime of the staker observed by this node when the outcome was decided,
+	// out of PercentDenominator
+	Uptime uint64 `serialize:"true"`
+}
+
+// getStakingOutcome returns the outcome of the staking period of the staker
+// that [txID] added
+func (vm *VM) getStakingOutcome(db database.Database, txID ids.ID) (*stakingOutcome, error) {
+	outcomeDB := prefixdb.NewNested([]byte(stakingOutcomeDBPrefix), db)
+	defer outcomeDB.Close()
+
+	outcomeBytes, err := outcomeDB.Get(txID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := stakingOutcome{}
+	if _, err := vm.codec.Unmarshal(outcomeBytes, &outcome); err != nil {
+		return nil, err
+	}
+	return &outcome, outcomeDB.Close()
+}
+
+func (vm *VM) putStakingOutcome(db database.Database, txID ids.ID, outcome *stakingOutcome) error {
+	outcomeBytes, err := vm.codec.Marshal(codecVersion, outcome)
+	if err != nil {
+		return err
+	}
+
+	outcomeDB := prefixdb.NewNested([]byte(stakingOutcomeDBPrefix), db)
+	errs := wrappers.Errs{}
+	errs.Add(
+		outcomeDB.Put(txID[:], outcomeBytes),
+		outcomeDB.Close(),
+	)
+	return errs.Err
+}
+
 // Unmarshal a Block from bytes and initialize it
 // The Block being unmarshaled must have had static type Block when it was marshaled
 // i.e. don't do:
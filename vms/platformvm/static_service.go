@@ -63,6 +63,13 @@ type APIStaker struct {
 	NodeID      string       `json:"nodeID"`
 }
 
+// APISubnetValidator is the repr. of a subnet validator sent over APIs.
+type APISubnetValidator struct {
+	APIStaker
+	// The time the validator is removed at, if its removal is pending
+	RemovalTime *json.Uint64 `json:"removalTime,omitempty"`
+}
+
 // APIOwner is the repr. of a reward owner sent over APIs.
 type APIOwner struct {
 	Locktime  json.Uint64 `json:"locktime"`
@@ -74,13 +81,15 @@ type APIOwner struct {
 type APIPrimaryValidator struct {
 	APIStaker
 	// The owner the staking reward, if applicable, will go to
-	RewardOwner        *APIOwner     `json:"rewardOwner,omitempty"`
-	PotentialReward    *json.Uint64  `json:"potentialReward,omitempty"`
-	DelegationFee      json.Float32  `json:"delegationFee"`
-	ExactDelegationFee *json.Uint32  `json:"exactDelegationFee,omitempty"`
-	Uptime             *json.Float32 `json:"uptime,omitempty"`
-	Connected          *bool         `json:"connected,omitempty"`
-	Staked             []APIUTXO     `json:"staked,omitempty"`
+	RewardOwner     *APIOwner    `json:"rewardOwner,omitempty"`
+	PotentialReward *json.Uint64 `json:"potentialReward,omitempty"`
+	// The part of the rewards of the delegators that this validator receives
+	PotentialDelegationReward *json.Uint64  `json:"potentialDelegationReward,omitempty"`
+	DelegationFee             json.Float32  `json:"delegationFee"`
+	ExactDelegationFee        *json.Uint32  `json:"exactDelegationFee,omitempty"`
+	Uptime                    *json.Float32 `json:"uptime,omitempty"`
+	Connected                 *bool         `json:"connected,omitempty"`
+	Staked                    []APIUTXO     `json:"staked,omitempty"`
 	// The delegators delegating to this validator
 	Delegators []APIPrimaryDelegator `json:"delegators"`
 }
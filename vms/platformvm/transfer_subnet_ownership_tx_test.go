@@ -0,0 +1,147 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestUnsignedTransferSubnetOwnershipTxVerify(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	tx, err := vm.newTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		[]ids.ShortID{keys[3].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	utx := tx.UnsignedTx.(*UnsignedTransferSubnetOwnershipTx)
+	utx.syntacticallyVerified = false
+	utx.Subnet = constants.PrimaryNetworkID
+	if err := utx.Verify(vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID); err == nil {
+		t.Fatal("should have failed because the primary network has no owner")
+	}
+
+	utx.Subnet = testSubnet1.ID()
+	utx.Owner = &secp256k1fx.OutputOwners{
+		Threshold: 2,
+		Addrs:     []ids.ShortID{keys[3].PublicKey().Address()},
+	}
+	if err := utx.Verify(vm.Ctx, vm.codec, vm.txFee, vm.Ctx.AVAXAssetID); err == nil {
+		t.Fatal("should have failed because the threshold is above the number of addresses")
+	}
+}
+
+// Ensure SemanticVerify fails when the current owners' threshold isn't met
+func TestTransferSubnetOwnershipTxInsufficientControlSigs(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	tx, err := vm.newTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		[]ids.ShortID{keys[3].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove a signature
+	subnetCred := tx.Creds[len(tx.Creds)-1].(*secp256k1fx.Credential)
+	subnetCred.Sigs = subnetCred.Sigs[1:]
+	if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vm.DB, tx); err == nil {
+		t.Fatal("should have errored because a sig is missing")
+	}
+}
+
+// Ensure the new owners, and only them, manage the subnet once its ownership
+// is transferred
+func TestTransferSubnetOwnershipTxValid(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	newOwnerKey := keys[3]
+	tx, err := vm.newTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		[]ids.ShortID{newOwnerKey.PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vm.DB, tx); err != nil {
+		t.Fatalf("expected tx to pass verification but got error: %v", err)
+	}
+
+	owner, err := vm.subnetOwner(vm.DB, testSubnet1.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner.Threshold != 1 || len(owner.Addrs) != 1 || !owner.Addrs[0].Equals(newOwnerKey.PublicKey().Address()) {
+		t.Fatalf("expected the subnet to be owned by %s", newOwnerKey.PublicKey().Address())
+	}
+
+	// The previous owners can't manage the subnet anymore
+	if _, err := vm.newCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		avm.ID,
+		nil,
+		"chain name",
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	); err == nil {
+		t.Fatal("should have failed because the keys don't own the subnet anymore")
+	}
+
+	createChainTx, err := vm.newCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		avm.ID,
+		nil,
+		"chain name",
+		[]*crypto.PrivateKeySECP256K1R{newOwnerKey},
+		ids.ShortEmpty, // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := createChainTx.UnsignedTx.(UnsignedDecisionTx).SemanticVerify(vm, vm.DB, createChainTx); err != nil {
+		t.Fatalf("expected tx to pass verification but got error: %v", err)
+	}
+}
@@ -0,0 +1,305 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// JSONTx is the JSON representation of a transaction, with its inputs, outputs
+// and credentials decoded and its addresses formatted
+type JSONTx struct {
+	ID ids.ID `json:"id"`
+	// Type of the unsigned transaction, such as "platformvm.UnsignedAddValidatorTx"
+	Type        string                `json:"type"`
+	UnsignedTx  interface{}           `json:"unsignedTx"`
+	Credentials []avax.JSONCredential `json:"credentials"`
+}
+
+// JSONValidator is the JSON representation of Validator
+type JSONValidator struct {
+	NodeID string `json:"nodeID"`
+	Start  uint64 `json:"start"`
+	End    uint64 `json:"end"`
+	Weight uint64 `json:"weight"`
+}
+
+// JSONAddValidatorTx is the JSON representation of UnsignedAddValidatorTx
+type JSONAddValidatorTx struct {
+	*avax.JSONBaseTx
+	Validator    JSONValidator                  `json:"validator"`
+	Stake        []*avax.JSONTransferableOutput `json:"stake"`
+	RewardsOwner avax.JSONOutput                `json:"rewardsOwner"`
+	Shares       uint32                         `json:"shares"`
+}
+
+// JSONAddDelegatorTx is the JSON representation of UnsignedAddDelegatorTx
+type JSONAddDelegatorTx struct {
+	*avax.JSONBaseTx
+	Validator    JSONValidator                  `json:"validator"`
+	Stake        []*avax.JSONTransferableOutput `json:"stake"`
+	RewardsOwner avax.JSONOutput                `json:"rewardsOwner"`
+}
+
+// JSONAddSubnetValidatorTx is the JSON representation of
+// UnsignedAddSubnetValidatorTx
+type JSONAddSubnetValidatorTx struct {
+	*avax.JSONBaseTx
+	Validator  JSONValidator  `json:"validator"`
+	Subnet     ids.ID         `json:"subnet"`
+	SubnetAuth avax.JSONInput `json:"subnetAuthorization"`
+}
+
+// JSONCreateChainTx is the JSON representation of UnsignedCreateChainTx. The
+// genesis data is hex encoded.
+type JSONCreateChainTx struct {
+	*avax.JSONBaseTx
+	SubnetID    ids.ID         `json:"subnetID"`
+	ChainName   string         `json:"chainName"`
+	VMID        ids.ID         `json:"vmID"`
+	FxIDs       []ids.ID       `json:"fxIDs"`
+	GenesisData string         `json:"genesisData"`
+	SubnetAuth  avax.JSONInput `json:"subnetAuthorization"`
+}
+
+// JSONCreateSubnetTx is the JSON representation of UnsignedCreateSubnetTx
+type JSONCreateSubnetTx struct {
+	*avax.JSONBaseTx
+	Owner avax.JSONOutput `json:"owner"`
+}
+
+// JSONTransferSubnetOwnershipTx is the JSON representation of
+// UnsignedTransferSubnetOwnershipTx
+type JSONTransferSubnetOwnershipTx struct {
+	*avax.JSONBaseTx
+	SubnetID   ids.ID          `json:"subnetID"`
+	SubnetAuth avax.JSONInput  `json:"subnetAuthorization"`
+	Owner      avax.JSONOutput `json:"owner"`
+}
+
+// JSONRemoveSubnetValidatorTx is the JSON representation of
+// UnsignedRemoveSubnetValidatorTx
+type JSONRemoveSubnetValidatorTx struct {
+	*avax.JSONBaseTx
+	NodeID     string         `json:"nodeID"`
+	SubnetID   ids.ID         `json:"subnetID"`
+	Time       uint64         `json:"time"`
+	SubnetAuth avax.JSONInput `json:"subnetAuthorization"`
+}
+
+// JSONImportTx is the JSON representation of UnsignedImportTx
+type JSONImportTx struct {
+	*avax.JSONBaseTx
+	SourceChain    ids.ID                        `json:"sourceChain"`
+	ImportedInputs []*avax.JSONTransferableInput `json:"importedInputs"`
+}
+
+// JSONExportTx is the JSON representation of UnsignedExportTx
+type JSONExportTx struct {
+	*avax.JSONBaseTx
+	DestinationChain ids.ID                         `json:"destinationChain"`
+	ExportedOutputs  []*avax.JSONTransferableOutput `json:"exportedOutputs"`
+}
+
+// validatorJSON returns the JSON representation of [vdr]
+func validatorJSON(vdr *Validator) JSONValidator {
+	return JSONValidator{
+		NodeID: vdr.NodeID.PrefixedString(constants.NodeIDPrefix),
+		Start:  vdr.Start,
+		End:    vdr.End,
+		Weight: vdr.Wght,
+	}
+}
+
+// txJSON returns the JSON representation of [tx], with the addresses formatted
+// as addresses of this chain
+func (vm *VM) txJSON(tx *Tx) (*JSONTx, error) {
+	typeName, utxJSON, err := vm.unsignedTxJSON(tx.UnsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	creds := make([]avax.JSONCredential, len(tx.Creds))
+	for i, cred := range tx.Creds {
+		creds[i], err = avax.CredentialJSON(cred, vm.FormatLocalAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &JSONTx{
+		ID:          tx.ID(),
+		Type:        typeName,
+		UnsignedTx:  utxJSON,
+		Credentials: creds,
+	}, nil
+}
+
+// unsignedTxJSON returns the name of the type of [utx] and its JSON
+// representation
+func (vm *VM) unsignedTxJSON(utx UnsignedTx) (string, interface{}, error) {
+	formatAddr := vm.FormatLocalAddress
+	switch utx := utx.(type) {
+	case *UnsignedAddValidatorTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		stake, err := avax.OutputsJSON(utx.Stake, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		rewardsOwner, err := avax.OutputJSON(utx.RewardsOwner, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "platformvm.UnsignedAddValidatorTx", &JSONAddValidatorTx{
+			JSONBaseTx:   baseTx,
+			Validator:    validatorJSON(&utx.Validator),
+			Stake:        stake,
+			RewardsOwner: rewardsOwner,
+			Shares:       utx.Shares,
+		}, nil
+	case *UnsignedAddDelegatorTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		stake, err := avax.OutputsJSON(utx.Stake, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		rewardsOwner, err := avax.OutputJSON(utx.RewardsOwner, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "platformvm.UnsignedAddDelegatorTx", &JSONAddDelegatorTx{
+			JSONBaseTx:   baseTx,
+			Validator:    validatorJSON(&utx.Validator),
+			Stake:        stake,
+			RewardsOwner: rewardsOwner,
+		}, nil
+	case *UnsignedAddSubnetValidatorTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		subnetAuth, err := avax.InputJSON(utx.SubnetAuth, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "platformvm.UnsignedAddSubnetValidatorTx", &JSONAddSubnetValidatorTx{
+			JSONBaseTx: baseTx,
+			Validator:  validatorJSON(&utx.Validator.Validator),
+			Subnet:     utx.Validator.Subnet,
+			SubnetAuth: subnetAuth,
+		}, nil
+	case *UnsignedCreateChainTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		genesisData, err := formatting.Encode(formatting.Hex, utx.GenesisData)
+		if err != nil {
+			return "", nil, err
+		}
+		subnetAuth, err := avax.InputJSON(utx.SubnetAuth, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "platformvm.UnsignedCreateChainTx", &JSONCreateChainTx{
+			JSONBaseTx:  baseTx,
+			SubnetID:    utx.SubnetID,
+			ChainName:   utx.ChainName,
+			VMID:        utx.VMID,
+			FxIDs:       utx.FxIDs,
+			GenesisData: genesisData,
+			SubnetAuth:  subnetAuth,
+		}, nil
+	case *UnsignedCreateSubnetTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		owner, err := avax.OutputJSON(utx.Owner, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "platformvm.UnsignedCreateSubnetTx", &JSONCreateSubnetTx{
+			JSONBaseTx: baseTx,
+			Owner:      owner,
+		}, nil
+	case *UnsignedTransferSubnetOwnershipTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		subnetAuth, err := avax.InputJSON(utx.SubnetAuth, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		owner, err := avax.OutputJSON(utx.Owner, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "platformvm.UnsignedTransferSubnetOwnershipTx", &JSONTransferSubnetOwnershipTx{
+			JSONBaseTx: baseTx,
+			SubnetID:   utx.Subnet,
+			SubnetAuth: subnetAuth,
+			Owner:      owner,
+		}, nil
+	case *UnsignedRemoveSubnetValidatorTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		subnetAuth, err := avax.InputJSON(utx.SubnetAuth, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "platformvm.UnsignedRemoveSubnetValidatorTx", &JSONRemoveSubnetValidatorTx{
+			JSONBaseTx: baseTx,
+			NodeID:     utx.NodeID.PrefixedString(constants.NodeIDPrefix),
+			SubnetID:   utx.Subnet,
+			Time:       utx.Time,
+			SubnetAuth: subnetAuth,
+		}, nil
+	case *UnsignedImportTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		ins, err := avax.InputsJSON(utx.ImportedInputs, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "platformvm.UnsignedImportTx", &JSONImportTx{
+			JSONBaseTx:     baseTx,
+			SourceChain:    utx.SourceChain,
+			ImportedInputs: ins,
+		}, nil
+	case *UnsignedExportTx:
+		baseTx, err := utx.BaseTx.BaseTx.JSON(formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		outs, err := avax.OutputsJSON(utx.ExportedOutputs, formatAddr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "platformvm.UnsignedExportTx", &JSONExportTx{
+			JSONBaseTx:       baseTx,
+			DestinationChain: utx.DestinationChain,
+			ExportedOutputs:  outs,
+		}, nil
+	case *UnsignedAdvanceTimeTx:
+		// These transactions don't contain any addresses or bytes
+		return "platformvm.UnsignedAdvanceTimeTx", utx, nil
+	case *UnsignedRewardValidatorTx:
+		return "platformvm.UnsignedRewardValidatorTx", utx, nil
+	default:
+		return "", nil, errUnknownTxType
+	}
+}
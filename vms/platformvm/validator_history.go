@@ -0,0 +1,305 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	validatorDiffsDBPrefix        = "validatorDiffs"
+	pendingValidatorDiffsDBPrefix = "pendingValidatorDiffs"
+)
+
+var (
+	// Key of the height of the first block whose validator diffs were stored.
+	// Nodes that ran a version without validator diffs don't have the diffs
+	// of the blocks they accepted before upgrading.
+	validatorDiffsStartKey = []byte("validatorDiffsStart")
+
+	errFutureHeight     = errors.New("height is above the last accepted height")
+	errHeightNotIndexed = errors.New("validator set isn't indexed at height")
+	errUnknownSubnet    = errors.New("subnet doesn't exist")
+)
+
+// validatorDiff is the change of the weight of a validator that accepting a
+// block caused
+type validatorDiff struct {
+	NodeID   ids.ShortID `serialize:"true"`
+	Weight   uint64      `serialize:"true"`
+	Decrease bool        `serialize:"true"`
+}
+
+// validatorDiffs are the changes of the weights of the validators of a subnet
+// that accepting a block caused
+type validatorDiffs struct {
+	Diffs []validatorDiff `serialize:"true"`
+}
+
+// validatorWeights returns the weight of each of the current validators of
+// [subnetID] in [db], keyed by node ID
+func (vm *VM) validatorWeights(db database.Database, subnetID ids.ID) (map[[20]byte]uint64, error) {
+	weights := make(map[[20]byte]uint64)
+
+	stopPrefix := []byte(fmt.Sprintf("%s%s", subnetID, stopDBPrefix))
+	stopDB := prefixdb.NewNested(stopPrefix, db)
+	defer stopDB.Close()
+	stopIter := stopDB.NewIterator()
+	defer stopIter.Release()
+
+	for stopIter.Next() { // Iterates in order of increasing stop time
+		txBytes := stopIter.Value()
+
+		tx := rewardTx{}
+		if _, err := vm.codec.Unmarshal(txBytes, &tx); err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal validator tx: %w", err)
+		}
+		if err := tx.Tx.Sign(vm.codec, nil); err != nil {
+			return nil, err
+		}
+
+		var vdr Validator
+		switch staker := tx.Tx.UnsignedTx.(type) {
+		case *UnsignedAddDelegatorTx:
+			vdr = staker.Validator
+		case *UnsignedAddValidatorTx:
+			vdr = staker.Validator
+		case *UnsignedAddSubnetValidatorTx:
+			vdr = staker.Validator.Validator
+		default:
+			return nil, fmt.Errorf("expected validator but got %T", tx.Tx.UnsignedTx)
+		}
+		key := vdr.NodeID.Key()
+		weight, err := safemath.Add64(weights[key], vdr.Weight())
+		if err != nil {
+			return nil, err
+		}
+		weights[key] = weight
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		stopIter.Error(),
+		stopDB.Close(),
+	)
+	return weights, errs.Err
+}
+
+// getPendingValidatorDiffs returns the changes to the validators of
+// [subnetID] that the stakers added to and removed from [db] since the last
+// block was accepted
+func (vm *VM) getPendingValidatorDiffs(db database.Database, subnetID ids.ID) (*validatorDiffs, error) {
+	pendingDB := prefixdb.NewNested([]byte(pendingValidatorDiffsDBPrefix), db)
+	defer pendingDB.Close()
+
+	diffs := &validatorDiffs{}
+	diffBytes, err := pendingDB.Get(subnetID[:])
+	if err == database.ErrNotFound {
+		return diffs, pendingDB.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := vm.codec.Unmarshal(diffBytes, diffs); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal validator diffs: %w", err)
+	}
+	return diffs, pendingDB.Close()
+}
+
+// addPendingValidatorDiff records in [db] that a staker of [subnetID] was
+// added or removed. The diff is stored at a height once the block that made
+// it is accepted.
+func (vm *VM) addPendingValidatorDiff(db database.Database, subnetID ids.ID, diff validatorDiff) error {
+	diffs, err := vm.getPendingValidatorDiffs(db, subnetID)
+	if err != nil {
+		return err
+	}
+	diffs.Diffs = append(diffs.Diffs, diff)
+
+	diffBytes, err := vm.codec.Marshal(codecVersion, diffs)
+	if err != nil {
+		return fmt.Errorf("couldn't serialize validator diffs: %w", err)
+	}
+	pendingDB := prefixdb.NewNested([]byte(pendingValidatorDiffsDBPrefix), db)
+	errs := wrappers.Errs{}
+	errs.Add(
+		pendingDB.Put(subnetID[:], diffBytes),
+		pendingDB.Close(),
+	)
+	return errs.Err
+}
+
+// heightKey returns the database key of [height]. Keys sort by height.
+func heightKey(height uint64) []byte {
+	p := wrappers.Packer{MaxSize: wrappers.LongLen}
+	p.PackLong(height)
+	return p.Bytes
+}
+
+// putValidatorDiffs stores in [db] the changes to the validator sets that the
+// block at [height] made, which are the diffs pending in [db]
+func (vm *VM) putValidatorDiffs(db database.Database, height uint64) error {
+	pendingDB := prefixdb.NewNested([]byte(pendingValidatorDiffsDBPrefix), db)
+	defer pendingDB.Close()
+	iter := pendingDB.NewIterator()
+	defer iter.Release()
+
+	pending := make(map[ids.ID][]byte)
+	for iter.Next() {
+		subnetID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return err
+		}
+		pending[subnetID] = append([]byte(nil), iter.Value()...)
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	for subnetID, diffBytes := range pending {
+		diffDB := prefixdb.NewNested([]byte(fmt.Sprintf("%s%s", subnetID, validatorDiffsDBPrefix)), db)
+		errs := wrappers.Errs{}
+		errs.Add(
+			diffDB.Put(heightKey(height), diffBytes),
+			diffDB.Close(),
+			pendingDB.Delete(subnetID[:]),
+		)
+		if errs.Errored() {
+			return errs.Err
+		}
+	}
+	return pendingDB.Close()
+}
+
+// initValidatorDiffs marks the validator diffs as stored from the block after
+// the last accepted block, unless they are already being stored
+func (vm *VM) initValidatorDiffs() error {
+	if has, err := vm.DB.Has(validatorDiffsStartKey); err != nil || has {
+		return err
+	}
+	lastAccepted, err := vm.getBlock(vm.LastAccepted())
+	if err != nil {
+		return fmt.Errorf("couldn't get last accepted block: %w", err)
+	}
+	// The validator set at the last accepted height is the current validator
+	// set, so it's known even though it has no diffs
+	if err := vm.DB.Put(validatorDiffsStartKey, heightKey(lastAccepted.Height())); err != nil {
+		return err
+	}
+	return vm.DB.Commit()
+}
+
+// validatorDiffsStart returns the lowest height that the validator sets are
+// known at
+func (vm *VM) validatorDiffsStart() (uint64, error) {
+	startBytes, err := vm.DB.Get(validatorDiffsStartKey)
+	if err != nil {
+		return 0, err
+	}
+	p := wrappers.Packer{Bytes: startBytes}
+	start := p.UnpackLong()
+	return start, p.Err
+}
+
+// GetCurrentHeight returns the height of the last accepted block
+func (vm *VM) GetCurrentHeight() (uint64, error) {
+	lastAccepted, err := vm.getBlock(vm.LastAccepted())
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get last accepted block: %w", err)
+	}
+	return lastAccepted.Height(), nil
+}
+
+// GetValidatorSet returns the validators of [subnetID] once the block at
+// [height] was accepted. The validator set is found by undoing the changes of
+// the blocks accepted after [height] to the current validator set.
+func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (validators.Set, error) {
+	currentHeight, err := vm.GetCurrentHeight()
+	if err != nil {
+		return nil, err
+	}
+	if height > currentHeight {
+		return nil, fmt.Errorf("%w: %d > %d", errFutureHeight, height, currentHeight)
+	}
+	start, err := vm.validatorDiffsStart()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the first indexed height: %w", err)
+	}
+	if height < start {
+		return nil, fmt.Errorf("%w %d. The first indexed height is %d", errHeightNotIndexed, height, start)
+	}
+	if subnetID != constants.PrimaryNetworkID {
+		if _, err := vm.getSubnet(vm.DB, subnetID); err != nil {
+			return nil, fmt.Errorf("%w: %s", errUnknownSubnet, subnetID)
+		}
+	}
+
+	weights, err := vm.validatorWeights(vm.DB, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The weight that was added and removed after [height], by node ID
+	added := make(map[[20]byte]uint64)
+	removed := make(map[[20]byte]uint64)
+
+	diffDB := prefixdb.NewNested([]byte(fmt.Sprintf("%s%s", subnetID, validatorDiffsDBPrefix)), vm.DB)
+	defer diffDB.Close()
+	iter := diffDB.NewIteratorWithStart(heightKey(height + 1))
+	defer iter.Release()
+
+	for iter.Next() {
+		diffs := validatorDiffs{}
+		if _, err := vm.codec.Unmarshal(iter.Value(), &diffs); err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal validator diffs: %w", err)
+		}
+		for _, diff := range diffs.Diffs {
+			key := diff.NodeID.Key()
+			changes := added
+			if diff.Decrease {
+				changes = removed
+			}
+			change, err := safemath.Add64(changes[key], diff.Weight)
+			if err != nil {
+				return nil, err
+			}
+			changes[key] = change
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	for key, weight := range removed {
+		newWeight, err := safemath.Add64(weights[key], weight)
+		if err != nil {
+			return nil, err
+		}
+		weights[key] = newWeight
+	}
+	vdrs := validators.NewSet()
+	for key, weight := range weights {
+		newWeight, err := safemath.Sub64(weight, added[key])
+		if err != nil {
+			return nil, err
+		}
+		if newWeight == 0 {
+			continue
+		}
+		if err := vdrs.AddWeight(ids.NewShortID(key), newWeight); err != nil {
+			return nil, err
+		}
+	}
+	return vdrs, diffDB.Close()
+}
@@ -0,0 +1,154 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestPutValidatorDiffs(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	toRemove, err := vm.nextStakerStop(vm.DB, constants.PrimaryNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	removedVdr := toRemove.Tx.UnsignedTx.(*UnsignedAddValidatorTx).Validator
+
+	db := versiondb.New(vm.DB)
+	if err := vm.removeStaker(db, constants.PrimaryNetworkID, toRemove); err != nil {
+		t.Fatal(err)
+	}
+
+	// The pending diffs are only the changes made to [db]
+	pending, err := vm.getPendingValidatorDiffs(db, constants.PrimaryNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []validatorDiff{{
+		NodeID:   removedVdr.NodeID,
+		Weight:   removedVdr.Weight(),
+		Decrease: true,
+	}}
+	assert.Equal(t, expected, pending.Diffs)
+	if pending, err := vm.getPendingValidatorDiffs(vm.DB, constants.PrimaryNetworkID); err != nil {
+		t.Fatal(err)
+	} else if len(pending.Diffs) != 0 {
+		t.Fatalf("shouldn't have pending diffs before the change is committed")
+	}
+
+	// Storing the diffs at a height clears the pending diffs
+	if err := vm.putValidatorDiffs(db, 1); err != nil {
+		t.Fatal(err)
+	}
+	if pending, err := vm.getPendingValidatorDiffs(db, constants.PrimaryNetworkID); err != nil {
+		t.Fatal(err)
+	} else if len(pending.Diffs) != 0 {
+		t.Fatalf("should have cleared the pending diffs")
+	}
+
+	diffDB := prefixdb.NewNested([]byte(fmt.Sprintf("%s%s", constants.PrimaryNetworkID, validatorDiffsDBPrefix)), db)
+	diffBytes, err := diffDB.Get(heightKey(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	diffs := validatorDiffs{}
+	if _, err := vm.codec.Unmarshal(diffBytes, &diffs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, expected, diffs.Diffs)
+}
+
+func TestGetValidatorSet(t *testing.T) {
+	vm, _ := defaultVM(t)
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	// Fast forward clock to time for genesis validators to leave
+	vm.clock.Set(defaultValidateEndTime)
+
+	// Accept the proposals to advance time and to reward a genesis validator
+	for i := 0; i < 2; i++ {
+		blk, err := vm.BuildBlock()
+		if err != nil {
+			t.Fatal(err)
+		} else if err := blk.Verify(); err != nil {
+			t.Fatal(err)
+		}
+		block := blk.(*ProposalBlock)
+		options, err := block.Options()
+		if err != nil {
+			t.Fatal(err)
+		}
+		commit := options[0].(*Commit)
+		if err := block.Accept(); err != nil {
+			t.Fatal(err)
+		} else if err := vm.SaveBlock(block); err != nil { // Normally done by the engine
+			t.Fatal(err)
+		} else if err := commit.Verify(); err != nil {
+			t.Fatal(err)
+		} else if err := commit.Accept(); err != nil {
+			t.Fatal(err)
+		} else if err := vm.SaveBlock(commit); err != nil { // Normally done by the engine
+			t.Fatal(err)
+		}
+	}
+
+	height, err := vm.GetCurrentHeight()
+	if err != nil {
+		t.Fatal(err)
+	} else if height != 4 {
+		t.Fatalf("expected height to be 4 but is %d", height)
+	}
+
+	genesisVdrs, err := vm.GetValidatorSet(0, constants.PrimaryNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	} else if genesisVdrs.Len() != len(keys) {
+		t.Fatalf("expected %d genesis validators but got %d", len(keys), genesisVdrs.Len())
+	}
+	currentVdrs, err := vm.GetValidatorSet(height, constants.PrimaryNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	} else if currentVdrs.Len() != len(keys)-1 {
+		t.Fatalf("expected %d validators but got %d", len(keys)-1, currentVdrs.Len())
+	}
+	for _, vdr := range genesisVdrs.List() {
+		if currentVdrs.Contains(vdr.ID()) {
+			continue
+		}
+		if _, isValidator, err := vm.isValidator(vm.DB, constants.PrimaryNetworkID, vdr.ID()); err != nil {
+			t.Fatal(err)
+		} else if isValidator {
+			t.Fatalf("%s is a current validator but isn't in the current validator set", vdr.ID())
+		}
+	}
+
+	if _, err := vm.GetValidatorSet(height+1, constants.PrimaryNetworkID); err == nil {
+		t.Fatal("should have failed to get the validator set at a future height")
+	}
+	if _, err := vm.GetValidatorSet(0, ids.GenerateTestID()); err == nil {
+		t.Fatal("should have failed to get the validator set of an unknown subnet")
+	}
+}
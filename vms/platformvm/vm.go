@@ -33,6 +33,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/core"
 	"github.com/ava-labs/avalanchego/vms/components/state"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
@@ -89,6 +90,7 @@ var (
 
 	_ block.ChainVM        = &VM{}
 	_ validators.Connector = &VM{}
+	_ common.AppVM         = &VM{}
 )
 
 // VM implements the snowman.ChainVM interface
@@ -111,6 +113,9 @@ type VM struct {
 
 	mempool Mempool
 
+	// Used to gossip txs issued to this node to the validators
+	appSender common.AppSender
+
 	// Used to create and use keys.
 	factory crypto.FactorySECP256K1R
 
@@ -294,6 +299,11 @@ func (vm *VM) Initialize(
 		if err := genesisBlock.CommonBlock.Accept(); err != nil {
 			return fmt.Errorf("error accepting genesis block: %w", err)
 		}
+		// The genesis stakers are the changes to the validator sets that the
+		// genesis block made
+		if err := vm.putValidatorDiffs(vm.DB, genesisBlock.Height()); err != nil {
+			return fmt.Errorf("couldn't store genesis validator diffs: %w", err)
+		}
 
 		if err := vm.SetDBInitialized(); err != nil {
 			return fmt.Errorf("error while setting db to initialized: %w", err)
@@ -334,6 +344,19 @@ func (vm *VM) Initialize(
 		return errInvalidLastAcceptedBlock
 	}
 
+	if err := vm.initValidatorDiffs(); err != nil {
+		return fmt.Errorf("couldn't initialize validator diffs: %w", err)
+	}
+
+	if err := vm.initBlockIndex(); err != nil {
+		return fmt.Errorf("couldn't index accepted blocks by height: %w", err)
+	}
+
+	// Re-issue the txs that were in the mempool when the node last ran
+	if err := vm.mempool.loadTxs(); err != nil {
+		return fmt.Errorf("couldn't load mempool txs: %w", err)
+	}
+
 	return nil
 }
 
@@ -770,6 +793,24 @@ func (vm *VM) CreateStaticHandlers() map[string]*common.HTTPHandler {
 	}
 }
 
+// SetAppSender implements the common.AppVM interface
+func (vm *VM) SetAppSender(sender common.AppSender) { vm.appSender = sender }
+
+// AppGossip implements the common.AppVM interface. [msg] is a tx gossiped by
+// [nodeID], which is added to the mempool if it's valid. Invalid txs are
+// dropped rather than reported, as returning an error is fatal to the chain.
+func (vm *VM) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	tx := &Tx{}
+	if _, err := vm.codec.Unmarshal(msg, tx); err != nil {
+		vm.Ctx.Log.Debug("dropping unparsable tx gossiped by %s: %s", nodeID, err)
+		return nil
+	}
+	if err := vm.mempool.AddGossipedTx(nodeID, tx); err != nil {
+		vm.Ctx.Log.Debug("dropping tx %s gossiped by %s: %s", tx.ID(), nodeID, err)
+	}
+	return nil
+}
+
 // Connected implements validators.Connector
 func (vm *VM) Connected(vdrID ids.ShortID) {
 	vm.connections[vdrID.Key()] = time.Unix(vm.clock.Time().Unix(), 0)
@@ -1011,6 +1052,34 @@ pendingStakerLoop:
 			if err := vm.addStaker(db, subnetID, &rTx); err != nil {
 				return fmt.Errorf("couldn't add staker: %w", err)
 			}
+		case *UnsignedRemoveSubnetValidatorTx:
+			if subnetID != staker.Subnet {
+				return fmt.Errorf("RemoveSubnetValidatorTx references the incorrect subnet. Expected %s; Got %s",
+					subnetID, staker.Subnet)
+			}
+			if staker.StartTime().After(timestamp) {
+				break pendingStakerLoop
+			}
+
+			if err := tx.Sign(vm.codec, nil); err != nil {
+				return err
+			}
+
+			if err := vm.dequeueStaker(db, subnetID, &tx); err != nil {
+				return fmt.Errorf("couldn't dequeue staker: %w", err)
+			}
+
+			vdrTx, isValidator, err := vm.currentSubnetValidator(db, subnetID, staker.NodeID)
+			if err != nil {
+				return fmt.Errorf("couldn't get subnet validator: %w", err)
+			}
+			if !isValidator {
+				// The validator already left the subnet
+				continue
+			}
+			if err := vm.removeStaker(db, subnetID, vdrTx); err != nil {
+				return fmt.Errorf("couldn't remove staker: %w", err)
+			}
 		default:
 			return fmt.Errorf("expected validator but got %T", tx.UnsignedTx)
 		}
@@ -1102,48 +1171,34 @@ func (vm *VM) updateVdrMgr(force bool) error {
 }
 
 func (vm *VM) updateVdrSet(subnetID ids.ID) error {
-	vdrs := validators.NewSet()
-
-	stopPrefix := []byte(fmt.Sprintf("%s%s", subnetID, stopDBPrefix))
-	stopDB := prefixdb.NewNested(stopPrefix, vm.DB)
-	defer stopDB.Close()
-	stopIter := stopDB.NewIterator()
-	defer stopIter.Release()
-
-	for stopIter.Next() { // Iterates in order of increasing start time
-		txBytes := stopIter.Value()
-
-		tx := rewardTx{}
-		if _, err := vm.codec.Unmarshal(txBytes, &tx); err != nil {
-			return fmt.Errorf("couldn't unmarshal validator tx: %w", err)
-		}
-		if err := tx.Tx.Sign(vm.codec, nil); err != nil {
-			return err
-		}
+	weights, err := vm.validatorWeights(vm.DB, subnetID)
+	if err != nil {
+		return err
+	}
 
-		var err error
-		switch staker := tx.Tx.UnsignedTx.(type) {
-		case *UnsignedAddDelegatorTx:
-			err = vdrs.AddWeight(staker.Validator.NodeID, staker.Validator.Weight())
-		case *UnsignedAddValidatorTx:
-			err = vdrs.AddWeight(staker.Validator.NodeID, staker.Validator.Weight())
-		case *UnsignedAddSubnetValidatorTx:
-			err = vdrs.AddWeight(staker.Validator.NodeID, staker.Validator.Weight())
-		default:
-			err = fmt.Errorf("expected validator but got %T", tx.Tx.UnsignedTx)
-		}
-		if err != nil {
+	vdrs := validators.NewSet()
+	for nodeID, weight := range weights {
+		if err := vdrs.AddWeight(ids.NewShortID(nodeID), weight); err != nil {
 			return err
 		}
 	}
+	return vm.vdrMgr.Set(subnetID, vdrs)
+}
 
-	errs := wrappers.Errs{}
-	errs.Add(
-		vm.vdrMgr.Set(subnetID, vdrs),
-		stopIter.Error(),
-		stopDB.Close(),
-	)
-	return errs.Err
+// txBuilder returns a builder for the transactions of this chain. It's created
+// on every call so that it reflects the current fees.
+func (vm *VM) txBuilder() *Builder {
+	return &Builder{
+		Builder: wallet.Builder{
+			AVAXAssetID: vm.Ctx.AVAXAssetID,
+			Codec:       vm.codec,
+			Clock:       &vm.clock,
+		},
+		NetworkID:     vm.Ctx.NetworkID,
+		ChainID:       vm.Ctx.ChainID,
+		TxFee:         vm.txFee,
+		CreationTxFee: vm.creationTxFee,
+	}
 }
 
 // Codec ...
@@ -1300,6 +1355,10 @@ func (vm *VM) calculateUptime(db database.Database, nodeID ids.ShortID, startTim
 		}
 	}
 	bestPossibleUpDuration := uint64(currentLocalTime.Sub(startTime) / time.Second)
+	if bestPossibleUpDuration == 0 || upDuration >= bestPossibleUpDuration {
+		// The validator hasn't been down since it started validating
+		return 1, nil
+	}
 	return float64(upDuration) / float64(bestPossibleUpDuration), nil
 }
 
@@ -1503,6 +1562,8 @@ func (vm *VM) maxStakeAmount(db database.Database, subnetID ids.ID, nodeID ids.S
 			validator = &staker.Validator
 		case *UnsignedAddSubnetValidatorTx:
 			validator = &staker.Validator.Validator
+		case *UnsignedRemoveSubnetValidatorTx:
+			continue
 		default:
 			return 0, fmt.Errorf("expected validator but got %T", tx.UnsignedTx)
 		}
@@ -0,0 +1,35 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package propertyfx
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// JSONMintOperation is the JSON representation of MintOperation
+type JSONMintOperation struct {
+	MintInput   secp256k1fx.Input            `json:"mintInput"`
+	MintOutput  secp256k1fx.JSONOutputOwners `json:"mintOutput"`
+	OwnedOutput secp256k1fx.JSONOutputOwners `json:"ownedOutput"`
+}
+
+// JSON returns the JSON representation of [op], with the addresses formatted
+// by [formatAddr]. MintOutput and OwnedOutput are represented by the JSON
+// representation of their owners.
+func (op *MintOperation) JSON(formatAddr func(ids.ShortID) (string, error)) (interface{}, error) {
+	mintOwners, err := op.MintOutput.FormatOwners(formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	ownedOwners, err := op.OwnedOutput.FormatOwners(formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONMintOperation{
+		MintInput:   op.MintInput,
+		MintOutput:  mintOwners,
+		OwnedOutput: ownedOwners,
+	}, nil
+}
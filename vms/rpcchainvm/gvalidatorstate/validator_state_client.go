@@ -0,0 +1,59 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gvalidatorstate
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gvalidatorstate/gvalidatorstateproto"
+)
+
+var (
+	_ validators.State = &Client{}
+)
+
+// Client is an implementation of a validator state that talks over RPC.
+type Client struct {
+	client gvalidatorstateproto.ValidatorStateClient
+}
+
+// NewClient returns a validator state instance connected to a remote
+// validator state instance
+func NewClient(client gvalidatorstateproto.ValidatorStateClient) *Client {
+	return &Client{client: client}
+}
+
+// GetCurrentHeight ...
+func (c *Client) GetCurrentHeight() (uint64, error) {
+	resp, err := c.client.GetCurrentHeight(context.Background(), &gvalidatorstateproto.GetCurrentHeightRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Height, nil
+}
+
+// GetValidatorSet ...
+func (c *Client) GetValidatorSet(height uint64, subnetID ids.ID) (validators.Set, error) {
+	resp, err := c.client.GetValidatorSet(context.Background(), &gvalidatorstateproto.GetValidatorSetRequest{
+		Height:   height,
+		SubnetID: subnetID[:],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vdrs := validators.NewSet()
+	for _, vdr := range resp.Validators {
+		nodeID, err := ids.ToShortID(vdr.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if err := vdrs.AddWeight(nodeID, vdr.Weight); err != nil {
+			return nil, err
+		}
+	}
+	return vdrs, nil
+}
@@ -0,0 +1,61 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gvalidatorstate
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gvalidatorstate/gvalidatorstateproto"
+)
+
+// Server is a validator state that is managed over RPC.
+type Server struct {
+	state validators.State
+}
+
+// NewServer returns a validator state instance connected to a remote
+// validator state instance
+func NewServer(state validators.State) *Server {
+	return &Server{state: state}
+}
+
+// GetCurrentHeight ...
+func (s *Server) GetCurrentHeight(
+	_ context.Context,
+	_ *gvalidatorstateproto.GetCurrentHeightRequest,
+) (*gvalidatorstateproto.GetCurrentHeightResponse, error) {
+	height, err := s.state.GetCurrentHeight()
+	return &gvalidatorstateproto.GetCurrentHeightResponse{
+		Height: height,
+	}, err
+}
+
+// GetValidatorSet ...
+func (s *Server) GetValidatorSet(
+	_ context.Context,
+	req *gvalidatorstateproto.GetValidatorSetRequest,
+) (*gvalidatorstateproto.GetValidatorSetResponse, error) {
+	subnetID, err := ids.ToID(req.SubnetID)
+	if err != nil {
+		return nil, err
+	}
+	vdrs, err := s.state.GetValidatorSet(req.Height, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	vdrList := vdrs.List()
+	resp := &gvalidatorstateproto.GetValidatorSetResponse{
+		Validators: make([]*gvalidatorstateproto.Validator, len(vdrList)),
+	}
+	for i, vdr := range vdrList {
+		resp.Validators[i] = &gvalidatorstateproto.Validator{
+			NodeID: vdr.ID().Bytes(),
+			Weight: vdr.Weight(),
+		}
+	}
+	return resp, nil
+}
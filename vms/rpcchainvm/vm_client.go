@@ -31,6 +31,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gsharedmemory/gsharedmemoryproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gsubnetlookup"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gsubnetlookup/gsubnetlookupproto"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gvalidatorstate"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gvalidatorstate/gvalidatorstateproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/messenger"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/messenger/messengerproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/vmproto"
@@ -52,6 +54,7 @@ type VMClient struct {
 	sharedMemory *gsharedmemory.Server
 	bcLookup     *galiaslookup.Server
 	snLookup     *gsubnetlookup.Server
+	vdrState     *gvalidatorstate.Server
 
 	serverCloser grpcutils.ServerCloser
 	conns        []*grpc.ClientConn
@@ -121,20 +124,31 @@ func (vm *VMClient) Initialize(
 	snLookupBrokerID := vm.broker.NextId()
 	go vm.broker.AcceptAndServe(snLookupBrokerID, vm.startSNLookupServer)
 
+	// start the validator state server, if the validator sets can be looked
+	// up. 0 tells the plugin that they can't be.
+	vdrStateBrokerID := uint32(0)
+	if ctx.ValidatorState != nil {
+		vm.vdrState = gvalidatorstate.NewServer(ctx.ValidatorState)
+		vdrStateBrokerID = vm.broker.NextId()
+		go vm.broker.AcceptAndServe(vdrStateBrokerID, vm.startValidatorStateServer)
+	}
+
 	resp, err := vm.client.Initialize(context.Background(), &vmproto.InitializeRequest{
-		NetworkID:          ctx.NetworkID,
-		SubnetID:           ctx.SubnetID[:],
-		ChainID:            ctx.ChainID[:],
-		NodeID:             ctx.NodeID.Bytes(),
-		XChainID:           ctx.XChainID[:],
-		AvaxAssetID:        ctx.AVAXAssetID[:],
-		GenesisBytes:       genesisBytes,
-		DbServer:           dbBrokerID,
-		EngineServer:       messengerBrokerID,
-		KeystoreServer:     keystoreBrokerID,
-		SharedMemoryServer: sharedMemoryBrokerID,
-		BcLookupServer:     bcLookupBrokerID,
-		SnLookupServer:     snLookupBrokerID,
+		NetworkID:            ctx.NetworkID,
+		SubnetID:             ctx.SubnetID[:],
+		ChainID:              ctx.ChainID[:],
+		NodeID:               ctx.NodeID.Bytes(),
+		XChainID:             ctx.XChainID[:],
+		AvaxAssetID:          ctx.AVAXAssetID[:],
+		GenesisBytes:         genesisBytes,
+		DbServer:             dbBrokerID,
+		EngineServer:         messengerBrokerID,
+		KeystoreServer:       keystoreBrokerID,
+		SharedMemoryServer:   sharedMemoryBrokerID,
+		BcLookupServer:       bcLookupBrokerID,
+		SnLookupServer:       snLookupBrokerID,
+		ConfigBytes:          ctx.ChainConfig,
+		ValidatorStateServer: vdrStateBrokerID,
 	})
 	if err != nil {
 		return err
@@ -191,6 +205,13 @@ func (vm *VMClient) startSNLookupServer(opts []grpc.ServerOption) *grpc.Server {
 	return server
 }
 
+func (vm *VMClient) startValidatorStateServer(opts []grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	vm.serverCloser.Add(server)
+	gvalidatorstateproto.RegisterValidatorStateServer(server, vm.vdrState)
+	return server
+}
+
 // Bootstrapping ...
 func (vm *VMClient) Bootstrapping() error {
 	_, err := vm.client.Bootstrapping(context.Background(), &vmproto.BootstrappingRequest{})
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/galiaslookup"
@@ -30,6 +31,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gsharedmemory/gsharedmemoryproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gsubnetlookup"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gsubnetlookup/gsubnetlookupproto"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gvalidatorstate"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gvalidatorstate/gvalidatorstateproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/messenger"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/messenger/messengerproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/vmproto"
@@ -130,6 +133,26 @@ func (vm *VMServer) Initialize(_ context.Context, req *vmproto.InitializeRequest
 	bcLookupClient := galiaslookup.NewClient(galiaslookupproto.NewAliasLookupClient(bcLookupConn))
 	snLookupClient := gsubnetlookup.NewClient(gsubnetlookupproto.NewSubnetLookupClient(snLookupConn))
 
+	// The validator sets can only be looked up if the node serves them
+	var (
+		vdrStateConn *grpc.ClientConn
+		vdrState     validators.State
+	)
+	if req.ValidatorStateServer != 0 {
+		vdrStateConn, err = vm.broker.Dial(req.ValidatorStateServer)
+		if err != nil {
+			// Ignore closing error to return the original error
+			_ = dbConn.Close()
+			_ = msgConn.Close()
+			_ = keystoreConn.Close()
+			_ = sharedMemoryConn.Close()
+			_ = bcLookupConn.Close()
+			_ = snLookupConn.Close()
+			return nil, err
+		}
+		vdrState = gvalidatorstate.NewClient(gvalidatorstateproto.NewValidatorStateClient(vdrStateConn))
+	}
+
 	toEngine := make(chan common.Message, 1)
 	go func() {
 		for msg := range toEngine {
@@ -152,6 +175,8 @@ func (vm *VMServer) Initialize(_ context.Context, req *vmproto.InitializeRequest
 		SharedMemory:        sharedMemoryClient,
 		BCLookup:            bcLookupClient,
 		SNLookup:            snLookupClient,
+		ValidatorState:      vdrState,
+		ChainConfig:         req.ConfigBytes,
 	}
 
 	if err := vm.vm.Initialize(vm.ctx, dbClient, req.GenesisBytes, toEngine, nil); err != nil {
@@ -162,6 +187,9 @@ func (vm *VMServer) Initialize(_ context.Context, req *vmproto.InitializeRequest
 		_ = sharedMemoryConn.Close()
 		_ = bcLookupConn.Close()
 		_ = snLookupConn.Close()
+		if vdrStateConn != nil {
+			_ = vdrStateConn.Close()
+		}
 		close(toEngine)
 		return nil, err
 	}
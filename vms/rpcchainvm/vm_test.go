@@ -0,0 +1,55 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func TestInitializePassesChainConfig(t *testing.T) {
+	// The context that the VM in the plugin was initialized with
+	var pluginCtx *snow.Context
+	vm := &block.TestVM{}
+	vm.T = t
+	vm.InitializeF = func(ctx *snow.Context, _ database.Database, _ []byte, _ chan<- common.Message, _ []*common.Fx) error {
+		pluginCtx = ctx
+		return nil
+	}
+	vm.LastAcceptedF = func() ids.ID { return ids.Empty }
+
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"vm": New(vm),
+	})
+	defer server.Stop()
+	defer client.Close()
+
+	vmIntf, err := client.Dispense("vm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vmClient := vmIntf.(*VMClient)
+
+	ctx := snow.DefaultContextTest()
+	ctx.ChainConfig = []byte(`{"pruning-enabled":false}`)
+	if err := vmClient.Initialize(ctx, memdb.New(), nil, make(chan common.Message, 1), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if pluginCtx == nil {
+		t.Fatal("should have initialized the VM in the plugin")
+	}
+	if !bytes.Equal(ctx.ChainConfig, pluginCtx.ChainConfig) {
+		t.Fatalf("expected the chain config %q but the plugin got %q", ctx.ChainConfig, pluginCtx.ChainConfig)
+	}
+}
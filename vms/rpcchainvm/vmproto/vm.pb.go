@@ -39,6 +39,8 @@ type InitializeRequest struct {
 	SharedMemoryServer   uint32   `protobuf:"varint,11,opt,name=sharedMemoryServer,proto3" json:"sharedMemoryServer,omitempty"`
 	BcLookupServer       uint32   `protobuf:"varint,12,opt,name=bcLookupServer,proto3" json:"bcLookupServer,omitempty"`
 	SnLookupServer       uint32   `protobuf:"varint,13,opt,name=snLookupServer,proto3" json:"snLookupServer,omitempty"`
+	ConfigBytes          []byte   `protobuf:"bytes,14,opt,name=configBytes,proto3" json:"configBytes,omitempty"`
+	ValidatorStateServer uint32   `protobuf:"varint,15,opt,name=validatorStateServer,proto3" json:"validatorStateServer,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -160,6 +162,20 @@ func (m *InitializeRequest) GetSnLookupServer() uint32 {
 	return 0
 }
 
+func (m *InitializeRequest) GetConfigBytes() []byte {
+	if m != nil {
+		return m.ConfigBytes
+	}
+	return nil
+}
+
+func (m *InitializeRequest) GetValidatorStateServer() uint32 {
+	if m != nil {
+		return m.ValidatorStateServer
+	}
+	return 0
+}
+
 type InitializeResponse struct {
 	LastAcceptedID       []byte   `protobuf:"bytes,1,opt,name=lastAcceptedID,proto3" json:"lastAcceptedID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1169,59 +1185,60 @@ func init() {
 }
 
 var fileDescriptor_cab246c8c7c5372d = []byte{
-	// 827 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x51, 0x6f, 0xe2, 0x46,
-	0x10, 0x16, 0xd0, 0x0b, 0x30, 0x40, 0x0e, 0x36, 0x21, 0x71, 0xdd, 0x5c, 0x4b, 0xad, 0xea, 0x44,
-	0xa5, 0x8a, 0x87, 0xeb, 0x6b, 0xa5, 0xd3, 0x71, 0xa4, 0x0d, 0x6a, 0x93, 0xa6, 0x46, 0x8a, 0x2a,
-	0xb5, 0x2f, 0x06, 0x4f, 0x82, 0x0b, 0x59, 0xbb, 0xbb, 0x0b, 0x09, 0xfd, 0x6f, 0xfd, 0x01, 0xfd,
-	0x57, 0x95, 0xd7, 0x6b, 0x7b, 0xbd, 0x18, 0xa5, 0xea, 0x1b, 0x33, 0xdf, 0x37, 0x9f, 0x77, 0x77,
-	0xe6, 0x1b, 0xa0, 0xb1, 0x7d, 0x1c, 0x45, 0x2c, 0x14, 0x21, 0xa9, 0x6f, 0x1f, 0xe5, 0x0f, 0xe7,
-	0x9f, 0x1a, 0xf4, 0xa6, 0x34, 0x10, 0x81, 0xb7, 0x0e, 0xfe, 0x42, 0x17, 0xff, 0xdc, 0x20, 0x17,
-	0xe4, 0x02, 0x9a, 0x14, 0xc5, 0x53, 0xc8, 0x56, 0xd3, 0x89, 0x55, 0x19, 0x54, 0x86, 0x1d, 0x37,
-	0x4f, 0x10, 0x1b, 0x1a, 0x7c, 0x33, 0xa7, 0x28, 0xa6, 0x13, 0xab, 0x3a, 0xa8, 0x0c, 0xdb, 0x6e,
-	0x16, 0x13, 0x0b, 0xea, 0x8b, 0xa5, 0x17, 0xd0, 0xe9, 0xc4, 0xaa, 0x49, 0x28, 0x0d, 0xc9, 0x19,
-	0x1c, 0xd1, 0xd0, 0xc7, 0xe9, 0xc4, 0xfa, 0x44, 0x02, 0x2a, 0x8a, 0xd5, 0x9e, 0x3f, 0xaa, 0x92,
-	0x57, 0x89, 0x5a, 0x1a, 0x93, 0x01, 0xb4, 0xbc, 0xad, 0xf7, 0xfc, 0x81, 0x73, 0xf9, 0xb1, 0x23,
-	0x09, 0xeb, 0x29, 0xe2, 0x40, 0xfb, 0x01, 0x29, 0xf2, 0x80, 0x8f, 0x77, 0x02, 0xb9, 0x55, 0x97,
-	0x94, 0x42, 0x2e, 0xfe, 0x82, 0x3f, 0x9f, 0x21, 0xdb, 0x22, 0xb3, 0x1a, 0xf2, 0x32, 0x59, 0x1c,
-	0xd7, 0x23, 0x7d, 0x08, 0x28, 0x2a, 0xbc, 0x29, 0xf1, 0x42, 0x8e, 0xbc, 0x85, 0xe3, 0x15, 0xee,
-	0xb8, 0x08, 0x59, 0xca, 0x02, 0xc9, 0x32, 0xb2, 0x64, 0x04, 0x84, 0x2f, 0x3d, 0x86, 0xfe, 0x35,
-	0x3e, 0x86, 0x6c, 0xa7, 0xb8, 0x2d, 0xc9, 0x2d, 0x41, 0x62, 0xdd, 0xf9, 0xe2, 0xa7, 0x30, 0x5c,
-	0x6d, 0x22, 0xc5, 0x6d, 0x27, 0xba, 0xc5, 0x6c, 0xcc, 0xe3, 0xb4, 0xc0, 0xeb, 0x24, 0xbc, 0x62,
-	0xd6, 0xf9, 0x0e, 0x88, 0xde, 0x4a, 0x1e, 0x85, 0x94, 0x63, 0x5c, 0xbd, 0xf6, 0xb8, 0xf8, 0xb0,
-	0x58, 0x60, 0x24, 0xd0, 0x57, 0x0d, 0x6d, 0xbb, 0x46, 0xd6, 0x39, 0x83, 0xd3, 0x71, 0x18, 0x0a,
-	0x2e, 0x98, 0x17, 0x45, 0x01, 0x7d, 0x50, 0xb3, 0xe0, 0x9c, 0x43, 0xdf, 0xc8, 0x27, 0xc2, 0x4e,
-	0x1f, 0x4e, 0x72, 0x00, 0xfd, 0x94, 0x5f, 0xd0, 0x89, 0xd3, 0x8a, 0xde, 0x83, 0xd7, 0xb3, 0xe5,
-	0x46, 0xf8, 0xe1, 0x13, 0x4d, 0xa9, 0x04, 0xba, 0x79, 0x4a, 0xd1, 0xce, 0xa1, 0xff, 0x91, 0xa1,
-	0x27, 0xf0, 0xca, 0xa3, 0xfe, 0x1a, 0x19, 0x4f, 0xc9, 0xdf, 0xc3, 0x99, 0x09, 0xa8, 0x1b, 0x7e,
-	0x03, 0x8d, 0xa5, 0xca, 0x59, 0x95, 0x41, 0x6d, 0xd8, 0x7a, 0xd7, 0x1d, 0xa9, 0xf9, 0x1e, 0x29,
-	0xb2, 0x9b, 0x31, 0x9c, 0xdf, 0xa0, 0xae, 0x92, 0xf1, 0x48, 0x46, 0x0c, 0xef, 0x83, 0x67, 0xf9,
-	0x24, 0x4d, 0x57, 0x45, 0xf1, 0xd8, 0xad, 0xc3, 0xc5, 0xea, 0xe7, 0x48, 0x04, 0x21, 0xe5, 0x72,
-	0xc6, 0x3b, 0xae, 0x9e, 0x8a, 0x2b, 0x79, 0xd2, 0x8a, 0x9a, 0x04, 0x55, 0xe4, 0x9c, 0x40, 0x6f,
-	0xbc, 0x09, 0xd6, 0xfe, 0x38, 0x26, 0xa7, 0x27, 0xbf, 0x03, 0xa2, 0x27, 0xd5, 0xa9, 0x8f, 0xa1,
-	0x1a, 0xf8, 0xaa, 0x17, 0xd5, 0xc0, 0x8f, 0xa7, 0x34, 0xf2, 0x18, 0x52, 0xcd, 0x55, 0x69, 0x4c,
-	0x4e, 0xe1, 0xd5, 0x5c, 0x8e, 0x77, 0xe2, 0xa9, 0x24, 0x70, 0xbe, 0x86, 0xde, 0xad, 0xc7, 0x38,
-	0xea, 0x1f, 0xcb, 0xa9, 0x15, 0x9d, 0xfa, 0x2b, 0x10, 0x9d, 0xfa, 0x3f, 0x8e, 0x10, 0xdf, 0x58,
-	0x78, 0x62, 0xc3, 0xb3, 0x1b, 0xcb, 0xc8, 0xf9, 0x12, 0x5e, 0xff, 0x80, 0xa2, 0x70, 0x04, 0x43,
-	0xd6, 0xf9, 0x1d, 0xba, 0x39, 0x45, 0x7d, 0x5a, 0xff, 0x54, 0xe5, 0xd0, 0x6d, 0xab, 0xda, 0x15,
-	0x0e, 0x1e, 0xe0, 0x2d, 0x9c, 0xce, 0x50, 0xdc, 0x32, 0xbc, 0x47, 0x86, 0x74, 0x81, 0x87, 0x4e,
-	0x71, 0x0e, 0x7d, 0x83, 0xa7, 0x26, 0xee, 0x2b, 0x20, 0xf2, 0x6c, 0x77, 0xc8, 0x82, 0xfb, 0xdd,
-	0xa1, 0xf2, 0x78, 0xda, 0x75, 0x96, 0x51, 0x9c, 0x18, 0xe9, 0xa5, 0xe2, 0x94, 0x65, 0x14, 0xbb,
-	0xf8, 0x07, 0x2e, 0x5e, 0x2c, 0x4e, 0x59, 0xaa, 0x78, 0x08, 0xdd, 0x99, 0xb7, 0xfd, 0x2f, 0xcd,
-	0x3f, 0x81, 0x9e, 0xc6, 0x4c, 0xca, 0xdf, 0xfd, 0x5d, 0x87, 0xea, 0xdd, 0x35, 0xb9, 0x04, 0xc8,
-	0x77, 0x06, 0xb1, 0x33, 0xdf, 0xec, 0xfd, 0x27, 0xd8, 0x9f, 0x95, 0x62, 0xaa, 0x9d, 0x37, 0xd0,
-	0x29, 0x2c, 0x09, 0xf2, 0x26, 0x63, 0x97, 0x2d, 0x15, 0xfb, 0xf3, 0x43, 0xb0, 0xd2, 0xfb, 0x11,
-	0xda, 0xfa, 0x12, 0x21, 0x17, 0x25, 0xfc, 0x6c, 0xe5, 0xd8, 0x6f, 0x0e, 0xa0, 0x4a, 0xec, 0x3d,
-	0x34, 0xd2, 0x35, 0x43, 0xac, 0x8c, 0x6a, 0x2c, 0x23, 0xfb, 0xd3, 0x12, 0x44, 0x09, 0xfc, 0x02,
-	0xc7, 0xc5, 0xd5, 0x43, 0xf2, 0xf3, 0x97, 0x2e, 0x2b, 0xfb, 0x8b, 0x83, 0xb8, 0x92, 0xbc, 0x04,
-	0xc8, 0x77, 0x82, 0xf6, 0xee, 0x7b, 0xdb, 0x43, 0x7b, 0xf7, 0x92, 0x25, 0x72, 0x09, 0x90, 0xfb,
-	0x5a, 0x93, 0xd9, 0xdb, 0x0b, 0x9a, 0x4c, 0xc9, 0x22, 0x78, 0x0f, 0x8d, 0xd4, 0xa1, 0xda, 0x0b,
-	0x19, 0xbe, 0xd6, 0x5e, 0x68, 0xcf, 0xce, 0x37, 0xd0, 0x29, 0x98, 0x4b, 0xeb, 0x7f, 0x99, 0x39,
-	0xb5, 0xfe, 0x97, 0x7a, 0x92, 0x8c, 0xa1, 0x99, 0x8d, 0x2c, 0xd1, 0x3a, 0x63, 0x0c, 0xbc, 0x6d,
-	0x97, 0x41, 0x4a, 0xe3, 0x0a, 0x5a, 0x9a, 0x63, 0x89, 0xf6, 0x8e, 0x7b, 0x6e, 0xb7, 0x2f, 0xca,
-	0x41, 0x43, 0x29, 0xb1, 0xaf, 0xa9, 0x54, 0xb0, 0xbe, 0xa9, 0x54, 0x74, 0x7c, 0xa6, 0x94, 0x78,
-	0xd9, 0x54, 0x2a, 0xec, 0x01, 0x53, 0xa9, 0x68, 0xff, 0xf9, 0x91, 0x84, 0xbe, 0xfd, 0x37, 0x00,
-	0x00, 0xff, 0xff, 0x55, 0x95, 0xc0, 0x7a, 0xd4, 0x09, 0x00, 0x00,
+	// 847 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9d, 0x55, 0x5b, 0x6f, 0xd3, 0x30,
+	0x14, 0xd6, 0xba, 0x4b, 0xdb, 0xd3, 0xcb, 0x56, 0xaf, 0x1b, 0x25, 0x6c, 0x30, 0x22, 0x34, 0x0d,
+	0x09, 0xf5, 0x61, 0xbc, 0x22, 0xa1, 0x75, 0x5c, 0x36, 0xc1, 0x60, 0xa4, 0xd2, 0x84, 0x04, 0x2f,
+	0x69, 0xe2, 0xb5, 0xa1, 0x5d, 0x12, 0x62, 0xb7, 0x5b, 0xf9, 0x33, 0xfc, 0x12, 0xfe, 0x1b, 0xb6,
+	0xe3, 0x24, 0x8e, 0x9b, 0x6a, 0x88, 0xb7, 0x9c, 0xf3, 0x7d, 0xe7, 0xb3, 0x7d, 0x6e, 0x81, 0xca,
+	0xec, 0xa6, 0x1b, 0x46, 0x01, 0x0d, 0x50, 0x79, 0x76, 0x23, 0x3e, 0xcc, 0xdf, 0x6b, 0xd0, 0x3a,
+	0xf7, 0x3d, 0xea, 0xd9, 0x13, 0xef, 0x17, 0xb6, 0xf0, 0xcf, 0x29, 0x26, 0x14, 0xed, 0x41, 0xd5,
+	0xc7, 0xf4, 0x36, 0x88, 0xc6, 0xe7, 0x6f, 0x3a, 0x2b, 0x07, 0x2b, 0x47, 0x0d, 0x2b, 0x73, 0x20,
+	0x03, 0x2a, 0x64, 0x3a, 0x60, 0x36, 0x03, 0x4b, 0x0c, 0xac, 0x5b, 0xa9, 0x8d, 0x3a, 0x50, 0x76,
+	0x46, 0xb6, 0xe7, 0x33, 0x68, 0x55, 0x40, 0x89, 0x89, 0x76, 0x61, 0xc3, 0x0f, 0x5c, 0xcc, 0x80,
+	0x35, 0x01, 0x48, 0x8b, 0xab, 0xdd, 0x9d, 0xca, 0x90, 0xf5, 0x58, 0x2d, 0xb1, 0xd1, 0x01, 0xd4,
+	0xec, 0x99, 0x7d, 0x77, 0x42, 0x88, 0x38, 0x6c, 0x43, 0xc0, 0xaa, 0x0b, 0x99, 0x50, 0x1f, 0x62,
+	0x1f, 0x13, 0x8f, 0xf4, 0xe6, 0x14, 0x93, 0x4e, 0x59, 0x50, 0x72, 0x3e, 0x7e, 0x82, 0x3b, 0xe8,
+	0xe3, 0x68, 0x86, 0xa3, 0x4e, 0x45, 0x3c, 0x26, 0xb5, 0x79, 0x3c, 0xf6, 0x87, 0x9e, 0x8f, 0x25,
+	0x5e, 0x15, 0x78, 0xce, 0x87, 0x0e, 0xa1, 0x39, 0xc6, 0x73, 0x42, 0x83, 0x28, 0x61, 0x81, 0x60,
+	0x69, 0x5e, 0xd4, 0x05, 0x44, 0x46, 0x76, 0x84, 0xdd, 0x0b, 0x7c, 0x13, 0x44, 0x73, 0xc9, 0xad,
+	0x09, 0x6e, 0x01, 0xc2, 0x75, 0x07, 0xce, 0xc7, 0x20, 0x18, 0x4f, 0x43, 0xc9, 0xad, 0xc7, 0xba,
+	0x79, 0x2f, 0xe7, 0x11, 0x3f, 0xc7, 0x6b, 0xc4, 0xbc, 0xbc, 0x97, 0x67, 0xcb, 0x09, 0xfc, 0x6b,
+	0x6f, 0x18, 0xa7, 0xa2, 0x19, 0x67, 0x4b, 0x71, 0xa1, 0x63, 0x68, 0xcf, 0x58, 0xa1, 0x5d, 0x9b,
+	0xdd, 0xba, 0x4f, 0x6d, 0x9a, 0xbc, 0x67, 0x53, 0xe8, 0x15, 0x62, 0xe6, 0x2b, 0x40, 0x6a, 0x83,
+	0x90, 0x30, 0xf0, 0x09, 0xe6, 0x77, 0x9a, 0xd8, 0x84, 0x9e, 0x38, 0x0e, 0x0e, 0x29, 0x76, 0x65,
+	0x9b, 0xd4, 0x2d, 0xcd, 0x6b, 0xee, 0x42, 0xbb, 0x17, 0x04, 0x94, 0xd0, 0xc8, 0x0e, 0x43, 0xcf,
+	0x1f, 0xca, 0x0e, 0x33, 0x1f, 0xc0, 0x8e, 0xe6, 0x8f, 0x85, 0xcd, 0x1d, 0xd8, 0xce, 0x00, 0xec,
+	0x26, 0xfc, 0x9c, 0x0e, 0x77, 0x4b, 0x7a, 0x0b, 0x36, 0xfb, 0xa3, 0x29, 0x75, 0x83, 0x5b, 0x3f,
+	0xa1, 0x22, 0xd8, 0xca, 0x5c, 0x92, 0xc6, 0x8e, 0x3b, 0x8d, 0x30, 0x7b, 0xd4, 0x99, 0xed, 0xbb,
+	0x13, 0x1c, 0x91, 0x84, 0xfc, 0x0e, 0x76, 0x75, 0x40, 0xbe, 0xf0, 0x05, 0x54, 0x46, 0xd2, 0xc7,
+	0xde, 0xb6, 0x7a, 0x54, 0x3b, 0xde, 0xea, 0xca, 0xa9, 0xe9, 0x4a, 0xb2, 0x95, 0x32, 0xcc, 0x6f,
+	0x50, 0x96, 0x4e, 0xde, 0xe8, 0x61, 0x84, 0xaf, 0xbd, 0x3b, 0x91, 0x92, 0xaa, 0x25, 0x2d, 0x5e,
+	0x9e, 0x49, 0xe0, 0x8c, 0x3f, 0x87, 0xd4, 0x63, 0x07, 0x88, 0xc9, 0x69, 0x58, 0xaa, 0x8b, 0x47,
+	0x92, 0xb8, 0x20, 0xab, 0x02, 0x94, 0x96, 0xb9, 0x0d, 0xad, 0xde, 0xd4, 0x9b, 0xb8, 0x3d, 0x4e,
+	0x4e, 0x6e, 0x7e, 0x05, 0x48, 0x75, 0xca, 0x5b, 0x37, 0xa1, 0xe4, 0xb9, 0xb2, 0x16, 0xec, 0x8b,
+	0xf7, 0x7e, 0xc8, 0x1a, 0xcf, 0x57, 0x66, 0x35, 0xb1, 0x51, 0x1b, 0xd6, 0x07, 0xa2, 0x53, 0xe2,
+	0x49, 0x8d, 0x0d, 0xf3, 0x39, 0xb4, 0x2e, 0xed, 0x88, 0x60, 0xf5, 0xb0, 0x8c, 0xba, 0xa2, 0x52,
+	0xbf, 0x02, 0x52, 0xa9, 0xff, 0x71, 0x05, 0xfe, 0x62, 0xd6, 0x6b, 0x53, 0x92, 0xbe, 0x58, 0x58,
+	0xe6, 0x53, 0xd8, 0x7c, 0x8f, 0x69, 0xee, 0x0a, 0x9a, 0xac, 0xf9, 0x1d, 0xb6, 0x32, 0x8a, 0x3c,
+	0x5a, 0x3d, 0x6a, 0x65, 0xd9, 0x6b, 0x4b, 0xca, 0x13, 0x96, 0x5e, 0xe0, 0x10, 0xda, 0x7d, 0x4c,
+	0x2f, 0x59, 0xe5, 0x30, 0x8b, 0x77, 0xf0, 0xb2, 0x5b, 0xb0, 0xc6, 0xd2, 0x78, 0xb2, 0xe3, 0x9e,
+	0xb1, 0xf2, 0xf0, 0xbb, 0x5d, 0xe1, 0xc8, 0xbb, 0x9e, 0x2f, 0x0b, 0xe7, 0xdd, 0xae, 0xb2, 0xb4,
+	0xe0, 0x78, 0x90, 0xee, 0x0b, 0x4e, 0x58, 0x5a, 0xb0, 0x85, 0x7f, 0x60, 0xe7, 0xde, 0xe0, 0x84,
+	0x25, 0x83, 0x8f, 0xd8, 0xf0, 0xd8, 0xb3, 0x7f, 0x29, 0x3e, 0x6b, 0x4a, 0x85, 0x19, 0x87, 0x1f,
+	0xff, 0x29, 0x43, 0xe9, 0xea, 0x02, 0xbd, 0x05, 0xc8, 0x76, 0x06, 0x32, 0xd2, 0xb9, 0x59, 0xf8,
+	0xd3, 0x18, 0x8f, 0x0a, 0x31, 0x59, 0xce, 0x4f, 0xd0, 0xc8, 0x2d, 0x09, 0xb4, 0x9f, 0xb2, 0x8b,
+	0x96, 0x8a, 0xf1, 0x78, 0x19, 0x2c, 0xf5, 0x3e, 0x40, 0x5d, 0x5d, 0x22, 0x68, 0xaf, 0x80, 0x9f,
+	0xae, 0x1c, 0x63, 0x7f, 0x09, 0x2a, 0xc5, 0x5e, 0x43, 0x25, 0x59, 0x33, 0xa8, 0x93, 0x52, 0xb5,
+	0x65, 0x64, 0x3c, 0x2c, 0x40, 0xa4, 0xc0, 0x17, 0x68, 0xe6, 0x57, 0x0f, 0xca, 0xee, 0x5f, 0xb8,
+	0xac, 0x8c, 0x27, 0x4b, 0x71, 0x29, 0xc9, 0xf2, 0x9e, 0xed, 0x04, 0x25, 0xef, 0x0b, 0xdb, 0x43,
+	0xc9, 0x7b, 0xc1, 0x12, 0x61, 0x32, 0xd9, 0x5c, 0x2b, 0x32, 0x0b, 0x7b, 0x41, 0x91, 0x29, 0x58,
+	0x04, 0x2c, 0x43, 0xc9, 0x84, 0x2a, 0x19, 0xd2, 0xe6, 0x5a, 0xc9, 0xd0, 0xc2, 0x38, 0xb3, 0xfa,
+	0xe7, 0x86, 0x4b, 0xa9, 0x7f, 0xd1, 0x70, 0x2a, 0xf5, 0x2f, 0x9c, 0x49, 0xd4, 0x83, 0x6a, 0xda,
+	0xb2, 0x48, 0xa9, 0x8c, 0xd6, 0xf0, 0x86, 0x51, 0x04, 0x49, 0x8d, 0x33, 0xa8, 0x29, 0x13, 0x8b,
+	0x94, 0x3c, 0x2e, 0x4c, 0xbb, 0xb1, 0x57, 0x0c, 0x6a, 0x4a, 0xf1, 0xf8, 0xea, 0x4a, 0xb9, 0xd1,
+	0xd7, 0x95, 0xf2, 0x13, 0x9f, 0x2a, 0xc5, 0xb3, 0xac, 0x2b, 0xe5, 0xf6, 0x80, 0xae, 0x94, 0x1f,
+	0xff, 0xc1, 0x86, 0x80, 0x5e, 0xfe, 0x05, 0xe5, 0x2a, 0xdd, 0xe7, 0x2a, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,91 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// JSONOutputOwners is the JSON representation of OutputOwners, with the
+// addresses formatted
+type JSONOutputOwners struct {
+	Locktime  uint64   `json:"locktime"`
+	Threshold uint32   `json:"threshold"`
+	Addresses []string `json:"addresses"`
+}
+
+// FormatOwners returns the JSON representation of [out], with the addresses
+// formatted by [formatAddr]
+func (out *OutputOwners) FormatOwners(formatAddr func(ids.ShortID) (string, error)) (JSONOutputOwners, error) {
+	addrs := make([]string, len(out.Addrs))
+	for i, addr := range out.Addrs {
+		addrStr, err := formatAddr(addr)
+		if err != nil {
+			return JSONOutputOwners{}, err
+		}
+		addrs[i] = addrStr
+	}
+	return JSONOutputOwners{
+		Locktime:  out.Locktime,
+		Threshold: out.Threshold,
+		Addresses: addrs,
+	}, nil
+}
+
+// JSON returns the JSON representation of [out], with the addresses formatted
+// by [formatAddr]
+func (out *OutputOwners) JSON(formatAddr func(ids.ShortID) (string, error)) (interface{}, error) {
+	owners, err := out.FormatOwners(formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &owners, nil
+}
+
+// JSONTransferOutput is the JSON representation of TransferOutput
+type JSONTransferOutput struct {
+	Amt uint64 `json:"amount"`
+	JSONOutputOwners
+}
+
+// JSON returns the JSON representation of [out], with the addresses formatted
+// by [formatAddr]
+func (out *TransferOutput) JSON(formatAddr func(ids.ShortID) (string, error)) (interface{}, error) {
+	owners, err := out.FormatOwners(formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONTransferOutput{
+		Amt:              out.Amt,
+		JSONOutputOwners: owners,
+	}, nil
+}
+
+// JSONMintOperation is the JSON representation of MintOperation
+type JSONMintOperation struct {
+	MintInput      Input              `json:"mintInput"`
+	MintOutput     JSONOutputOwners   `json:"mintOutput"`
+	TransferOutput JSONTransferOutput `json:"transferOutput"`
+}
+
+// JSON returns the JSON representation of [op], with the addresses formatted
+// by [formatAddr]
+func (op *MintOperation) JSON(formatAddr func(ids.ShortID) (string, error)) (interface{}, error) {
+	mintOwners, err := op.MintOutput.FormatOwners(formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	transferOwners, err := op.TransferOutput.FormatOwners(formatAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONMintOperation{
+		MintInput:  op.MintInput,
+		MintOutput: mintOwners,
+		TransferOutput: JSONTransferOutput{
+			Amt:              op.TransferOutput.Amt,
+			JSONOutputOwners: transferOwners,
+		},
+	}, nil
+}
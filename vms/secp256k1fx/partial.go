@@ -0,0 +1,119 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+// A partially signed transaction carries a credential for every input, but
+// the signatures that haven't been provided yet are left empty. Signatures
+// can then be collected from each of the key holders before the transaction
+// is issued.
+
+// SigSlot is a signature that a credential must contain
+type SigSlot struct {
+	// Address whose signature belongs in this slot
+	Address ids.ShortID
+
+	// True if the signature has been provided
+	Signed bool
+}
+
+// MatchAddresses attempts to match a list of addresses up to the provided
+// threshold. Unlike Keychain.Match, the keys of [addrs] aren't needed.
+func MatchAddresses(owners *OutputOwners, addrs ids.ShortSet, time uint64) ([]uint32, bool) {
+	if time < owners.Locktime {
+		return nil, false
+	}
+	sigs := make([]uint32, 0, owners.Threshold)
+	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(sigs)) < owners.Threshold; i++ {
+		if addrs.Contains(owners.Addrs[i]) {
+			sigs = append(sigs, i)
+		}
+	}
+	return sigs, uint32(len(sigs)) == owners.Threshold
+}
+
+// SpendWithAddresses attempts to create an input that will be signed by
+// [addrs]
+func SpendWithAddresses(out verify.Verifiable, addrs ids.ShortSet, time uint64) (verify.Verifiable, error) {
+	switch out := out.(type) {
+	case *MintOutput:
+		if sigIndices, able := MatchAddresses(&out.OutputOwners, addrs, time); able {
+			return &Input{
+				SigIndices: sigIndices,
+			}, nil
+		}
+		return nil, errCantSpend
+	case *TransferOutput:
+		if sigIndices, able := MatchAddresses(&out.OutputOwners, addrs, time); able {
+			return &TransferInput{
+				Amt: out.Amt,
+				Input: Input{
+					SigIndices: sigIndices,
+				},
+			}, nil
+		}
+		return nil, errCantSpend
+	}
+	return nil, fmt.Errorf("can't spend UTXO because it is unexpected type %T", out)
+}
+
+// NewUnsignedCredential returns a credential for [in] that doesn't contain
+// any signatures yet
+func NewUnsignedCredential(in *Input) *Credential {
+	return &Credential{
+		Sigs: make([][crypto.SECP256K1RSigLen]byte, len(in.SigIndices)),
+	}
+}
+
+// SigSlots returns the signatures [cred] must contain for [in] to spend an
+// output owned by [owners]
+func SigSlots(in *Input, owners *OutputOwners, cred *Credential) ([]SigSlot, error) {
+	if len(in.SigIndices) != len(cred.Sigs) {
+		return nil, errInputCredentialSignersMismatch
+	}
+	slots := make([]SigSlot, len(in.SigIndices))
+	for i, index := range in.SigIndices {
+		if index >= uint32(len(owners.Addrs)) {
+			return nil, errInputOutputIndexOutOfBounds
+		}
+		slots[i] = SigSlot{
+			Address: owners.Addrs[index],
+			Signed:  cred.Sigs[i] != [crypto.SECP256K1RSigLen]byte{},
+		}
+	}
+	return slots, nil
+}
+
+// SignCredential signs [hash] with the keys in [kc] that belong in the
+// missing signatures of [cred]. Returns the number of signatures added.
+func (kc *Keychain) SignCredential(hash []byte, in *Input, owners *OutputOwners, cred *Credential) (int, error) {
+	slots, err := SigSlots(in, owners, cred)
+	if err != nil {
+		return 0, err
+	}
+	numSigned := 0
+	for i, slot := range slots {
+		if slot.Signed {
+			continue
+		}
+		key, exists := kc.Get(slot.Address)
+		if !exists {
+			continue
+		}
+		sig, err := key.SignHash(hash)
+		if err != nil {
+			return numSigned, err
+		}
+		copy(cred.Sigs[i][:], sig)
+		numSigned++
+	}
+	return numSigned, nil
+}
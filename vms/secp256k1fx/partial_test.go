@@ -0,0 +1,144 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+func testKeys(t *testing.T) []*crypto.PrivateKeySECP256K1R {
+	factory := crypto.FactorySECP256K1R{}
+	sks := []*crypto.PrivateKeySECP256K1R{}
+	for _, keyStr := range keys {
+		skBytes, err := formatting.Decode(defaultEncoding, keyStr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		skIntf, err := factory.ToPrivateKey(skBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sks = append(sks, skIntf.(*crypto.PrivateKeySECP256K1R))
+	}
+	return sks
+}
+
+func TestMatchAddresses(t *testing.T) {
+	sks := testKeys(t)
+	owners := OutputOwners{
+		Locktime:  1,
+		Threshold: 2,
+		Addrs: []ids.ShortID{
+			sks[0].PublicKey().Address(),
+			sks[1].PublicKey().Address(),
+			sks[2].PublicKey().Address(),
+		},
+	}
+
+	addrs := ids.ShortSet{}
+	addrs.Add(owners.Addrs[0], owners.Addrs[2])
+	if _, ok := MatchAddresses(&owners, addrs, 0); ok {
+		t.Fatalf("Shouldn't have matched a locked output")
+	}
+	indices, ok := MatchAddresses(&owners, addrs, 1)
+	if !ok {
+		t.Fatalf("Should have matched the owners")
+	}
+	if len(indices) != 2 || indices[0] != 0 || indices[1] != 2 {
+		t.Fatalf("Wrong indices %v", indices)
+	}
+
+	addrs = ids.ShortSet{}
+	addrs.Add(owners.Addrs[1])
+	if _, ok := MatchAddresses(&owners, addrs, 1); ok {
+		t.Fatalf("Shouldn't have matched below the threshold")
+	}
+}
+
+func TestSignCredential(t *testing.T) {
+	sks := testKeys(t)
+	owners := OutputOwners{
+		Threshold: 2,
+		Addrs: []ids.ShortID{
+			sks[0].PublicKey().Address(),
+			sks[1].PublicKey().Address(),
+		},
+	}
+	in := &Input{SigIndices: []uint32{0, 1}}
+	cred := NewUnsignedCredential(in)
+	hash := hashing.ComputeHash256([]byte{1, 2, 3})
+
+	slots, err := SigSlots(in, &owners, cred)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, slot := range slots {
+		if slot.Signed {
+			t.Fatalf("Slot %d shouldn't be signed", i)
+		}
+		if !slot.Address.Equals(owners.Addrs[i]) {
+			t.Fatalf("Slot %d has the wrong address", i)
+		}
+	}
+
+	// Each key holder adds their own signature
+	for i, sk := range sks[:2] {
+		kc := NewKeychain()
+		kc.Add(sk)
+		numSigned, err := kc.SignCredential(hash, in, &owners, cred)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if numSigned != 1 {
+			t.Fatalf("Should have added 1 signature but added %d", numSigned)
+		}
+
+		slots, err := SigSlots(in, &owners, cred)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slots[i].Signed {
+			t.Fatalf("Slot %d should be signed", i)
+		}
+	}
+
+	// Signatures that were already provided aren't replaced
+	kc := NewKeychain()
+	kc.Add(sks[0])
+	if numSigned, err := kc.SignCredential(hash, in, &owners, cred); err != nil {
+		t.Fatal(err)
+	} else if numSigned != 0 {
+		t.Fatalf("Shouldn't have added any signatures but added %d", numSigned)
+	}
+
+	factory := crypto.FactorySECP256K1R{}
+	for i, sig := range cred.Sigs {
+		pk, err := factory.RecoverHashPublicKey(hash, sig[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !pk.Address().Equals(owners.Addrs[i]) {
+			t.Fatalf("Signature %d is from the wrong key", i)
+		}
+	}
+}
+
+func TestSigSlotsMismatch(t *testing.T) {
+	owners := OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	if _, err := SigSlots(&Input{SigIndices: []uint32{0}}, &owners, &Credential{}); err != errInputCredentialSignersMismatch {
+		t.Fatalf("Expected %s but got %v", errInputCredentialSignersMismatch, err)
+	}
+	in := &Input{SigIndices: []uint32{1}}
+	if _, err := SigSlots(in, &owners, NewUnsignedCredential(in)); err != errInputOutputIndexOutOfBounds {
+		t.Fatalf("Expected %s but got %v", errInputOutputIndexOutOfBounds, err)
+	}
+}
@@ -0,0 +1,191 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// Builder selects the UTXOs that fund a transaction out of the UTXOs it is
+// given, and returns the inputs, outputs and operations that spend them along
+// with the keys that sign them. It doesn't read the state of the chain, so it
+// can be used without a node. The chains put the returned parts in their
+// transactions and sign them.
+type Builder struct {
+	AVAXAssetID ids.ID
+
+	// Codec that serializes the outputs and operations, so they can be sorted.
+	// It must have the types of the chain's fxs registered.
+	Codec codec.Manager
+
+	// Clock that decides which UTXOs are unlocked. If nil, the wall clock is
+	// used.
+	Clock *timer.Clock
+}
+
+// now returns the time the UTXOs are spent at
+func (b *Builder) now() uint64 {
+	if b.Clock == nil {
+		return (&timer.Clock{}).Unix()
+	}
+	return b.Clock.Unix()
+}
+
+// Spend returns inputs that spend at least [amounts] out of [utxos], the
+// amount of each asset they spend, and the keys that sign them. The inputs
+// are selected so that they fit in a transaction.
+func (b *Builder) Spend(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	amounts map[ids.ID]uint64,
+) (
+	map[ids.ID]uint64,
+	[]*avax.TransferableInput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	candidates := KeychainSpendableUTXOs(utxos, kc, b.now())
+	amountsSpent, selected, err := SelectUTXOs(candidates, amounts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ins := make([]*avax.TransferableInput, len(selected))
+	keys := make([][]*crypto.PrivateKeySECP256K1R, len(selected))
+	for i, utxo := range selected {
+		ins[i] = utxo.Input()
+		keys[i] = utxo.Signers
+	}
+	avax.SortTransferableInputsWithSigners(ins, keys)
+	return amountsSpent, ins, keys, nil
+}
+
+// SpendWithAddresses is like Spend, but only needs the addresses that will
+// sign the inputs rather than their keys. The returned inputs are sorted.
+func (b *Builder) SpendWithAddresses(
+	utxos []*avax.UTXO,
+	addrs ids.ShortSet,
+	amounts map[ids.ID]uint64,
+) (
+	map[ids.ID]uint64,
+	[]*avax.TransferableInput,
+	error,
+) {
+	candidates := AddressSpendableUTXOs(utxos, addrs, b.now())
+	amountsSpent, selected, err := SelectUTXOs(candidates, amounts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ins := make([]*avax.TransferableInput, len(selected))
+	for i, utxo := range selected {
+		ins[i] = utxo.Input()
+	}
+	avax.SortTransferableInputs(ins)
+	return amountsSpent, ins, nil
+}
+
+// SpendAll returns inputs that spend every one of [utxos] that [kc] can spend,
+// the amount of each asset they spend, and the keys that sign them
+func (b *Builder) SpendAll(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+) (
+	map[ids.ID]uint64,
+	[]*avax.TransferableInput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	amountsSpent := make(map[ids.ID]uint64)
+	time := b.now()
+
+	ins := []*avax.TransferableInput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	for _, utxo := range utxos {
+		assetID := utxo.AssetID()
+		amountSpent := amountsSpent[assetID]
+
+		inputIntf, signers, err := kc.Spend(utxo.Out, time)
+		if err != nil {
+			// this utxo can't be spent with the current keys right now
+			continue
+		}
+		input, ok := inputIntf.(avax.TransferableIn)
+		if !ok {
+			// this input doesn't have an amount, so I don't care about it here
+			continue
+		}
+		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
+		if err != nil {
+			// there was an error calculating the consumed amount, just error
+			return nil, nil, nil, errSpendOverflow
+		}
+		amountsSpent[assetID] = newAmountSpent
+
+		// add the new input to the array
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: assetID},
+			In:     input,
+		})
+		// add the required keys to the array
+		keys = append(keys, signers)
+	}
+
+	avax.SortTransferableInputsWithSigners(ins, keys)
+	return amountsSpent, ins, keys, nil
+}
+
+// SpendAllWithAddresses is like SpendAll, but only needs the addresses that
+// will sign the inputs rather than their keys. The returned inputs are sorted.
+func (b *Builder) SpendAllWithAddresses(
+	utxos []*avax.UTXO,
+	addrs ids.ShortSet,
+) (
+	map[ids.ID]uint64,
+	[]*avax.TransferableInput,
+	error,
+) {
+	amountsSpent := make(map[ids.ID]uint64)
+	time := b.now()
+
+	ins := []*avax.TransferableInput{}
+	for _, utxo := range utxos {
+		assetID := utxo.AssetID()
+		amountSpent := amountsSpent[assetID]
+
+		inputIntf, err := secp256k1fx.SpendWithAddresses(utxo.Out, addrs, time)
+		if err != nil {
+			// this utxo can't be spent by these addresses right now
+			continue
+		}
+		input, ok := inputIntf.(avax.TransferableIn)
+		if !ok {
+			// this input doesn't have an amount, so I don't care about it here
+			continue
+		}
+		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
+		if err != nil {
+			// there was an error calculating the consumed amount, just error
+			return nil, nil, errSpendOverflow
+		}
+		amountsSpent[assetID] = newAmountSpent
+
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: assetID},
+			In:     input,
+		})
+	}
+
+	avax.SortTransferableInputs(ins)
+	return amountsSpent, ins, nil
+}
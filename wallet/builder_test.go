@@ -0,0 +1,177 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestBuilderSpend(t *testing.T) {
+	b := newTestBuilder(t)
+	key := newTestKey(t)
+	kc := secp256k1fx.NewKeychain()
+	kc.Add(key)
+	addr := key.PublicKey().Address()
+
+	assetID := ids.Empty.Prefix(2)
+	utxos := []*avax.UTXO{
+		newTestUTXO(ids.Empty.Prefix(0), testAVAXAssetID, 1000, addr),
+		newTestUTXO(ids.Empty.Prefix(1), assetID, 50, addr),
+		// Can't be spent by [kc]
+		newTestUTXO(ids.Empty.Prefix(3), assetID, 500, ids.ShortEmpty),
+	}
+
+	amountsSpent, ins, signers, err := b.Spend(utxos, kc, map[ids.ID]uint64{
+		testAVAXAssetID: 10,
+		assetID:         20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numIns := len(ins); numIns != 2 {
+		t.Fatalf("expected the AVAX and the asset to be spent by 2 inputs but got %d", numIns)
+	}
+	if numSigners := len(signers); numSigners != len(ins) {
+		t.Fatalf("expected one set of signers per input but got %d", numSigners)
+	}
+	if !avax.IsSortedAndUniqueTransferableInputs(ins) {
+		t.Fatalf("inputs should be sorted")
+	}
+	if amount := amountsSpent[assetID]; amount != 50 {
+		t.Fatalf("expected 50 of the asset to be spent but got %d", amount)
+	}
+	change := ChangeOutputs(amountsSpent, map[ids.ID]uint64{
+		testAVAXAssetID: 10,
+		assetID:         20,
+	}, addr)
+	if numChange := len(change); numChange != 2 {
+		t.Fatalf("expected change for both assets but got %d outputs", numChange)
+	}
+
+	if _, _, _, err := b.Spend(utxos, kc, map[ids.ID]uint64{assetID: 51}); err == nil {
+		t.Fatalf("should have failed to spend more than the keys own")
+	}
+}
+
+func TestBuilderStake(t *testing.T) {
+	b := newTestBuilder(t)
+	clock := timer.Clock{}
+	clock.Set(clock.Time())
+	b.Clock = &clock
+
+	key := newTestKey(t)
+	kc := secp256k1fx.NewKeychain()
+	kc.Add(key)
+	addr := key.PublicKey().Address()
+
+	locked := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.Empty.Prefix(0)},
+		Asset:  avax.Asset{ID: testAVAXAssetID},
+		Out: &stakeable.LockOut{
+			Locktime:        clock.Unix() + 1,
+			TransferableOut: newTestUTXO(ids.Empty, testAVAXAssetID, 600, addr).Out.(*secp256k1fx.TransferOutput),
+		},
+	}
+	unlocked := newTestUTXO(ids.Empty.Prefix(1), testAVAXAssetID, 1000, addr)
+	utxos := []*avax.UTXO{unlocked, locked}
+
+	// The locked AVAX is staked first, the rest is staked and the fee is paid
+	// out of the unlocked AVAX
+	ins, returned, staked, signers, err := b.Stake(utxos, kc, 1000, 10, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numIns := len(ins); numIns != 2 || len(signers) != 2 {
+		t.Fatalf("expected 2 inputs but got %d", numIns)
+	}
+	stakedAmount := uint64(0)
+	for _, out := range staked {
+		stakedAmount += out.Out.Amount()
+	}
+	if stakedAmount != 1000 {
+		t.Fatalf("expected 1000 to be staked but got %d", stakedAmount)
+	}
+	if numReturned := len(returned); numReturned != 1 || returned[0].Out.Amount() != 590 {
+		t.Fatalf("expected the 590 that wasn't staked or burned to be returned")
+	}
+
+	if _, _, _, _, err := b.Stake(utxos, kc, 1600, 10, addr); err == nil {
+		t.Fatalf("should have failed to stake more than the keys own")
+	}
+}
+
+func TestBuilderAuthorize(t *testing.T) {
+	b := newTestBuilder(t)
+	key := newTestKey(t)
+	kc := secp256k1fx.NewKeychain()
+	kc.Add(key)
+
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{key.PublicKey().Address()},
+	}
+	if _, signers, err := b.Authorize(owner, kc); err != nil {
+		t.Fatal(err)
+	} else if len(signers) != 1 {
+		t.Fatalf("expected 1 signer but got %d", len(signers))
+	}
+
+	owner.Addrs = []ids.ShortID{ids.ShortEmpty}
+	if _, _, err := b.Authorize(owner, kc); err != errCantSign {
+		t.Fatalf("expected %s but got %v", errCantSign, err)
+	}
+}
+
+func TestBuilderMint(t *testing.T) {
+	b := newTestBuilder(t)
+	key := newTestKey(t)
+	kc := secp256k1fx.NewKeychain()
+	kc.Add(key)
+	addr := key.PublicKey().Address()
+
+	assetID := ids.Empty.Prefix(2)
+	minter := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.Empty.Prefix(0)},
+		Asset:  avax.Asset{ID: assetID},
+		Out: &secp256k1fx.MintOutput{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.ShortEmpty},
+	}
+
+	amounts := map[ids.ID]uint64{assetID: 5}
+	ops, signers, err := b.Mint([]*avax.UTXO{minter}, kc, amounts, owners)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 1 || len(signers) != 1 {
+		t.Fatalf("expected 1 operation but got %d", len(ops))
+	}
+	mintOp, ok := ops[0].Op.(*secp256k1fx.MintOperation)
+	if !ok {
+		t.Fatalf("expected a mint operation but got %T", ops[0].Op)
+	}
+	if mintOp.TransferOutput.Amt != 5 {
+		t.Fatalf("expected 5 to be minted but got %d", mintOp.TransferOutput.Amt)
+	}
+	if amounts[assetID] != 5 {
+		t.Fatalf("the requested amounts shouldn't be modified")
+	}
+
+	if _, _, err := b.Mint([]*avax.UTXO{minter}, kc, map[ids.ID]uint64{ids.Empty: 5}, owners); err != errCantMint {
+		t.Fatalf("expected %s but got %v", errCantMint, err)
+	}
+}
@@ -0,0 +1,299 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var errCantSign = errors.New("can't sign")
+
+// Spender creates an input that spends [out] at [time], and returns the keys
+// that sign the input
+type Spender func(out verify.Verifiable, time uint64) (verify.Verifiable, []*crypto.PrivateKeySECP256K1R, error)
+
+// Stake the provided amount from [utxos] with the keys in [kc], while
+// deducting the provided fee.
+// Returns:
+// - [inputs] the inputs that should be consumed to fund the outputs
+// - [returnedOutputs] the outputs that should be immediately returned to the
+//                     UTXO set
+// - [stakedOutputs] the outputs that should be locked for the duration of the
+//                   staking period
+// - [signers] the proof of ownership of the funds being moved
+func (b *Builder) Stake(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	amount uint64,
+	fee uint64,
+	changeAddr ids.ShortID,
+) (
+	[]*avax.TransferableInput, // inputs
+	[]*avax.TransferableOutput, // returnedOutputs
+	[]*avax.TransferableOutput, // stakedOutputs
+	[][]*crypto.PrivateKeySECP256K1R, // signers
+	error,
+) {
+	return b.StakeWith(utxos, kc.Spend, amount, fee, changeAddr)
+}
+
+// StakeWith is like Stake, but the inputs that consume [utxos] are created by
+// [spend]
+func (b *Builder) StakeWith(
+	utxos []*avax.UTXO,
+	spend Spender,
+	amount uint64,
+	fee uint64,
+	changeAddr ids.ShortID,
+) (
+	[]*avax.TransferableInput, // inputs
+	[]*avax.TransferableOutput, // returnedOutputs
+	[]*avax.TransferableOutput, // stakedOutputs
+	[][]*crypto.PrivateKeySECP256K1R, // signers
+	error,
+) {
+	// Minimum time this transaction will be issued at
+	now := b.now()
+
+	ins := []*avax.TransferableInput{}
+	returnedOuts := []*avax.TransferableOutput{}
+	stakedOuts := []*avax.TransferableOutput{}
+	signers := [][]*crypto.PrivateKeySECP256K1R{}
+
+	// Amount of AVAX that has been staked
+	amountStaked := uint64(0)
+
+	// Consume locked UTXOs
+	for _, utxo := range utxos {
+		// If we have consumed more AVAX than we are trying to stake, then we
+		// have no need to consume more locked AVAX
+		if amountStaked >= amount {
+			break
+		}
+
+		if assetID := utxo.AssetID(); assetID != b.AVAXAssetID {
+			continue // We only care about staking AVAX, so ignore other assets
+		}
+
+		out, ok := utxo.Out.(*stakeable.LockOut)
+		if !ok {
+			// This output isn't locked, so it will be handled during the next
+			// iteration of the UTXO set
+			continue
+		}
+		if out.Locktime <= now {
+			// This output is no longer locked, so it will be handled during the
+			// next iteration of the UTXO set
+			continue
+		}
+
+		inner, ok := out.TransferableOut.(*secp256k1fx.TransferOutput)
+		if !ok {
+			// We only know how to clone secp256k1 outputs for now
+			continue
+		}
+
+		inIntf, inSigners, err := spend(out.TransferableOut, now)
+		if err != nil {
+			// We couldn't spend the output, so move on to the next one
+			continue
+		}
+		in, ok := inIntf.(avax.TransferableIn)
+		if !ok {
+			// Because we only use the secp Fx right now, this should never
+			// happen
+			continue
+		}
+
+		// The remaining value is initially the full value of the input
+		remainingValue := in.Amount()
+
+		// Stake any value that should be staked
+		amountToStake := safemath.Min64(
+			amount-amountStaked, // Amount we still need to stake
+			remainingValue,      // Amount available to stake
+		)
+		amountStaked += amountToStake
+		remainingValue -= amountToStake
+
+		// Add the input to the consumed inputs
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: b.AVAXAssetID},
+			In: &stakeable.LockIn{
+				Locktime:       out.Locktime,
+				TransferableIn: in,
+			},
+		})
+
+		// Add the output to the staked outputs
+		stakedOuts = append(stakedOuts, &avax.TransferableOutput{
+			Asset: avax.Asset{ID: b.AVAXAssetID},
+			Out: &stakeable.LockOut{
+				Locktime: out.Locktime,
+				TransferableOut: &secp256k1fx.TransferOutput{
+					Amt:          amountToStake,
+					OutputOwners: inner.OutputOwners,
+				},
+			},
+		})
+
+		if remainingValue > 0 {
+			// This input provided more value than was needed to be locked.
+			// Some of it must be returned
+			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: b.AVAXAssetID},
+				Out: &stakeable.LockOut{
+					Locktime: out.Locktime,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt:          remainingValue,
+						OutputOwners: inner.OutputOwners,
+					},
+				},
+			})
+		}
+
+		// Add the signers needed for this input to the set of signers
+		signers = append(signers, inSigners)
+	}
+
+	// Amount of AVAX that has been burned
+	amountBurned := uint64(0)
+
+	for _, utxo := range utxos {
+		// If we have consumed more AVAX than we are trying to stake, and we
+		// have burned more AVAX then we need to, then we have no need to
+		// consume more AVAX
+		if amountBurned >= fee && amountStaked >= amount {
+			break
+		}
+
+		if assetID := utxo.AssetID(); assetID != b.AVAXAssetID {
+			continue // We only care about burning AVAX, so ignore other assets
+		}
+
+		out := utxo.Out
+		inner, ok := out.(*stakeable.LockOut)
+		if ok {
+			if inner.Locktime > now {
+				// This output is currently locked, so this output can't be
+				// burned. Additionally, it may have already been consumed
+				// above. Regardless, we skip to the next UTXO
+				continue
+			}
+			out = inner.TransferableOut
+		}
+
+		inIntf, inSigners, err := spend(out, now)
+		if err != nil {
+			// We couldn't spend this UTXO, so we skip to the next one
+			continue
+		}
+		in, ok := inIntf.(avax.TransferableIn)
+		if !ok {
+			// Because we only use the secp Fx right now, this should never
+			// happen
+			continue
+		}
+
+		// The remaining value is initially the full value of the input
+		remainingValue := in.Amount()
+
+		// Burn any value that should be burned
+		amountToBurn := safemath.Min64(
+			fee-amountBurned, // Amount we still need to burn
+			remainingValue,   // Amount available to burn
+		)
+		amountBurned += amountToBurn
+		remainingValue -= amountToBurn
+
+		// Stake any value that should be staked
+		amountToStake := safemath.Min64(
+			amount-amountStaked, // Amount we still need to stake
+			remainingValue,      // Amount available to stake
+		)
+		amountStaked += amountToStake
+		remainingValue -= amountToStake
+
+		// Add the input to the consumed inputs
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: b.AVAXAssetID},
+			In:     in,
+		})
+
+		if amountToStake > 0 {
+			// Some of this input was put for staking
+			stakedOuts = append(stakedOuts, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: b.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amountToStake,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Locktime:  0,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{changeAddr},
+					},
+				},
+			})
+		}
+
+		if remainingValue > 0 {
+			// This input had extra value, so some of it must be returned
+			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: b.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: remainingValue,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Locktime:  0,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{changeAddr},
+					},
+				},
+			})
+		}
+
+		// Add the signers needed for this input to the set of signers
+		signers = append(signers, inSigners)
+	}
+
+	if amountBurned < fee || amountStaked < amount {
+		return nil, nil, nil, nil, fmt.Errorf(
+			"provided keys have balance (unlocked, locked) (%d, %d) but need (%d, %d)",
+			amountBurned, amountStaked, fee, amount)
+	}
+
+	avax.SortTransferableInputsWithSigners(ins, signers) // sort inputs and keys
+	avax.SortTransferableOutputs(returnedOuts, b.Codec)  // sort outputs
+	avax.SortTransferableOutputs(stakedOuts, b.Codec)    // sort outputs
+
+	return ins, returnedOuts, stakedOuts, signers, nil
+}
+
+// Authorize an operation on behalf of a subnet owned by [owner] with the keys
+// in [kc]
+func (b *Builder) Authorize(
+	owner *secp256k1fx.OutputOwners,
+	kc *secp256k1fx.Keychain,
+) (
+	verify.Verifiable, // Input that names owners
+	[]*crypto.PrivateKeySECP256K1R, // Keys that prove ownership
+	error,
+) {
+	// Attempt to prove ownership of the subnet
+	indices, signers, matches := kc.Match(owner, b.now())
+	if !matches {
+		return nil, nil, errCantSign
+	}
+	return &secp256k1fx.Input{SigIndices: indices}, signers, nil
+}
@@ -0,0 +1,229 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	// MaxTxSize is the largest transaction the codecs of the chains serialize
+	MaxTxSize = 1 << 18
+
+	// SpendSizeLimit is the number of bytes that the inputs selected to fund a
+	// transaction, and their credentials, may take up. The rest of
+	// [MaxTxSize] is left for the outputs, the memo and the operations of the
+	// transaction.
+	SpendSizeLimit = MaxTxSize - 1<<14
+)
+
+var (
+	errSpendOverflow = errors.New("spent amount overflows uint64")
+	errSpendTooLarge = errors.New("spending requires more UTXOs than fit in a transaction. Consolidate the UTXOs first")
+)
+
+// SpendableUTXO is a UTXO that holds an amount and can be spent now
+type SpendableUTXO struct {
+	UTXO *avax.UTXO
+	In   avax.TransferableIn
+	// Keys that sign [In], if they are known
+	Signers []*crypto.PrivateKeySECP256K1R
+}
+
+// Amount returns the amount the UTXO holds
+func (s *SpendableUTXO) Amount() uint64 { return s.In.Amount() }
+
+// Input returns the input that spends the UTXO
+func (s *SpendableUTXO) Input() *avax.TransferableInput {
+	return &avax.TransferableInput{
+		UTXOID: s.UTXO.UTXOID,
+		Asset:  avax.Asset{ID: s.UTXO.AssetID()},
+		In:     s.In,
+	}
+}
+
+// Size returns the number of bytes that spending the UTXO adds to a
+// transaction
+func (s *SpendableUTXO) Size() int {
+	if in, ok := s.In.(*secp256k1fx.TransferInput); ok {
+		return InputSize(len(in.SigIndices))
+	}
+	return InputSize(1)
+}
+
+// InputSize returns the number of bytes that an input signed by [numSigs]
+// keys adds to a transaction, including its credential. An input is the UTXO
+// ID (36 bytes), the asset ID (32 bytes), the type ID (4 bytes), the amount
+// (8 bytes) and the signature indices (4 bytes and 4 bytes per signature). Its
+// credential is the type ID (4 bytes) and the signatures (4 bytes and 65 bytes
+// per signature).
+func InputSize(numSigs int) int { return 92 + 69*numSigs }
+
+// KeychainSpendableUTXOs returns the UTXOs in [utxos] that [kc] can spend at
+// [time], grouped by asset
+func KeychainSpendableUTXOs(utxos []*avax.UTXO, kc *secp256k1fx.Keychain, time uint64) map[ids.ID][]*SpendableUTXO {
+	spendable := make(map[ids.ID][]*SpendableUTXO)
+	for _, utxo := range utxos {
+		inputIntf, signers, err := kc.Spend(utxo.Out, time)
+		if err != nil {
+			// this utxo can't be spent with the current keys right now
+			continue
+		}
+		input, ok := inputIntf.(avax.TransferableIn)
+		if !ok {
+			// this input doesn't have an amount, so I don't care about it here
+			continue
+		}
+		assetID := utxo.AssetID()
+		spendable[assetID] = append(spendable[assetID], &SpendableUTXO{
+			UTXO:    utxo,
+			In:      input,
+			Signers: signers,
+		})
+	}
+	return spendable
+}
+
+// AddressSpendableUTXOs returns the UTXOs in [utxos] that [addrs] can spend at
+// [time], grouped by asset
+func AddressSpendableUTXOs(utxos []*avax.UTXO, addrs ids.ShortSet, time uint64) map[ids.ID][]*SpendableUTXO {
+	spendable := make(map[ids.ID][]*SpendableUTXO)
+	for _, utxo := range utxos {
+		inputIntf, err := secp256k1fx.SpendWithAddresses(utxo.Out, addrs, time)
+		if err != nil {
+			// this utxo can't be spent by these addresses right now
+			continue
+		}
+		input, ok := inputIntf.(avax.TransferableIn)
+		if !ok {
+			// this input doesn't have an amount, so I don't care about it here
+			continue
+		}
+		assetID := utxo.AssetID()
+		spendable[assetID] = append(spendable[assetID], &SpendableUTXO{
+			UTXO: utxo,
+			In:   input,
+		})
+	}
+	return spendable
+}
+
+// SortByAmount sorts [utxos] by the amount they hold, largest first if
+// [descending] is true and smallest first otherwise. UTXOs that hold the same
+// amount are sorted by ID, so the order is deterministic.
+func SortByAmount(utxos []*SpendableUTXO, descending bool) {
+	sort.Slice(utxos, func(i, j int) bool {
+		iAmount, jAmount := utxos[i].Amount(), utxos[j].Amount()
+		if iAmount != jAmount {
+			return (iAmount > jAmount) == descending
+		}
+		iID, jID := utxos[i].UTXO.InputID(), utxos[j].UTXO.InputID()
+		return bytes.Compare(iID[:], jID[:]) < 0
+	})
+}
+
+// selectAssetUTXOs returns UTXOs out of [candidates], which all hold the same
+// asset, that hold at least [amount] in total, and the amount they hold. If one
+// UTXO holds enough, the smallest such UTXO is selected, so that large UTXOs
+// are kept for large payments and the change is small. Otherwise, the largest
+// UTXOs are selected first, so that as few inputs as possible are needed. If
+// [candidates] don't hold [amount], all of them are returned.
+func selectAssetUTXOs(candidates []*SpendableUTXO, amount uint64) ([]*SpendableUTXO, uint64, error) {
+	sorted := make([]*SpendableUTXO, len(candidates))
+	copy(sorted, candidates)
+	SortByAmount(sorted, true)
+
+	// [sorted[i-1]] is the smallest UTXO that holds [amount] on its own
+	if i := sort.Search(len(sorted), func(i int) bool { return sorted[i].Amount() < amount }); i > 0 {
+		return sorted[i-1 : i], sorted[i-1].Amount(), nil
+	}
+
+	amountSpent := uint64(0)
+	for i, utxo := range sorted {
+		newAmountSpent, err := safemath.Add64(amountSpent, utxo.Amount())
+		if err != nil {
+			return nil, 0, errSpendOverflow
+		}
+		amountSpent = newAmountSpent
+		if amountSpent >= amount {
+			return sorted[:i+1], amountSpent, nil
+		}
+	}
+	return sorted, amountSpent, nil
+}
+
+// SelectUTXOs returns UTXOs out of [candidates] that hold at least [amounts],
+// and the amount of each asset they hold. The selected UTXOs can be spent in
+// one transaction.
+func SelectUTXOs(candidates map[ids.ID][]*SpendableUTXO, amounts map[ids.ID]uint64) (map[ids.ID]uint64, []*SpendableUTXO, error) {
+	assetIDs := make([]ids.ID, 0, len(amounts))
+	for assetID := range amounts {
+		assetIDs = append(assetIDs, assetID)
+	}
+	ids.SortIDs(assetIDs)
+
+	amountsSpent := make(map[ids.ID]uint64, len(amounts))
+	selected := []*SpendableUTXO(nil)
+	size := 0
+	for _, assetID := range assetIDs {
+		amount := amounts[assetID]
+		if amount == 0 {
+			// we don't need any inputs for this asset
+			continue
+		}
+		assetUTXOs, amountSpent, err := selectAssetUTXOs(candidates[assetID], amount)
+		if err != nil {
+			return nil, nil, err
+		}
+		if amountSpent < amount {
+			return nil, nil, fmt.Errorf("want to spend %d of asset %s but only have %d",
+				amount,
+				assetID,
+				amountSpent,
+			)
+		}
+		for _, utxo := range assetUTXOs {
+			size += utxo.Size()
+		}
+		if size > SpendSizeLimit {
+			return nil, nil, errSpendTooLarge
+		}
+		amountsSpent[assetID] = amountSpent
+		selected = append(selected, assetUTXOs...)
+	}
+	return amountsSpent, selected, nil
+}
+
+// ChangeOutputs returns the outputs that send to [changeAddr] the amount of
+// each asset that was spent beyond [amounts]
+func ChangeOutputs(amountsSpent, amounts map[ids.ID]uint64, changeAddr ids.ShortID) []*avax.TransferableOutput {
+	outs := []*avax.TransferableOutput{}
+	for assetID, amountSpent := range amountsSpent {
+		amount := amounts[assetID]
+		if amountSpent > amount {
+			outs = append(outs, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amountSpent - amount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Locktime:  0,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{changeAddr},
+					},
+				},
+			})
+		}
+	}
+	return outs
+}
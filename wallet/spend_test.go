@@ -0,0 +1,80 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSpendableUTXOs(assetID ids.ID, amounts ...uint64) []*SpendableUTXO {
+	utxos := make([]*SpendableUTXO, len(amounts))
+	for i, amount := range amounts {
+		utxos[i] = &SpendableUTXO{
+			UTXO: newTestUTXO(ids.GenerateTestID(), assetID, amount, ids.ShortEmpty),
+			In: &secp256k1fx.TransferInput{
+				Amt:   amount,
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		}
+	}
+	return utxos
+}
+
+func TestSelectAssetUTXOs(t *testing.T) {
+	candidates := testSpendableUTXOs(ids.GenerateTestID(), 50, 5, 100, 10)
+
+	tests := []struct {
+		amount          uint64
+		expectedAmounts []uint64
+	}{
+		// The smallest UTXO that holds enough on its own
+		{amount: 8, expectedAmounts: []uint64{10}},
+		{amount: 100, expectedAmounts: []uint64{100}},
+		// The largest UTXOs first
+		{amount: 120, expectedAmounts: []uint64{100, 50}},
+		{amount: 160, expectedAmounts: []uint64{100, 50, 10}},
+		// Everything if there isn't enough
+		{amount: 200, expectedAmounts: []uint64{100, 50, 10, 5}},
+	}
+	for _, test := range tests {
+		selected, amountSpent, err := selectAssetUTXOs(candidates, test.amount)
+		assert.NoError(t, err)
+
+		amounts := make([]uint64, len(selected))
+		total := uint64(0)
+		for i, utxo := range selected {
+			amounts[i] = utxo.Amount()
+			total += utxo.Amount()
+		}
+		assert.Equal(t, test.expectedAmounts, amounts, "amount %d", test.amount)
+		assert.Equal(t, total, amountSpent, "amount %d", test.amount)
+	}
+}
+
+func TestSelectUTXOs(t *testing.T) {
+	assetID := ids.GenerateTestID()
+	amounts := make([]uint64, SpendSizeLimit/InputSize(1)+1)
+	for i := range amounts {
+		amounts[i] = 1
+	}
+	candidates := map[ids.ID][]*SpendableUTXO{
+		assetID: testSpendableUTXOs(assetID, amounts...),
+	}
+
+	amountsSpent, selected, err := SelectUTXOs(candidates, map[ids.ID]uint64{assetID: 100})
+	assert.NoError(t, err)
+	assert.Len(t, selected, 100)
+	assert.Equal(t, uint64(100), amountsSpent[assetID])
+
+	// Spending every UTXO doesn't fit in a transaction
+	_, _, err = SelectUTXOs(candidates, map[ids.ID]uint64{assetID: uint64(len(amounts))})
+	assert.Equal(t, errSpendTooLarge, err)
+
+	_, _, err = SelectUTXOs(candidates, map[ids.ID]uint64{assetID: uint64(len(amounts)) + 1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package wallet selects the UTXOs that fund X-Chain and P-Chain transactions,
+// and builds their inputs, outputs and operations along with the keys that sign
+// them. It doesn't talk to a node, use a database or depend on the VMs, so the
+// UTXOs must be supplied by the caller. The VMs put what it builds in their
+// transactions, both in their APIs and in the builders they export for use
+// without a node.
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var errNoKeys = errors.New("the wallet doesn't hold any keys")
+
+// Wallet holds keys and the UTXOs they can spend
+type Wallet struct {
+	keychain *secp256k1fx.Keychain // Mapping from public address to the SigningKeys
+	utxoSet  *UTXOSet              // Mapping from utxoIDs to UTXOs
+
+	balance map[ids.ID]uint64
+}
+
+// NewWallet returns a wallet that doesn't hold any keys or UTXOs
+func NewWallet() *Wallet {
+	return &Wallet{
+		keychain: secp256k1fx.NewKeychain(),
+		utxoSet:  &UTXOSet{},
+		balance:  make(map[ids.ID]uint64),
+	}
+}
+
+// Keychain returns the keys this wallet signs with
+func (w *Wallet) Keychain() *secp256k1fx.Keychain { return w.keychain }
+
+// ImportKey imports a private key into this wallet
+func (w *Wallet) ImportKey(sk *crypto.PrivateKeySECP256K1R) { w.keychain.Add(sk) }
+
+// GetAddress returns the address that change is sent to, which is one of the
+// addresses this wallet manages
+func (w *Wallet) GetAddress() (ids.ShortID, error) {
+	if w.keychain.Addrs.Len() == 0 {
+		return ids.ShortID{}, errNoKeys
+	}
+	return w.keychain.Addrs.CappedList(1)[0], nil
+}
+
+// CreateAddress returns a new address.
+// It also saves the address and the private key that controls it
+// so the address can be used later
+func (w *Wallet) CreateAddress() (ids.ShortID, error) {
+	privKey, err := w.keychain.New()
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	return privKey.PublicKey().Address(), nil
+}
+
+// AddUTXO adds [utxo] to this wallet if the keys of this wallet can spend it,
+// now or once it's unlocked. Returns true if the UTXO was added.
+func (w *Wallet) AddUTXO(utxo *avax.UTXO) bool {
+	if !w.owns(utxo.Out) {
+		return false
+	}
+
+	utxoID := utxo.InputID()
+	if w.utxoSet.Get(utxoID) != nil {
+		return true
+	}
+	w.utxoSet.Put(utxo)
+	if out, ok := utxo.Out.(avax.TransferableOut); ok {
+		w.balance[utxo.AssetID()] += out.Amount()
+	}
+	return true
+}
+
+// owns returns true if the keys of this wallet can spend [out] at some time
+func (w *Wallet) owns(out verify.Verifiable) bool {
+	var owners *secp256k1fx.OutputOwners
+	switch out := out.(type) {
+	case *stakeable.LockOut:
+		return w.owns(out.TransferableOut)
+	case *secp256k1fx.TransferOutput:
+		owners = &out.OutputOwners
+	case *secp256k1fx.MintOutput:
+		owners = &out.OutputOwners
+	case *nftfx.TransferOutput:
+		owners = &out.OutputOwners
+	case *nftfx.MintOutput:
+		owners = &out.OutputOwners
+	case *propertyfx.OwnedOutput:
+		owners = &out.OutputOwners
+	case *propertyfx.MintOutput:
+		owners = &out.OutputOwners
+	default:
+		return false
+	}
+	_, _, ok := w.keychain.Match(owners, math.MaxUint64)
+	return ok
+}
+
+// RemoveUTXO removes the UTXO [utxoID] from this wallet
+func (w *Wallet) RemoveUTXO(utxoID ids.ID) {
+	utxo := w.utxoSet.Remove(utxoID)
+	if utxo == nil {
+		return
+	}
+
+	out, ok := utxo.Out.(avax.TransferableOut)
+	if !ok {
+		return
+	}
+	assetID := utxo.AssetID()
+	newBalance := w.balance[assetID] - out.Amount()
+	if newBalance == 0 {
+		delete(w.balance, assetID)
+	} else {
+		w.balance[assetID] = newBalance
+	}
+}
+
+// UTXOs returns the UTXOs in this wallet. The returned slice must not be
+// modified.
+func (w *Wallet) UTXOs() []*avax.UTXO { return w.utxoSet.UTXOs }
+
+// Balance returns the amount of [assetID] that the UTXOs in this wallet hold,
+// including the amount that is still locked
+func (w *Wallet) Balance(assetID ids.ID) uint64 { return w.balance[assetID] }
+
+// Accept removes the UTXOs consumed by a transaction from this wallet and adds
+// the UTXOs it produces that this wallet owns
+func (w *Wallet) Accept(consumed []*avax.UTXOID, produced []*avax.UTXO) {
+	for _, utxoID := range consumed {
+		w.RemoveUTXO(utxoID.InputID())
+	}
+	for _, utxo := range produced {
+		w.AddUTXO(utxo)
+	}
+}
+
+func (w *Wallet) String() string {
+	return fmt.Sprintf(
+		"Keychain:\n"+
+			"%s\n"+
+			"%s",
+		w.keychain.PrefixedString("    "),
+		w.utxoSet.PrefixedString("    "),
+	)
+}
@@ -0,0 +1,134 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var testAVAXAssetID = ids.ID{'a', 'v', 'a', 'x'}
+
+func newTestKey(t *testing.T) *crypto.PrivateKeySECP256K1R {
+	factory := crypto.FactorySECP256K1R{}
+	sk, err := factory.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sk.(*crypto.PrivateKeySECP256K1R)
+}
+
+func newTestUTXO(txID ids.ID, assetID ids.ID, amount uint64, owner ids.ShortID) *avax.UTXO {
+	return &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: txID},
+		Asset:  avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{owner},
+			},
+		},
+	}
+}
+
+// newTestBuilder returns a builder whose codec serializes the secp256k1fx
+// types and the stakeable locks
+func newTestBuilder(t *testing.T) *Builder {
+	c := codec.NewDefault()
+	if err := (&secp256k1fx.Fx{}).Initialize(&secp256k1fx.TestVM{
+		Codec: c,
+		Log:   logging.NoLog{},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RegisterType(&stakeable.LockIn{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RegisterType(&stakeable.LockOut{}); err != nil {
+		t.Fatal(err)
+	}
+	m := codec.NewDefaultManager()
+	if err := m.RegisterCodec(0, c); err != nil {
+		t.Fatal(err)
+	}
+	return &Builder{
+		AVAXAssetID: testAVAXAssetID,
+		Codec:       m,
+	}
+}
+
+func TestWalletAddUTXO(t *testing.T) {
+	w := NewWallet()
+	key := newTestKey(t)
+	w.ImportKey(key)
+
+	owned := newTestUTXO(ids.Empty.Prefix(0), testAVAXAssetID, 1000, key.PublicKey().Address())
+	if !w.AddUTXO(owned) {
+		t.Fatalf("should have added a UTXO owned by the wallet")
+	}
+	// Adding the same UTXO twice doesn't change the balance
+	w.AddUTXO(owned)
+	notOwned := newTestUTXO(ids.Empty.Prefix(1), testAVAXAssetID, 1000, ids.ShortEmpty)
+	if w.AddUTXO(notOwned) {
+		t.Fatalf("shouldn't have added a UTXO that isn't owned by the wallet")
+	}
+	// Locked UTXOs are owned, as they can be spent once they're unlocked
+	locked := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.Empty.Prefix(2)},
+		Asset:  avax.Asset{ID: testAVAXAssetID},
+		Out: &stakeable.LockOut{
+			Locktime:        1,
+			TransferableOut: owned.Out.(*secp256k1fx.TransferOutput),
+		},
+	}
+	if !w.AddUTXO(locked) {
+		t.Fatalf("should have added a locked UTXO owned by the wallet")
+	}
+	if balance := w.Balance(testAVAXAssetID); balance != 2000 {
+		t.Fatalf("expected balance to be 2000, was %d", balance)
+	}
+
+	w.RemoveUTXO(owned.InputID())
+	w.RemoveUTXO(locked.InputID())
+	if balance := w.Balance(testAVAXAssetID); balance != 0 {
+		t.Fatalf("expected balance to be 0, was %d", balance)
+	}
+}
+
+func TestWalletNoKeys(t *testing.T) {
+	w := NewWallet()
+	if _, err := w.GetAddress(); err != errNoKeys {
+		t.Fatalf("expected %s but got %v", errNoKeys, err)
+	}
+}
+
+func TestWalletAccept(t *testing.T) {
+	w := NewWallet()
+	key := newTestKey(t)
+	w.ImportKey(key)
+	addr := key.PublicKey().Address()
+
+	spent := newTestUTXO(ids.Empty.Prefix(0), testAVAXAssetID, 1000, addr)
+	w.AddUTXO(spent)
+
+	change := newTestUTXO(ids.Empty.Prefix(1), testAVAXAssetID, 900, addr)
+	sent := newTestUTXO(ids.Empty.Prefix(1), testAVAXAssetID, 90, ids.ShortEmpty)
+	sent.OutputIndex = 1
+	w.Accept([]*avax.UTXOID{&spent.UTXOID}, []*avax.UTXO{change, sent})
+
+	if balance := w.Balance(testAVAXAssetID); balance != 900 {
+		t.Fatalf("expected balance to be 900, was %d", balance)
+	}
+	if numUTXOs := len(w.UTXOs()); numUTXOs != 1 {
+		t.Fatalf("expected only the change to be in the wallet but it has %d UTXOs", numUTXOs)
+	}
+}
@@ -0,0 +1,227 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errNoSpendableNFT = errors.New("the provided keys can't spend an NFT of the group")
+	errCantMint       = errors.New("provided addresses don't have the authority to mint the provided asset")
+)
+
+// FxOperation is an operation that an fx performs on UTXOs
+type FxOperation interface {
+	verify.Verifiable
+
+	Outs() []verify.State
+}
+
+// Operation is an fx operation on the UTXOs [UTXOIDs], which hold [Asset].
+// The X-Chain performs it as part of an OperationTx.
+type Operation struct {
+	Asset   avax.Asset
+	UTXOIDs []*avax.UTXOID
+	Op      FxOperation
+}
+
+// Mint returns the operations that mint [amounts] of assets to [owners] with
+// the mint outputs in [utxos], and the keys that sign them. The operations
+// aren't sorted.
+func (b *Builder) Mint(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	amounts map[ids.ID]uint64,
+	owners secp256k1fx.OutputOwners,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	time := b.now()
+
+	ops := []*Operation{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+
+	// The amounts that are still to be minted
+	remaining := make(map[ids.ID]uint64, len(amounts))
+	for assetID, amount := range amounts {
+		remaining[assetID] = amount
+	}
+
+	for _, utxo := range utxos {
+		// makes sure that the variable isn't overwritten with the next iteration
+		utxo := utxo
+
+		assetID := utxo.AssetID()
+		amount := remaining[assetID]
+		if amount == 0 {
+			continue
+		}
+
+		out, ok := utxo.Out.(*secp256k1fx.MintOutput)
+		if !ok {
+			continue
+		}
+
+		inIntf, signers, err := kc.Spend(out, time)
+		if err != nil {
+			continue
+		}
+
+		in, ok := inIntf.(*secp256k1fx.Input)
+		if !ok {
+			continue
+		}
+
+		// add the operation to the array
+		ops = append(ops, &Operation{
+			Asset:   utxo.Asset,
+			UTXOIDs: []*avax.UTXOID{&utxo.UTXOID},
+			Op: &secp256k1fx.MintOperation{
+				MintInput:  *in,
+				MintOutput: *out,
+				TransferOutput: secp256k1fx.TransferOutput{
+					Amt:          amount,
+					OutputOwners: owners,
+				},
+			},
+		})
+		// add the required keys to the array
+		keys = append(keys, signers)
+
+		// remove the asset from the required amounts to mint
+		delete(remaining, assetID)
+	}
+
+	for _, amount := range remaining {
+		if amount > 0 {
+			return nil, nil, errCantMint
+		}
+	}
+	return ops, keys, nil
+}
+
+// SpendNFT returns the operation that sends an NFT of [assetID] in [groupID]
+// out of [utxos] to [to], and the keys that sign it
+func (b *Builder) SpendNFT(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	groupID uint32,
+	to ids.ShortID,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	time := b.now()
+
+	for _, utxo := range utxos {
+		// makes sure that the variable isn't overwritten with the next iteration
+		utxo := utxo
+
+		if utxo.AssetID() != assetID {
+			// wrong asset ID
+			continue
+		}
+		out, ok := utxo.Out.(*nftfx.TransferOutput)
+		if !ok {
+			// wrong output type
+			continue
+		}
+		if out.GroupID != groupID {
+			// wrong group id
+			continue
+		}
+		indices, signers, ok := kc.Match(&out.OutputOwners, time)
+		if !ok {
+			// unable to spend the output
+			continue
+		}
+
+		return []*Operation{{
+			Asset:   utxo.Asset,
+			UTXOIDs: []*avax.UTXOID{&utxo.UTXOID},
+			Op: &nftfx.TransferOperation{
+				Input: secp256k1fx.Input{
+					SigIndices: indices,
+				},
+				Output: nftfx.TransferOutput{
+					GroupID: out.GroupID,
+					Payload: out.Payload,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{to},
+					},
+				},
+			},
+		}}, [][]*crypto.PrivateKeySECP256K1R{signers}, nil
+	}
+	return nil, nil, errNoSpendableNFT
+}
+
+// MintNFT returns the operation that mints an NFT of [assetID] that holds
+// [payload] to [to] with a mint output in [utxos], and the keys that sign it
+func (b *Builder) MintNFT(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	payload []byte,
+	to ids.ShortID,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	time := b.now()
+
+	for _, utxo := range utxos {
+		// makes sure that the variable isn't overwritten with the next iteration
+		utxo := utxo
+
+		if utxo.AssetID() != assetID {
+			// wrong asset id
+			continue
+		}
+		out, ok := utxo.Out.(*nftfx.MintOutput)
+		if !ok {
+			// wrong output type
+			continue
+		}
+
+		indices, signers, ok := kc.Match(&out.OutputOwners, time)
+		if !ok {
+			// unable to spend the output
+			continue
+		}
+
+		return []*Operation{{
+			Asset: avax.Asset{ID: assetID},
+			UTXOIDs: []*avax.UTXOID{
+				&utxo.UTXOID,
+			},
+			Op: &nftfx.MintOperation{
+				MintInput: secp256k1fx.Input{
+					SigIndices: indices,
+				},
+				GroupID: out.GroupID,
+				Payload: payload,
+				Outputs: []*secp256k1fx.OutputOwners{{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{to},
+				}},
+			},
+		}}, [][]*crypto.PrivateKeySECP256K1R{signers}, nil
+	}
+	return nil, nil, errCantMint
+}
@@ -15,7 +15,7 @@ import (
 
 func TestNewWallet(t *testing.T) {
 	chainID := ids.ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	w, err := NewWallet(logging.NoLog{}, 12345, chainID, 0)
+	w, err := NewWallet(logging.NoLog{}, 12345, chainID, ids.Empty, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -26,7 +26,7 @@ func TestNewWallet(t *testing.T) {
 
 func TestWalletGetAddress(t *testing.T) {
 	chainID := ids.ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	w, err := NewWallet(logging.NoLog{}, 12345, chainID, 0)
+	w, err := NewWallet(logging.NoLog{}, 12345, chainID, ids.Empty, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -42,7 +42,7 @@ func TestWalletGetAddress(t *testing.T) {
 
 func TestWalletGetMultipleAddresses(t *testing.T) {
 	chainID := ids.ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	w, err := NewWallet(logging.NoLog{}, 12345, chainID, 0)
+	w, err := NewWallet(logging.NoLog{}, 12345, chainID, ids.Empty, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -62,7 +62,7 @@ func TestWalletGetMultipleAddresses(t *testing.T) {
 
 func TestWalletEmptyBalance(t *testing.T) {
 	chainID := ids.ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	w, err := NewWallet(logging.NoLog{}, 12345, chainID, 0)
+	w, err := NewWallet(logging.NoLog{}, 12345, chainID, ids.Empty, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -74,7 +74,7 @@ func TestWalletEmptyBalance(t *testing.T) {
 
 func TestWalletAddUTXO(t *testing.T) {
 	chainID := ids.ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	w, err := NewWallet(logging.NoLog{}, 12345, chainID, 0)
+	w, err := NewWallet(logging.NoLog{}, 12345, chainID, ids.Empty, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -96,7 +96,7 @@ func TestWalletAddUTXO(t *testing.T) {
 
 func TestWalletAddInvalidUTXO(t *testing.T) {
 	chainID := ids.ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	w, err := NewWallet(logging.NoLog{}, 12345, chainID, 0)
+	w, err := NewWallet(logging.NoLog{}, 12345, chainID, ids.Empty, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -115,7 +115,7 @@ func TestWalletAddInvalidUTXO(t *testing.T) {
 
 func TestWalletCreateTx(t *testing.T) {
 	chainID := ids.ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	w, err := NewWallet(logging.NoLog{}, 12345, chainID, 0)
+	w, err := NewWallet(logging.NoLog{}, 12345, chainID, ids.Empty, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -165,7 +165,7 @@ func TestWalletCreateTx(t *testing.T) {
 
 func TestWalletImportKey(t *testing.T) {
 	chainID := ids.ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	w, err := NewWallet(logging.NoLog{}, 12345, chainID, 0)
+	w, err := NewWallet(logging.NoLog{}, 12345, chainID, ids.Empty, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -190,7 +190,7 @@ func TestWalletImportKey(t *testing.T) {
 
 func TestWalletString(t *testing.T) {
 	chainID := ids.ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	w, err := NewWallet(logging.NoLog{}, 12345, chainID, 0)
+	w, err := NewWallet(logging.NoLog{}, 12345, chainID, ids.Empty, 0)
 	if err != nil {
 		t.Fatal(err)
 	}